@@ -0,0 +1,159 @@
+package audit
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/metrics"
+)
+
+// queueSize is the number of audit events which can wait to be written to the sink before the events are dropped.
+const queueSize = 1024
+
+var (
+	errQueueFull = errors.New("the audit log queue is full")
+	errClosed    = errors.New("the audit log is closed")
+)
+
+// NewLogger creates a Logger which writes the audit events to the sink of the configuration.
+func NewLogger(config *schema.AuditConfiguration, certPool *x509.CertPool) (logger *Logger, err error) {
+	var s sink
+
+	switch {
+	case config.File != nil:
+		s, err = newFileSink(config.File)
+	case config.Syslog != nil:
+		s = newSyslogSink(config.Syslog)
+	case config.HTTP != nil:
+		s = newHTTPSink(config.HTTP, certPool)
+	default:
+		return nil, fmt.Errorf("audit log has no sink configured")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return newLogger(s, queueSize), nil
+}
+
+func newLogger(s sink, size int) *Logger {
+	logger := &Logger{
+		sink:   s,
+		events: make(chan queuedEvent, size),
+		done:   make(chan struct{}),
+	}
+
+	go logger.run()
+
+	return logger
+}
+
+// Logger writes the audit events to a sink separately from the application log. The events are written by a worker
+// from a bounded queue so a slow sink never delays the requests which caused them.
+type Logger struct {
+	sink sink
+
+	mutex  sync.RWMutex
+	closed bool
+	events chan queuedEvent
+	done   chan struct{}
+
+	dropped uint64
+}
+
+// queuedEvent is an audit event waiting to be written along with its JSON representation.
+type queuedEvent struct {
+	event Event
+	data  []byte
+}
+
+// Log queues the audit event to be written to the sink. The version, ID and time of the event are set when they're
+// not. The event is dropped when the queue is full, in which case an error is returned and the event is counted by
+// Dropped.
+func (l *Logger) Log(event Event) (err error) {
+	event.Version = SchemaVersion
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	event.Time = event.Time.UTC()
+
+	if event.Actor.Type == "" {
+		event.Actor.Type = ActorAnonymous
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	if l.closed {
+		return errClosed
+	}
+
+	select {
+	case l.events <- queuedEvent{event: event, data: data}:
+		return nil
+	default:
+		atomic.AddUint64(&l.dropped, 1)
+		metrics.RecordAuditEventDropped()
+
+		return errQueueFull
+	}
+}
+
+// Dropped returns the number of audit events which were dropped because the queue was full.
+func (l *Logger) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// run writes the queued events to the sink until the queue is closed. The events which can't be written are logged
+// as nothing else can be done about them.
+func (l *Logger) run() {
+	defer close(l.done)
+
+	for queued := range l.events {
+		if err := l.sink.write(queued.event, queued.data); err != nil {
+			logging.Logger().Errorf("Unable to write the %s audit event %s: %+v", queued.event.Type, queued.event.ID, err)
+		}
+	}
+}
+
+// Close writes the events which are still queued and closes the sink. The events logged afterwards are rejected.
+func (l *Logger) Close() error {
+	l.mutex.Lock()
+
+	if l.closed {
+		l.mutex.Unlock()
+
+		return nil
+	}
+
+	l.closed = true
+
+	close(l.events)
+
+	l.mutex.Unlock()
+
+	<-l.done
+
+	return l.sink.close()
+}
@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+func TestShouldWriteEventsToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	logger, err := NewLogger(&schema.AuditConfiguration{File: &schema.AuditFileConfiguration{Path: path}}, nil)
+	require.NoError(t, err)
+
+	now := time.Date(2021, 11, 2, 10, 20, 30, 0, time.FixedZone("", 3600))
+
+	require.NoError(t, logger.Log(Event{
+		Time:    now,
+		Type:    EventAuthenticationFirstFactor,
+		Outcome: OutcomeFailure,
+		Actor:   Actor{Type: ActorUser, ID: "john", IP: "192.168.0.10"},
+		Target:  Target{Type: TargetUser, ID: "john"},
+	}))
+	require.NoError(t, logger.Log(Event{Type: EventAuthorizationDenied, Outcome: OutcomeDenied, Target: Target{Type: TargetResource, ID: "https://admin.example.com/"}}))
+	require.NoError(t, logger.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	require.Len(t, lines, 2)
+
+	assert.Regexp(t, `^\{"version":1,"id":"[0-9a-f-]{36}","time":"2021-11-02T09:20:30Z","type":"authentication.first_factor",`+
+		`"outcome":"failure","actor":\{"type":"user","id":"john","ip":"192.168.0.10"\},"target":\{"type":"user","id":"john"\}\}$`, lines[0])
+
+	var event Event
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+
+	assert.Equal(t, SchemaVersion, event.Version)
+	assert.Equal(t, ActorAnonymous, event.Actor.Type)
+	assert.Equal(t, EventAuthorizationDenied, event.Type)
+	assert.False(t, event.Time.IsZero())
+}
+
+func TestShouldFailToOpenAuditFile(t *testing.T) {
+	_, err := NewLogger(&schema.AuditConfiguration{File: &schema.AuditFileConfiguration{Path: filepath.Join(t.TempDir(), "missing", "audit.log")}}, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to open the audit log file: ")
+}
+
+func TestShouldSendEventsToSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	logger, err := NewLogger(&schema.AuditConfiguration{Syslog: &schema.SyslogConfiguration{
+		Network: "udp", Address: conn.LocalAddr().String(), AppName: "authelia", Facility: "authpriv",
+	}}, nil)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	buf := make([]byte, 2048)
+
+	require.NoError(t, logger.Log(Event{Type: EventSecondFactorRegistered, Outcome: OutcomeSuccess}))
+
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "<85>1 "))
+	assert.Contains(t, string(buf[:n]), " second_factor.registered - {\"version\":1,")
+
+	require.NoError(t, logger.Log(Event{Type: EventAuthenticationSecondFactor, Outcome: OutcomeFailure}))
+
+	n, _, err = conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "<84>1 "))
+}
+
+func TestShouldPostEventsToHTTPEndpoint(t *testing.T) {
+	var (
+		body        []byte
+		contentType string
+		token       string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		contentType = r.Header.Get("Content-Type")
+		token = r.Header.Get("Authorization")
+
+		if strings.Contains(string(body), EventAdminAction) {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte("unavailable"))
+		}
+	}))
+
+	defer server.Close()
+
+	logger, err := NewLogger(&schema.AuditConfiguration{HTTP: &schema.AuditHTTPConfiguration{
+		URL:     server.URL,
+		Timeout: time.Second,
+		Headers: []schema.WebhookHeaderConfiguration{{Name: "Authorization", Value: "Bearer abc"}},
+		TLS:     &schema.TLSConfig{},
+	}}, nil)
+	require.NoError(t, err)
+
+	defer logger.Close()
+
+	hook := test.NewLocal(logging.Logger())
+
+	require.NoError(t, logger.Log(Event{Type: EventOpenIDConnectToken, Outcome: OutcomeSuccess, Actor: Actor{Type: ActorClient, ID: "grafana"}}))
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "Bearer abc", token)
+	assert.Contains(t, string(body), `"actor":{"type":"client","id":"grafana"}`)
+	assert.Nil(t, hook.LastEntry())
+
+	logger, err = NewLogger(&schema.AuditConfiguration{HTTP: &schema.AuditHTTPConfiguration{URL: server.URL, Timeout: time.Second, TLS: &schema.TLSConfig{}}}, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, logger.Log(Event{ID: "d1b6c5b2", Type: EventAdminAction, Outcome: OutcomeSuccess}))
+	require.NoError(t, logger.Close())
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, logrus.ErrorLevel, hook.LastEntry().Level)
+	assert.Equal(t, "Unable to write the admin.action audit event d1b6c5b2: audit log endpoint responded with status code 503: unavailable", hook.LastEntry().Message)
+}
+
+// blockingSink is a sink which blocks the writes until it's released.
+type blockingSink struct {
+	started chan struct{}
+	release chan struct{}
+	written []string
+}
+
+func (s *blockingSink) write(event Event, _ []byte) error {
+	s.started <- struct{}{}
+	<-s.release
+
+	s.written = append(s.written, event.ID)
+
+	return nil
+}
+
+func (s *blockingSink) close() error {
+	return nil
+}
+
+func TestShouldDropEventsWhenTheQueueIsFull(t *testing.T) {
+	s := &blockingSink{started: make(chan struct{}, 3), release: make(chan struct{})}
+	logger := newLogger(s, 1)
+
+	require.NoError(t, logger.Log(Event{ID: "a", Type: EventAdminAction}))
+
+	// The worker is writing the first event, the second one waits in the queue and the third one doesn't fit.
+	<-s.started
+
+	require.NoError(t, logger.Log(Event{ID: "b", Type: EventAdminAction}))
+	assert.EqualError(t, logger.Log(Event{ID: "c", Type: EventAdminAction}), "the audit log queue is full")
+	assert.Equal(t, uint64(1), logger.Dropped())
+
+	close(s.release)
+
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close())
+
+	assert.Equal(t, []string{"a", "b"}, s.written)
+	assert.EqualError(t, logger.Log(Event{ID: "d", Type: EventAdminAction}), "the audit log is closed")
+}
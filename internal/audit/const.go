@@ -0,0 +1,54 @@
+package audit
+
+// SchemaVersion is the version of the schema of the audit events. It's only incremented for changes which aren't
+// backwards compatible, such as removing or renaming a field or an event type.
+const SchemaVersion = 1
+
+// Event types.
+const (
+	EventAuthenticationFirstFactor  = "authentication.first_factor"
+	EventAuthenticationSecondFactor = "authentication.second_factor"
+	EventAuthenticationLogout       = "authentication.logout"
+	EventSecondFactorRegistered     = "second_factor.registered"
+	EventSecondFactorRemoved        = "second_factor.removed"
+	EventPasswordResetRequested     = "password_reset.requested"
+	EventPasswordResetCompleted     = "password_reset.completed"
+	EventOpenIDConnectConsent       = "oidc.consent"
+	EventOpenIDConnectToken         = "oidc.token"
+	EventAuthorizationDenied        = "authorization.denied"
+	EventAdminAction                = "admin.action"
+)
+
+// Event outcomes.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeDenied  = "denied"
+)
+
+// Actor types.
+const (
+	ActorAnonymous  = "anonymous"
+	ActorUser       = "user"
+	ActorClient     = "client"
+	ActorAdminToken = "admin_token"
+)
+
+// Target types.
+const (
+	TargetUser     = "user"
+	TargetClient   = "client"
+	TargetResource = "resource"
+)
+
+// Event details.
+const (
+	DetailMethod     = "method"
+	DetailAction     = "action"
+	DetailPolicy     = "policy"
+	DetailHTTPMethod = "http_method"
+	DetailGrantType  = "grant_type"
+	DetailScopes     = "scopes"
+	DetailRevoked    = "revoked"
+	DetailSessionID  = "session_id"
+)
@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func newFileSink(config *schema.AuditFileConfiguration) (*fileSink, error) {
+	file, err := os.OpenFile(config.Path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the audit log file: %w", err)
+	}
+
+	return &fileSink{file: file}, nil
+}
+
+// fileSink appends the audit events to a file, one JSON object per line.
+type fileSink struct {
+	mutex sync.Mutex
+	file  *os.File
+}
+
+func (s *fileSink) write(_ Event, data []byte) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, err = s.file.Write(append(data, '\n'))
+
+	return err
+}
+
+func (s *fileSink) close() error {
+	return s.file.Close()
+}
+
+func newSyslogSink(config *schema.SyslogConfiguration) *syslogSink {
+	return &syslogSink{writer: logging.NewSyslogWriter(*config)}
+}
+
+// syslogSink sends the audit events to a syslog server with the event type as the message ID. The events which weren't
+// successful have the warning severity, the others have the notice severity.
+type syslogSink struct {
+	writer *logging.SyslogWriter
+}
+
+func (s *syslogSink) write(event Event, data []byte) error {
+	severity := logging.SyslogSeverityNotice
+
+	if event.Outcome != OutcomeSuccess {
+		severity = logging.SyslogSeverityWarning
+	}
+
+	return s.writer.WriteMessage(severity, event.Type, data)
+}
+
+func (s *syslogSink) close() error {
+	return s.writer.Close()
+}
+
+func newHTTPSink(config *schema.AuditHTTPConfiguration, certPool *x509.CertPool) *httpSink {
+	return &httpSink{
+		config: config,
+		client: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: utils.NewTLSConfig(config.TLS, tls.VersionTLS12, certPool),
+			},
+		},
+	}
+}
+
+// httpSink posts each audit event as JSON to a HTTP endpoint.
+type httpSink struct {
+	config *schema.AuditHTTPConfiguration
+	client *http.Client
+}
+
+func (s *httpSink) write(_ Event, data []byte) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	for _, header := range s.config.Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("audit log endpoint responded with status code %d: %s", resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	return nil
+}
+
+func (s *httpSink) close() error {
+	s.client.CloseIdleConnections()
+
+	return nil
+}
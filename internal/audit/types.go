@@ -0,0 +1,41 @@
+package audit
+
+import (
+	"time"
+)
+
+// Event is an audit event. The JSON representation is a stable contract for the consumers of the audit log, fields are
+// only added to it unless the SchemaVersion is incremented.
+type Event struct {
+	Version int       `json:"version"`
+	ID      string    `json:"id"`
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Outcome string    `json:"outcome"`
+	Actor   Actor     `json:"actor"`
+	Target  Target    `json:"target"`
+	Reason  string    `json:"reason,omitempty"`
+	TraceID string    `json:"trace_id,omitempty"`
+
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// Actor is who caused an audit event.
+type Actor struct {
+	Type      string `json:"type"`
+	ID        string `json:"id,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+}
+
+// Target is what an audit event affected.
+type Target struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+}
+
+// sink writes the audit events serialized as JSON.
+type sink interface {
+	write(event Event, data []byte) error
+	close() error
+}
@@ -3,9 +3,11 @@ package authentication
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"net"
 	"strings"
+	"time"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/sirupsen/logrus"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -103,7 +106,9 @@ func (p *LDAPUserProvider) connect(userDN string, password string) (LDAPConnecti
 }
 
 // CheckUserPassword checks if provided password matches for the given user.
-func (p *LDAPUserProvider) CheckUserPassword(inputUsername string, password string) (bool, error) {
+func (p *LDAPUserProvider) CheckUserPassword(inputUsername string, password string) (valid bool, err error) {
+	defer p.observe("CheckUserPassword", time.Now(), &err)
+
 	conn, err := p.connect(p.configuration.User, p.configuration.Password)
 	if err != nil {
 		return false, err
@@ -138,6 +143,7 @@ type ldapUserProfile struct {
 	Emails      []string
 	DisplayName string
 	Username    string
+	Locale      string
 }
 
 func (p *LDAPUserProvider) resolveUsersFilter(inputUsername string) (filter string) {
@@ -188,6 +194,10 @@ func (p *LDAPUserProvider) getUserProfile(conn LDAPConnection, inputUsername str
 			userProfile.Emails = attr.Values
 		}
 
+		if p.configuration.LocaleAttribute != "" && attr.Name == p.configuration.LocaleAttribute {
+			userProfile.Locale = attr.Values[0]
+		}
+
 		if attr.Name == p.configuration.UsernameAttribute {
 			if len(attr.Values) != 1 {
 				return nil, fmt.Errorf("user '%s' cannot have multiple value for attribute '%s'",
@@ -229,7 +239,9 @@ func (p *LDAPUserProvider) resolveGroupsFilter(inputUsername string, profile *ld
 }
 
 // GetDetails retrieve the groups a user belongs to.
-func (p *LDAPUserProvider) GetDetails(inputUsername string) (*UserDetails, error) {
+func (p *LDAPUserProvider) GetDetails(inputUsername string) (details *UserDetails, err error) {
+	defer p.observe("GetDetails", time.Now(), &err)
+
 	conn, err := p.connect(p.configuration.User, p.configuration.Password)
 	if err != nil {
 		return nil, err
@@ -275,11 +287,14 @@ func (p *LDAPUserProvider) GetDetails(inputUsername string) (*UserDetails, error
 		DisplayName: profile.DisplayName,
 		Emails:      profile.Emails,
 		Groups:      groups,
+		Locale:      profile.Locale,
 	}, nil
 }
 
 // UpdatePassword update the password of the given user.
-func (p *LDAPUserProvider) UpdatePassword(inputUsername string, newPassword string) error {
+func (p *LDAPUserProvider) UpdatePassword(inputUsername string, newPassword string) (err error) {
+	defer p.observe("UpdatePassword", time.Now(), &err)
+
 	conn, err := p.connect(p.configuration.User, p.configuration.Password)
 	if err != nil {
 		return fmt.Errorf("unable to update password. Cause: %w", err)
@@ -323,3 +338,20 @@ func (p *LDAPUserProvider) UpdatePassword(inputUsername string, newPassword stri
 
 	return nil
 }
+
+// observe records the latency of an LDAP operation which started at the given time and whether it failed. Unknown
+// users and invalid credentials aren't failures of the LDAP server.
+func (p *LDAPUserProvider) observe(operation string, started time.Time, err *error) {
+	failure := *err
+
+	var errLDAP *ldap.Error
+
+	switch {
+	case errors.Is(failure, ErrUserNotFound):
+		failure = nil
+	case errors.As(failure, &errLDAP) && errLDAP.ResultCode == ldap.LDAPResultInvalidCredentials:
+		failure = nil
+	}
+
+	metrics.ObserveBackend(metrics.BackendLDAP, operation, time.Since(started), failure)
+}
@@ -68,6 +68,10 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		p.configuration.UsernameAttribute,
 	}
 
+	if p.configuration.LocaleAttribute != "" {
+		p.usersAttributes = append(p.usersAttributes, p.configuration.LocaleAttribute)
+	}
+
 	if p.configuration.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.configuration.AdditionalUsersDN + "," + p.configuration.BaseDN
 	} else {
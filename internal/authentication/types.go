@@ -6,4 +6,5 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+	Locale      string
 }
@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -26,8 +27,12 @@ func NewAccessControlRule(pos int, rule schema.ACLRule, networksMap map[string][
 		Resources: schemaResourcesToACL(rule.Resources),
 		Methods:   schemaMethodsToACL(rule.Methods),
 		Networks:  schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
-		Subjects:  schemaSubjectsToACL(rule.Subjects),
+		Countries: schemaCountriesToACL(rule.Networks),
+		ASNs:      schemaASNsToACL(rule.Networks),
+		Subjects:  schemaSubjectsToACL(rule.Subjects, networksMap, networksCacheMap),
 		Policy:    PolicyToLevel(rule.Policy),
+
+		MaxAuthenticationAge: schemaMaxAuthenticationAgeToACL(rule.MaxAuthenticationAge),
 	}
 }
 
@@ -38,8 +43,14 @@ type AccessControlRule struct {
 	Resources []AccessControlResource
 	Methods   []string
 	Networks  []*net.IPNet
+	Countries []string
+	ASNs      []uint
 	Subjects  []AccessControlSubjects
 	Policy    Level
+
+	// MaxAuthenticationAge is the maximum duration since the authentication level required by the Policy was
+	// completed, after which the user has to perform it again. A value of 0 means no maximum applies.
+	MaxAuthenticationAge time.Duration
 }
 
 // IsMatch returns true if all elements of an AccessControlRule match the object and subject.
@@ -109,8 +120,8 @@ func isMatchForMethods(object Object, acl *AccessControlRule) (match bool) {
 }
 
 func isMatchForNetworks(subject Subject, acl *AccessControlRule) (match bool) {
-	// If there are no networks in this rule then the network condition is a match.
-	if len(acl.Networks) == 0 {
+	// If there are no networks, countries or ASNs in this rule then the network condition is a match.
+	if len(acl.Networks) == 0 && len(acl.Countries) == 0 && len(acl.ASNs) == 0 {
 		return true
 	}
 
@@ -121,6 +132,18 @@ func isMatchForNetworks(subject Subject, acl *AccessControlRule) (match bool) {
 		}
 	}
 
+	if subject.Country != "" && utils.IsStringInSlice(subject.Country, acl.Countries) {
+		return true
+	}
+
+	if subject.ASN != 0 {
+		for _, asn := range acl.ASNs {
+			if asn == subject.ASN {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
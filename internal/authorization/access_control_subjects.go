@@ -1,10 +1,14 @@
 package authorization
 
 import (
+	"net"
+	"regexp"
+	"strings"
+
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
-// AccessControlSubject abstracts an ACL subject of type `group:` or `user:`.
+// AccessControlSubject abstracts an ACL subject of type `user:`, `group:`, `email:`, `oidc:` or `network:`.
 type AccessControlSubject interface {
 	IsMatch(subject Subject) (match bool)
 }
@@ -15,8 +19,8 @@ type AccessControlSubjects struct {
 }
 
 // AddSubject appends to the AccessControlSubjects based on a subject rule string.
-func (acs *AccessControlSubjects) AddSubject(subjectRule string) {
-	subject := schemaSubjectToACLSubject(subjectRule)
+func (acs *AccessControlSubjects) AddSubject(subjectRule string, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) {
+	subject := schemaSubjectToACLSubject(subjectRule, networksMap, networksCacheMap)
 
 	if subject != nil {
 		acs.Subjects = append(acs.Subjects, subject)
@@ -53,3 +57,62 @@ type AccessControlGroup struct {
 func (acg AccessControlGroup) IsMatch(subject Subject) (match bool) {
 	return utils.IsStringInSlice(acg.Name, subject.Groups)
 }
+
+// AccessControlEmail represents an ACL subject of type `email:`.
+type AccessControlEmail struct {
+	Pattern *regexp.Regexp
+}
+
+// NewAccessControlEmail creates a new AccessControlEmail from an email pattern where `*` matches any characters.
+func NewAccessControlEmail(pattern string) AccessControlEmail {
+	expression := strings.ReplaceAll(regexp.QuoteMeta(strings.ToLower(pattern)), `\*`, `.*`)
+
+	return AccessControlEmail{Pattern: regexp.MustCompile("^" + expression + "$")}
+}
+
+// IsMatch returns true if the AccessControlEmail pattern matches one of the emails of the Subject.
+func (ace AccessControlEmail) IsMatch(subject Subject) (match bool) {
+	for _, email := range subject.Emails {
+		if ace.Pattern.MatchString(strings.ToLower(email)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccessControlClient represents an ACL subject of type `oidc:`.
+type AccessControlClient struct {
+	ID string
+}
+
+// IsMatch returns true if the AccessControlClient ID matches the OpenID Connect client ID of the Subject.
+func (acc AccessControlClient) IsMatch(subject Subject) (match bool) {
+	return subject.ClientID != "" && subject.ClientID == acc.ID
+}
+
+// AccessControlNetwork represents an ACL subject of type `network:`.
+type AccessControlNetwork struct {
+	Networks []*net.IPNet
+}
+
+// IsMatch returns true if one of the AccessControlNetwork networks contains the IP of the Subject.
+func (acn AccessControlNetwork) IsMatch(subject Subject) (match bool) {
+	for _, network := range acn.Networks {
+		if network.Contains(subject.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AccessControlNegation represents an ACL subject prefixed with `!` which inverts the match of the subject.
+type AccessControlNegation struct {
+	Subject AccessControlSubject
+}
+
+// IsMatch returns true if the negated subject does not match the Subject.
+func (acn AccessControlNegation) IsMatch(subject Subject) (match bool) {
+	return !acn.Subject.IsMatch(subject)
+}
@@ -1,6 +1,9 @@
 package authorization
 
 import (
+	"sync"
+	"time"
+
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 )
@@ -10,6 +13,8 @@ type Authorizer struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
 	configuration *schema.Configuration
+
+	mutex sync.RWMutex
 }
 
 // NewAuthorizer create an instance of authorizer with a given access control configuration.
@@ -21,13 +26,28 @@ func NewAuthorizer(configuration *schema.Configuration) *Authorizer {
 	}
 }
 
+// SetRules atomically replaces the rules of the authorizer with the provided rules.
+func (p *Authorizer) SetRules(rules []*AccessControlRule) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.rules = rules
+}
+
+func (p *Authorizer) getRules() (rules []*AccessControlRule) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.rules
+}
+
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
-func (p Authorizer) IsSecondFactorEnabled() bool {
+func (p *Authorizer) IsSecondFactorEnabled() bool {
 	if p.defaultPolicy == TwoFactor {
 		return true
 	}
 
-	for _, rule := range p.rules {
+	for _, rule := range p.getRules() {
 		if rule.Policy == TwoFactor {
 			return true
 		}
@@ -45,17 +65,25 @@ func (p Authorizer) IsSecondFactorEnabled() bool {
 }
 
 // GetRequiredLevel retrieve the required level of authorization to access the object.
-func (p Authorizer) GetRequiredLevel(subject Subject, object Object) Level {
+func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) Level {
+	level, _ := p.GetRequirements(subject, object)
+
+	return level
+}
+
+// GetRequirements retrieve the required level of authorization to access the object, and the maximum age the
+// authentication at that level may have. A maximum authentication age of 0 means the age is not restricted.
+func (p *Authorizer) GetRequirements(subject Subject, object Object) (level Level, maxAuthenticationAge time.Duration) {
 	logger := logging.Logger()
 
 	logger.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
+	for _, rule := range p.getRules() {
 		if rule.IsMatch(subject, object) {
 			logger.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject.String(), object.String(), object.Method)
 
-			return rule.Policy
+			return rule.Policy, rule.MaxAuthenticationAge
 		}
 
 		logger.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject.String(), object.String(), object.Method)
@@ -64,5 +92,5 @@ func (p Authorizer) GetRequiredLevel(subject Subject, object Object) Level {
 	logger.Debugf("No matching rule for subject %s and url %s... Applying default policy.",
 		subject.String(), object.String())
 
-	return p.defaultPolicy
+	return p.defaultPolicy, 0
 }
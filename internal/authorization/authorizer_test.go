@@ -4,6 +4,7 @@ import (
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -330,6 +331,34 @@ func (s *AuthorizerSuite) TestShouldCheckIPMatching() {
 	tester.CheckAuthorizations(s.T(), Sam, "https://ipv6.example.com/", "GET", TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckCountryAndASNMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains:  []string{"admin.example.com"},
+			Policy:   twoFactor,
+			Networks: []string{"country:nz", "country:AU"},
+		}).
+		WithRule(schema.ACLRule{
+			Domains:  []string{"cdn.example.com"},
+			Policy:   bypass,
+			Networks: []string{"asn:13335", "10.0.0.0/8"},
+		}).
+		Build()
+
+	kiwi := Subject{Username: "kiwi", IP: net.ParseIP("192.0.2.1"), Country: "NZ", ASN: 9500}
+	yankee := Subject{Username: "yankee", IP: net.ParseIP("192.0.2.2"), Country: "US", ASN: 13335}
+	unknown := Subject{Username: "unknown", IP: net.ParseIP("10.0.0.1")}
+
+	tester.CheckAuthorizations(s.T(), kiwi, "https://admin.example.com/", "GET", TwoFactor)
+	tester.CheckAuthorizations(s.T(), yankee, "https://admin.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), unknown, "https://admin.example.com/", "GET", Denied)
+
+	tester.CheckAuthorizations(s.T(), kiwi, "https://cdn.example.com/", "GET", Denied)
+	tester.CheckAuthorizations(s.T(), yankee, "https://cdn.example.com/", "GET", Bypass)
+	tester.CheckAuthorizations(s.T(), unknown, "https://cdn.example.com/", "GET", Bypass)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckMethodMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -483,6 +512,39 @@ func (s *AuthorizerSuite) TestShouldMatchResourceWithSubjectRules() {
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://private.example.com", "GET", TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldReturnMaxAuthenticationAgeOfMatchingRule() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.ACLRule{
+			Domains:              []string{"admin.example.com"},
+			Policy:               twoFactor,
+			MaxAuthenticationAge: "10m",
+		}).
+		WithRule(schema.ACLRule{
+			Domains: []string{"*.example.com"},
+			Policy:  twoFactor,
+		}).
+		Build()
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "admin.example.com", Path: "/"}, "GET")
+
+	level, maxAuthenticationAge := tester.GetRequirements(John, object)
+	s.Assert().Equal(TwoFactor, level)
+	s.Assert().Equal(10*time.Minute, maxAuthenticationAge)
+
+	object = NewObject(&url.URL{Scheme: "https", Host: "app.example.com", Path: "/"}, "GET")
+
+	level, maxAuthenticationAge = tester.GetRequirements(John, object)
+	s.Assert().Equal(TwoFactor, level)
+	s.Assert().Equal(time.Duration(0), maxAuthenticationAge)
+
+	object = NewObject(&url.URL{Scheme: "https", Host: "example.org", Path: "/"}, "GET")
+
+	level, maxAuthenticationAge = tester.GetRequirements(John, object)
+	s.Assert().Equal(Denied, level)
+	s.Assert().Equal(time.Duration(0), maxAuthenticationAge)
+}
+
 func (s *AuthorizerSuite) TestPolicyToLevel() {
 	s.Assert().Equal(Bypass, PolicyToLevel(bypass))
 	s.Assert().Equal(OneFactor, PolicyToLevel(oneFactor))
@@ -492,6 +554,15 @@ func (s *AuthorizerSuite) TestPolicyToLevel() {
 	s.Assert().Equal(Denied, PolicyToLevel("whatever"))
 }
 
+func (s *AuthorizerSuite) TestLevelToPolicy() {
+	s.Assert().Equal(bypass, LevelToPolicy(Bypass))
+	s.Assert().Equal(oneFactor, LevelToPolicy(OneFactor))
+	s.Assert().Equal(twoFactor, LevelToPolicy(TwoFactor))
+	s.Assert().Equal(deny, LevelToPolicy(Denied))
+
+	s.Assert().Equal(deny, LevelToPolicy(Level(42)))
+}
+
 func TestRunSuite(t *testing.T) {
 	s := AuthorizerSuite{}
 	suite.Run(t, &s)
@@ -1,5 +1,7 @@
 package authorization
 
+import "time"
+
 // Level is the type representing an authorization level.
 type Level int
 
@@ -16,6 +18,13 @@ const (
 
 const userPrefix = "user:"
 const groupPrefix = "group:"
+const emailPrefix = "email:"
+const oidcPrefix = "oidc:"
+const networkPrefix = "network:"
+const negationPrefix = "!"
+
+const countryPrefix = "country:"
+const asnPrefix = "asn:"
 
 const bypass = "bypass"
 const oneFactor = "one_factor"
@@ -23,3 +32,7 @@ const twoFactor = "two_factor"
 const deny = "deny"
 
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (Method %s)"
+
+// rulesDirectoryReloadDelay is the time to wait after the last change to the rules directory before reloading it, so
+// that editors which write a file in several operations only trigger a single reload.
+const rulesDirectoryReloadDelay = 500 * time.Millisecond
@@ -0,0 +1,197 @@
+package authorization
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// RulesDirectoryWatcher loads the access control rules from the YAML files of the access_control.rules_dir directory
+// and keeps the rules of an Authorizer up to date when those files change.
+type RulesDirectoryWatcher struct {
+	configuration schema.AccessControlConfiguration
+	authorizer    *Authorizer
+	watcher       *fsnotify.Watcher
+}
+
+// NewRulesDirectoryWatcher creates a new RulesDirectoryWatcher for the given configuration and authorizer.
+func NewRulesDirectoryWatcher(configuration schema.AccessControlConfiguration, authorizer *Authorizer) *RulesDirectoryWatcher {
+	return &RulesDirectoryWatcher{
+		configuration: configuration,
+		authorizer:    authorizer,
+	}
+}
+
+// Load the rules from the rules directory and apply them to the authorizer. The rules of the authorizer are left
+// untouched if any of the files is invalid.
+func (w *RulesDirectoryWatcher) Load() (errs []error) {
+	rules, errs := w.load()
+	if len(errs) != 0 {
+		return errs
+	}
+
+	w.authorizer.SetRules(rules)
+
+	return nil
+}
+
+// Start watching the rules directory for changes.
+func (w *RulesDirectoryWatcher) Start() (err error) {
+	if w.watcher, err = fsnotify.NewWatcher(); err != nil {
+		return fmt.Errorf("unable to watch the access control rules directory %s: %w", w.configuration.RulesDir, err)
+	}
+
+	if err = w.watcher.Add(w.configuration.RulesDir); err != nil {
+		_ = w.watcher.Close()
+
+		return fmt.Errorf("unable to watch the access control rules directory %s: %w", w.configuration.RulesDir, err)
+	}
+
+	go w.run()
+
+	return nil
+}
+
+// Close stops watching the rules directory.
+func (w *RulesDirectoryWatcher) Close() (err error) {
+	if w.watcher == nil {
+		return nil
+	}
+
+	return w.watcher.Close()
+}
+
+func (w *RulesDirectoryWatcher) run() {
+	logger := logging.Logger()
+
+	timer := time.NewTimer(rulesDirectoryReloadDelay)
+	timer.Stop()
+
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				timer.Stop()
+
+				return
+			}
+
+			if !isRulesFile(event.Name) {
+				continue
+			}
+
+			logger.Tracef("Access control rules directory event %s on file %s", event.Op, event.Name)
+
+			timer.Reset(rulesDirectoryReloadDelay)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				timer.Stop()
+
+				return
+			}
+
+			logger.Errorf("Error occurred watching the access control rules directory %s: %v", w.configuration.RulesDir, err)
+		case <-timer.C:
+			w.reload()
+		}
+	}
+}
+
+func (w *RulesDirectoryWatcher) reload() {
+	logger := logging.Logger()
+
+	rules, errs := w.load()
+	if len(errs) != 0 {
+		for _, err := range errs {
+			logger.Errorf("Rejected change to the access control rules directory %s: %v", w.configuration.RulesDir, err)
+		}
+
+		logger.Warnf("Access control rules directory %s has errors, the current rules will remain in effect", w.configuration.RulesDir)
+
+		return
+	}
+
+	w.authorizer.SetRules(rules)
+
+	logger.Infof("Reloaded access control rules from directory %s, %d rules are now in effect", w.configuration.RulesDir, len(rules))
+}
+
+// load reads and validates every rules file in the rules directory, and returns the rules from the main configuration
+// followed by the rules of each file in lexical order of the file names.
+func (w *RulesDirectoryWatcher) load() (rules []*AccessControlRule, errs []error) {
+	entries, err := os.ReadDir(w.configuration.RulesDir)
+	if err != nil {
+		return nil, []error{fmt.Errorf("unable to read the access control rules directory %s: %w", w.configuration.RulesDir, err)}
+	}
+
+	config := schema.AccessControlConfiguration{
+		DefaultPolicy: w.configuration.DefaultPolicy,
+		Networks:      w.configuration.Networks,
+		Rules:         append([]schema.ACLRule{}, w.configuration.Rules...),
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !isRulesFile(entry.Name()) {
+			continue
+		}
+
+		fileRules, fileErrs := w.loadFile(filepath.Join(w.configuration.RulesDir, entry.Name()))
+		if len(fileErrs) != 0 {
+			errs = append(errs, fileErrs...)
+
+			continue
+		}
+
+		config.Rules = append(config.Rules, fileRules...)
+	}
+
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	return NewAccessControlRules(config), nil
+}
+
+func (w *RulesDirectoryWatcher) loadFile(path string) (rules []schema.ACLRule, errs []error) {
+	val := schema.NewStructValidator()
+
+	file := struct {
+		Rules []schema.ACLRule `koanf:"rules"`
+	}{}
+
+	if _, err := configuration.LoadAdvanced(val, "", &file, configuration.NewYAMLFileSource(path)); err != nil {
+		val.Push(err)
+	}
+
+	if !val.HasErrors() && len(file.Rules) != 0 {
+		validator.ValidateRules(schema.AccessControlConfiguration{
+			DefaultPolicy: w.configuration.DefaultPolicy,
+			Networks:      w.configuration.Networks,
+			Rules:         file.Rules,
+		}, val)
+	}
+
+	for _, err := range val.Errors() {
+		errs = append(errs, fmt.Errorf("rules file %s: %w", path, err))
+	}
+
+	return file.Rules, errs
+}
+
+func isRulesFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		return true
+	default:
+		return false
+	}
+}
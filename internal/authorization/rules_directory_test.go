@@ -0,0 +1,97 @@
+package authorization
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newRulesDirectoryTest(t *testing.T) (dir string, authorizer *Authorizer, watcher *RulesDirectoryWatcher) {
+	dir = t.TempDir()
+
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			DefaultPolicy: deny,
+			Rules: []schema.ACLRule{
+				{
+					Domains: []string{"public.example.com"},
+					Policy:  bypass,
+				},
+			},
+			RulesDir: dir,
+		},
+	}
+
+	authorizer = NewAuthorizer(config)
+
+	return dir, authorizer, NewRulesDirectoryWatcher(config.AccessControl, authorizer)
+}
+
+func writeRulesFile(t *testing.T, dir, name, content string) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func TestShouldLoadRulesFromRulesDirectory(t *testing.T) {
+	dir, authorizer, watcher := newRulesDirectoryTest(t)
+
+	writeRulesFile(t, dir, "b.yml", "rules:\n  - domain: b.example.com\n    policy: two_factor\n")
+	writeRulesFile(t, dir, "a.yaml", "rules:\n  - domain: a.example.com\n    policy: one_factor\n    subject: 'group:dev'\n")
+	writeRulesFile(t, dir, "README.md", "not a rules file")
+
+	require.Len(t, watcher.Load(), 0)
+
+	rules := authorizer.getRules()
+	require.Len(t, rules, 3)
+
+	assert.Equal(t, 1, rules[0].Position)
+	assert.Equal(t, Bypass, rules[0].Policy)
+	assert.Equal(t, 2, rules[1].Position)
+	assert.Equal(t, OneFactor, rules[1].Policy)
+	assert.Len(t, rules[1].Subjects, 1)
+	assert.Equal(t, 3, rules[2].Position)
+	assert.Equal(t, TwoFactor, rules[2].Policy)
+}
+
+func TestShouldRejectInvalidRulesDirectoryWithoutDroppingRules(t *testing.T) {
+	dir, authorizer, watcher := newRulesDirectoryTest(t)
+
+	writeRulesFile(t, dir, "a.yml", "rules:\n  - domain: a.example.com\n    policy: one_factor\n")
+
+	require.Len(t, watcher.Load(), 0)
+	require.Len(t, authorizer.getRules(), 2)
+
+	writeRulesFile(t, dir, "b.yml", "rules:\n  - domain: b.example.com\n    policy: invalid\n")
+
+	errs := watcher.Load()
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "rules file "+filepath.Join(dir, "b.yml")+": Policy [invalid] for rule #1 domain: [b.example.com] is invalid, a policy must either be 'deny', 'two_factor', 'one_factor' or 'bypass'")
+
+	assert.Len(t, authorizer.getRules(), 2)
+}
+
+func TestShouldReloadRulesDirectoryOnChange(t *testing.T) {
+	dir, authorizer, watcher := newRulesDirectoryTest(t)
+
+	require.Len(t, watcher.Load(), 0)
+	require.NoError(t, watcher.Start())
+
+	defer watcher.Close()
+
+	writeRulesFile(t, dir, "a.yml", "rules:\n  - domain: a.example.com\n    policy: one_factor\n")
+
+	assert.Eventually(t, func() bool {
+		return len(authorizer.getRules()) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+
+	writeRulesFile(t, dir, "a.yml", "rules:\n  - domain: [a.example.com\n")
+
+	time.Sleep(rulesDirectoryReloadDelay * 3)
+
+	assert.Len(t, authorizer.getRules(), 2)
+}
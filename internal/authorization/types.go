@@ -11,12 +11,20 @@ import (
 type Subject struct {
 	Username string
 	Groups   []string
+	Emails   []string
+	ClientID string
 	IP       net.IP
+	Country  string
+	ASN      uint
 }
 
 // String returns a string representation of the Subject.
 func (s Subject) String() string {
-	return fmt.Sprintf("username=%s groups=%s ip=%s", s.Username, strings.Join(s.Groups, ","), s.IP.String())
+	if s.ClientID != "" {
+		return fmt.Sprintf("username=%s groups=%s emails=%s client=%s ip=%s", s.Username, strings.Join(s.Groups, ","), strings.Join(s.Emails, ","), s.ClientID, s.IP.String())
+	}
+
+	return fmt.Sprintf("username=%s groups=%s emails=%s ip=%s", s.Username, strings.Join(s.Groups, ","), strings.Join(s.Emails, ","), s.IP.String())
 }
 
 // IsAnonymous returns true if the Subject username and groups are empty.
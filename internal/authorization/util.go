@@ -3,10 +3,13 @@ package authorization
 import (
 	"net"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // PolicyToLevel converts a string policy to int authorization level.
@@ -25,17 +28,60 @@ func PolicyToLevel(policy string) Level {
 	return Denied
 }
 
-func schemaSubjectToACLSubject(subjectRule string) (subject AccessControlSubject) {
-	if strings.HasPrefix(subjectRule, userPrefix) {
-		user := strings.Trim(subjectRule[len(userPrefix):], " ")
+// LevelToPolicy converts a int authorization level to string policy.
+func LevelToPolicy(level Level) (policy string) {
+	switch level {
+	case Bypass:
+		return bypass
+	case OneFactor:
+		return oneFactor
+	case TwoFactor:
+		return twoFactor
+	case Denied:
+		return deny
+	}
 
-		return AccessControlUser{Name: user}
+	return deny
+}
+
+func schemaSubjectToACLSubject(subjectRule string, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) (subject AccessControlSubject) {
+	subjectRule = strings.Trim(subjectRule, " ")
+
+	if strings.HasPrefix(subjectRule, negationPrefix) {
+		subject = schemaSubjectToACLSubject(subjectRule[len(negationPrefix):], networksMap, networksCacheMap)
+		if subject == nil {
+			return nil
+		}
+
+		return AccessControlNegation{Subject: subject}
 	}
 
-	if strings.HasPrefix(subjectRule, groupPrefix) {
+	switch {
+	case strings.HasPrefix(subjectRule, userPrefix):
+		user := strings.Trim(subjectRule[len(userPrefix):], " ")
+
+		return AccessControlUser{Name: user}
+	case strings.HasPrefix(subjectRule, groupPrefix):
 		group := strings.Trim(subjectRule[len(groupPrefix):], " ")
 
 		return AccessControlGroup{Name: group}
+	case strings.HasPrefix(subjectRule, emailPrefix):
+		email := strings.Trim(subjectRule[len(emailPrefix):], " ")
+
+		return NewAccessControlEmail(email)
+	case strings.HasPrefix(subjectRule, oidcPrefix):
+		client := strings.Trim(subjectRule[len(oidcPrefix):], " ")
+
+		return AccessControlClient{ID: client}
+	case strings.HasPrefix(subjectRule, networkPrefix):
+		network := strings.Trim(subjectRule[len(networkPrefix):], " ")
+
+		networks := schemaNetworksToACL([]string{network}, networksMap, networksCacheMap)
+		if len(networks) == 0 {
+			return nil
+		}
+
+		return AccessControlNetwork{Networks: networks}
 	}
 
 	return nil
@@ -107,6 +153,31 @@ func schemaNetworksToACL(networkRules []string, networksMap map[string][]*net.IP
 	return networks
 }
 
+func schemaCountriesToACL(networkRules []string) (countries []string) {
+	for _, network := range networkRules {
+		if strings.HasPrefix(network, countryPrefix) {
+			countries = append(countries, strings.ToUpper(strings.TrimSpace(network[len(countryPrefix):])))
+		}
+	}
+
+	return countries
+}
+
+func schemaASNsToACL(networkRules []string) (asns []uint) {
+	for _, network := range networkRules {
+		if !strings.HasPrefix(network, asnPrefix) {
+			continue
+		}
+
+		asn, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(network[len(asnPrefix):]), "AS"), 10, 32)
+		if err == nil {
+			asns = append(asns, uint(asn))
+		}
+	}
+
+	return asns
+}
+
 func parseSchemaNetworks(schemaNetworks []schema.ACLNetwork) (networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) {
 	// These maps store pointers to the net.IPNet values so we can reuse them efficiently.
 	// The networksMap contains the named networks as keys, the networksCacheMap contains the CIDR notations as keys.
@@ -137,26 +208,26 @@ func parseSchemaNetworks(schemaNetworks []schema.ACLNetwork) (networksMap map[st
 }
 
 func parseNetwork(networkRule string) (cidr *net.IPNet, err error) {
-	if !strings.Contains(networkRule, "/") {
-		ip := net.ParseIP(networkRule)
-		if ip.To4() != nil {
-			_, cidr, err = net.ParseCIDR(networkRule + "/32")
-		} else {
-			_, cidr, err = net.ParseCIDR(networkRule + "/128")
-		}
-	} else {
-		_, cidr, err = net.ParseCIDR(networkRule)
+	return utils.ParseHostCIDR(networkRule)
+}
+
+func schemaMaxAuthenticationAgeToACL(maxAuthenticationAge string) (duration time.Duration) {
+	if maxAuthenticationAge == "" {
+		return 0
 	}
 
-	return cidr, err
+	// Skip Error Check since validator checks it.
+	duration, _ = utils.ParseDurationString(maxAuthenticationAge)
+
+	return duration
 }
 
-func schemaSubjectsToACL(subjectRules [][]string) (subjects []AccessControlSubjects) {
+func schemaSubjectsToACL(subjectRules [][]string, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) (subjects []AccessControlSubjects) {
 	for _, subjectRule := range subjectRules {
 		subject := AccessControlSubjects{}
 
 		for _, subjectRuleItem := range subjectRule {
-			subject.AddSubject(subjectRuleItem)
+			subject.AddSubject(subjectRuleItem, networksMap, networksCacheMap)
 		}
 
 		if len(subject.Subjects) != 0 {
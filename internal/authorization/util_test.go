@@ -13,7 +13,7 @@ import (
 
 func TestShouldNotParseInvalidSubjects(t *testing.T) {
 	subjectsSchema := [][]string{{"groups:z"}, {"group:z", "users:b"}}
-	subjectsACL := schemaSubjectsToACL(subjectsSchema)
+	subjectsACL := schemaSubjectsToACL(subjectsSchema, map[string][]*net.IPNet{}, map[string]*net.IPNet{})
 
 	require.Len(t, subjectsACL, 1)
 
@@ -22,6 +22,33 @@ func TestShouldNotParseInvalidSubjects(t *testing.T) {
 	assert.True(t, subjectsACL[0].IsMatch(Subject{Username: "a", Groups: []string{"z"}}))
 }
 
+func TestShouldParseExtendedSubjects(t *testing.T) {
+	networksMap, networksCacheMap := parseSchemaNetworks([]schema.ACLNetwork{{Name: "internal", Networks: []string{"10.0.0.0/8"}}})
+
+	subjectsSchema := [][]string{{"group:employees", "!group:contractors"}, {"email:*@corp.com"}, {"oidc:grafana"}, {"user:sam", "network:internal"}, {"network:invalid"}}
+	subjectsACL := schemaSubjectsToACL(subjectsSchema, networksMap, networksCacheMap)
+
+	require.Len(t, subjectsACL, 4)
+
+	require.Len(t, subjectsACL[0].Subjects, 2)
+	assert.IsType(t, AccessControlGroup{}, subjectsACL[0].Subjects[0])
+	assert.IsType(t, AccessControlNegation{}, subjectsACL[0].Subjects[1])
+
+	assert.True(t, subjectsACL[0].IsMatch(Subject{Username: "a", Groups: []string{"employees"}}))
+	assert.False(t, subjectsACL[0].IsMatch(Subject{Username: "a", Groups: []string{"employees", "contractors"}}))
+	assert.False(t, subjectsACL[0].IsMatch(Subject{Username: "a", Groups: []string{"contractors"}}))
+
+	assert.True(t, subjectsACL[1].IsMatch(Subject{Username: "a", Emails: []string{"a@example.com", "A@Corp.com"}}))
+	assert.False(t, subjectsACL[1].IsMatch(Subject{Username: "a", Emails: []string{"a@corp.com.example.com"}}))
+	assert.False(t, subjectsACL[1].IsMatch(Subject{Username: "a"}))
+
+	assert.True(t, subjectsACL[2].IsMatch(Subject{Username: "a", ClientID: "grafana"}))
+	assert.False(t, subjectsACL[2].IsMatch(Subject{Username: "a"}))
+
+	assert.True(t, subjectsACL[3].IsMatch(Subject{Username: "sam", IP: net.ParseIP("10.1.2.3")}))
+	assert.False(t, subjectsACL[3].IsMatch(Subject{Username: "sam", IP: net.ParseIP("192.168.1.1")}))
+}
+
 func TestShouldSplitDomainCorrectly(t *testing.T) {
 	prefix, suffix := domainToPrefixSuffix("apple.example.com")
 
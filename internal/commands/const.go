@@ -93,3 +93,6 @@ const (
 var (
 	errNoStorageProvider = errors.New("no storage provider configured")
 )
+
+// regulationListPageSize is the number of active bans loaded at once by the regulation list command.
+const regulationListPageSize = 100
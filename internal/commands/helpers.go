@@ -1,8 +1,18 @@
 package commands
 
 import (
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/ntp"
@@ -10,6 +20,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/totp"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -27,6 +38,32 @@ func getStorageProvider() (provider storage.Provider) {
 	}
 }
 
+func getUserProvider(certPool *x509.CertPool) (provider authentication.UserProvider) {
+	switch {
+	case config.AuthenticationBackend.File != nil:
+		return authentication.NewFileUserProvider(config.AuthenticationBackend.File)
+	case config.AuthenticationBackend.LDAP != nil:
+		return authentication.NewLDAPUserProvider(config.AuthenticationBackend, certPool)
+	default:
+		return nil
+	}
+}
+
+func getNotifier(certPool *x509.CertPool) (notifier notification.Notifier) {
+	switch {
+	case config.Notifier.SMTP != nil:
+		return notification.NewSMTPNotifier(config.Notifier.SMTP, certPool)
+	case config.Notifier.FileSystem != nil:
+		return notification.NewFileNotifier(*config.Notifier.FileSystem)
+	case config.Notifier.Webhook != nil:
+		return notification.NewWebhookNotifier(config.Notifier.Webhook, certPool)
+	case config.Notifier.Sendmail != nil:
+		return notification.NewSendmailNotifier(config.Notifier.Sendmail)
+	default:
+		return nil
+	}
+}
+
 func getProviders() (providers middlewares.Providers, warnings []error, errors []error) {
 	// TODO: Adjust this so the CertPool can be used like a provider.
 	autheliaCertPool, warnings, errors := utils.NewX509CertPool(config.CertificatesDirectory)
@@ -36,36 +73,42 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 
 	storageProvider := getStorageProvider()
 
-	var (
-		userProvider authentication.UserProvider
-		err          error
-	)
-
-	switch {
-	case config.AuthenticationBackend.File != nil:
-		userProvider = authentication.NewFileUserProvider(config.AuthenticationBackend.File)
-	case config.AuthenticationBackend.LDAP != nil:
-		userProvider = authentication.NewLDAPUserProvider(config.AuthenticationBackend, autheliaCertPool)
-	}
-
-	var notifier notification.Notifier
+	var err error
 
-	switch {
-	case config.Notifier.SMTP != nil:
-		notifier = notification.NewSMTPNotifier(config.Notifier.SMTP, autheliaCertPool)
-	case config.Notifier.FileSystem != nil:
-		notifier = notification.NewFileNotifier(*config.Notifier.FileSystem)
-	}
+	userProvider := getUserProvider(autheliaCertPool)
+	notifier := getNotifier(autheliaCertPool)
 
 	var ntpProvider *ntp.Provider
 	if config.NTP != nil {
 		ntpProvider = ntp.NewProvider(config.NTP)
 	}
 
+	var geoIPProvider geoip.Provider
+
+	if config.GeoIP != nil {
+		maxMindProvider, err := geoip.NewMaxMindProvider(config.GeoIP)
+		if err != nil {
+			errors = append(errors, err)
+		} else {
+			geoIPProvider = maxMindProvider
+		}
+	}
+
 	clock := utils.RealClock{}
 	authorizer := authorization.NewAuthorizer(config)
-	sessionProvider := session.NewProvider(config.Session, autheliaCertPool)
-	regulator := regulation.NewRegulator(config.Regulation, storageProvider, clock)
+
+	if config.AccessControl.RulesDir != "" {
+		rulesWatcher := authorization.NewRulesDirectoryWatcher(config.AccessControl, authorizer)
+
+		if errs := rulesWatcher.Load(); len(errs) != 0 {
+			errors = append(errors, errs...)
+		} else if err = rulesWatcher.Start(); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	sessionProvider := session.NewProvider(config.Session, autheliaCertPool, storageProvider)
+	regulator := regulation.NewRegulator(config.Regulation, storageProvider, geoIPProvider, clock)
 
 	oidcProvider, err := oidc.NewOpenIDConnectProvider(config.IdentityProviders.OIDC)
 	if err != nil {
@@ -74,6 +117,27 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 
 	totpProvider := totp.NewTimeBasedProvider(config.TOTP)
 
+	templatesProvider, err := templates.NewProvider(config.Notifier.TemplatePath, config.Notifier.TemplateLocale)
+	if err != nil {
+		errors = append(errors, err)
+	}
+
+	var auditLogger *audit.Logger
+
+	if config.Audit != nil {
+		if auditLogger, err = audit.NewLogger(config.Audit, autheliaCertPool); err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	var securityEvents *notification.SecurityEventNotifier
+
+	if notifier != nil && templatesProvider != nil {
+		securityEvents = notification.NewSecurityEventNotifier(config.Notifier, notifier, templatesProvider, userProvider)
+
+		regulator.SetUserBanHandler(securityEvents.NotifyUserBan)
+	}
+
 	return middlewares.Providers{
 		Authorizer:      authorizer,
 		UserProvider:    userProvider,
@@ -82,7 +146,62 @@ func getProviders() (providers middlewares.Providers, warnings []error, errors [
 		StorageProvider: storageProvider,
 		NTP:             ntpProvider,
 		Notifier:        notifier,
+		SecurityEvents:  securityEvents,
 		SessionProvider: sessionProvider,
 		TOTP:            totpProvider,
+		GeoIP:           geoIPProvider,
+		Templates:       templatesProvider,
+		Audit:           auditLogger,
 	}, warnings, errors
 }
+
+// newConfigurationPersistentPreRunE returns a PersistentPreRunE which loads the configuration files given by the config
+// flag as well as the environment and secrets, then validates the configuration with validate.
+func newConfigurationPersistentPreRunE(validate func(val *schema.StructValidator)) func(cmd *cobra.Command, args []string) (err error) {
+	return func(cmd *cobra.Command, _ []string) (err error) {
+		configs, err := cmd.Flags().GetStringSlice("config")
+		if err != nil {
+			return err
+		}
+
+		sources := make([]configuration.Source, 0, len(configs)+2)
+
+		for _, configFile := range configs {
+			if _, err := os.Stat(configFile); os.IsNotExist(err) {
+				return fmt.Errorf("could not load the provided configuration file %s: %w", configFile, err)
+			}
+
+			sources = append(sources, configuration.NewYAMLFileSource(configFile))
+		}
+
+		sources = append(sources, configuration.NewEnvironmentSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter))
+		sources = append(sources, configuration.NewSecretsSource(configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter))
+
+		val := schema.NewStructValidator()
+
+		config = &schema.Configuration{}
+
+		if _, err = configuration.LoadAdvanced(val, "", &config, sources...); err != nil {
+			return err
+		}
+
+		validate(val)
+
+		if val.HasErrors() {
+			var finalErr error
+
+			for i, err := range val.Errors() {
+				if i == 0 {
+					finalErr = err
+					continue
+				}
+
+				finalErr = fmt.Errorf("%w, %v", finalErr, err)
+			}
+
+			return finalErr
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewRegulationCmd returns a new regulation *cobra.Command.
+func NewRegulationCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:               "regulation",
+		Short:             "Manage the Authelia regulation bans",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: regulationPersistentPreRunE,
+	}
+
+	cmd.PersistentFlags().StringSliceP("config", "c", []string{"config.yml"}, "configuration file to load for the storage provider")
+
+	cmd.AddCommand(
+		newRegulationListCmd(),
+		newRegulationUnbanCmd(),
+	)
+
+	return cmd
+}
+
+func newRegulationListCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the active bans",
+		Args:  cobra.NoArgs,
+		RunE:  regulationListRunE,
+	}
+
+	return cmd
+}
+
+func newRegulationUnbanCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "unban [user|ip|subnet]",
+		Short: "Revoke the active bans of a user, remote IP or subnet",
+		Long: "Revoke the active bans of a user, remote IP or subnet. The argument is a remote IP if it's an IP address, " +
+			"a subnet if it's in CIDR notation, and a username otherwise.",
+		Example: "authelia regulation unban john\nauthelia regulation unban 192.0.2.1\nauthelia regulation unban 192.0.2.0/24\nauthelia regulation unban --global",
+		Args:    cobra.MaximumNArgs(1),
+		RunE:    regulationUnbanRunE,
+	}
+
+	cmd.Flags().Bool("global", false, "revoke the ban of everyone caused by reaching the global limit")
+
+	return cmd
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func regulationPersistentPreRunE(cmd *cobra.Command, args []string) (err error) {
+	return newConfigurationPersistentPreRunE(func(val *schema.StructValidator) {
+		validator.ValidateStorage(config.Storage, val)
+	})(cmd, args)
+}
+
+func regulationListRunE(_ *cobra.Command, _ []string) (err error) {
+	storageProvider := getStorageProvider()
+
+	defer func() {
+		_ = storageProvider.Close()
+	}()
+
+	ctx := context.Background()
+
+	if err = checkStorageSchemaUpToDate(ctx, storageProvider); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	header := false
+
+	for page := 0; ; page++ {
+		bans, err := storageProvider.LoadActiveRegulationBans(ctx, now, regulationListPageSize, page)
+		if err != nil {
+			return err
+		}
+
+		if !header && len(bans) != 0 {
+			fmt.Printf("type,value,created,expires\n")
+
+			header = true
+		}
+
+		for _, ban := range bans {
+			fmt.Printf("%s,%s,%s,%s\n", ban.Type, ban.Value, ban.Time.Format(time.RFC3339), ban.Expires.Format(time.RFC3339))
+		}
+
+		if len(bans) < regulationListPageSize {
+			break
+		}
+	}
+
+	if !header {
+		fmt.Printf("There are no active bans.\n")
+	}
+
+	return nil
+}
+
+func regulationUnbanRunE(cmd *cobra.Command, args []string) (err error) {
+	global, err := cmd.Flags().GetBool("global")
+	if err != nil {
+		return err
+	}
+
+	var (
+		banTypes []string
+		value    string
+	)
+
+	switch {
+	case global && len(args) != 0:
+		return errors.New("the --global flag can't be used with a user, remote IP or subnet")
+	case global:
+		banTypes = []string{regulation.BanTypeGlobal}
+	case len(args) == 0:
+		return errors.New("a user, remote IP or subnet, or the --global flag is required")
+	default:
+		banTypes, value = regulationBanSubject(args[0])
+	}
+
+	storageProvider := getStorageProvider()
+
+	defer func() {
+		_ = storageProvider.Close()
+	}()
+
+	ctx := context.Background()
+
+	if err = checkStorageSchemaUpToDate(ctx, storageProvider); err != nil {
+		return err
+	}
+
+	regulator := regulation.NewRegulator(nil, storageProvider, nil, &utils.RealClock{})
+
+	var count int64
+
+	for _, banType := range banTypes {
+		revoked, err := regulator.Unban(ctx, banType, value)
+		if err != nil {
+			return err
+		}
+
+		count += revoked
+	}
+
+	name := fmt.Sprintf("%s '%s'", banTypes[0], value)
+	if global {
+		name = "everyone"
+	}
+
+	if count == 0 {
+		fmt.Printf("There are no active bans of %s.\n", name)
+
+		return nil
+	}
+
+	fmt.Printf("Revoked the active bans of %s.\n", name)
+
+	return nil
+}
+
+// regulationBanSubject returns the ban types and value of the subject given to the unban command. The bans of a user
+// or remote IP include their TOTP and identity verification bans.
+func regulationBanSubject(subject string) (banTypes []string, value string) {
+	if ip := net.ParseIP(subject); ip != nil {
+		return []string{regulation.BanTypeIP, regulation.BanTypeIdentityVerificationIP}, ip.String()
+	}
+
+	if _, subnet, err := net.ParseCIDR(subject); err == nil {
+		return []string{regulation.BanTypeSubnet}, subnet.String()
+	}
+
+	return []string{regulation.BanTypeUser, regulation.BanTypeTOTP, regulation.BanTypeIdentityVerificationUser}, subject
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+func TestRegulationBanSubject(t *testing.T) {
+	testCases := []struct {
+		subject       string
+		expectedTypes []string
+		expectedValue string
+	}{
+		{"john", []string{regulation.BanTypeUser, regulation.BanTypeTOTP, regulation.BanTypeIdentityVerificationUser}, "john"},
+		{"192.0.2.1", []string{regulation.BanTypeIP, regulation.BanTypeIdentityVerificationIP}, "192.0.2.1"},
+		{"2001:DB8::1", []string{regulation.BanTypeIP, regulation.BanTypeIdentityVerificationIP}, "2001:db8::1"},
+		{"192.0.2.1/24", []string{regulation.BanTypeSubnet}, "192.0.2.0/24"},
+		{"2001:db8::/64", []string{regulation.BanTypeSubnet}, "2001:db8::/64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.subject, func(t *testing.T) {
+			banTypes, value := regulationBanSubject(tc.subject)
+
+			assert.Equal(t, tc.expectedTypes, banTypes)
+			assert.Equal(t, tc.expectedValue, value)
+		})
+	}
+}
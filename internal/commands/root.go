@@ -1,9 +1,12 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -13,6 +16,8 @@ import (
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/models"
 	"github.com/authelia/authelia/v4/internal/server"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/tracing"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -38,7 +43,9 @@ func NewRootCmd() (cmd *cobra.Command) {
 		NewCertificatesCmd(),
 		newCompletionCmd(),
 		NewHashPasswordCmd(),
+		NewRegulationCmd(),
 		NewRSACmd(),
+		NewSessionsCmd(),
 		NewStorageCmd(),
 		newValidateConfigCmd(),
 	)
@@ -59,6 +66,16 @@ func cmdRootRun(_ *cobra.Command, _ []string) {
 		logger.Fatalf("Cannot initialize logger: %v", err)
 	}
 
+	if config.Log.FilePath != "" {
+		go doLogReopen()
+	}
+
+	if config.Tracing.Enabled {
+		if err := tracing.InitializeTracing(config.Tracing); err != nil {
+			logger.Fatalf("Cannot initialize tracing: %v", err)
+		}
+	}
+
 	providers, warnings, errors := getProviders()
 	if len(warnings) != 0 {
 		for _, err := range warnings {
@@ -76,9 +93,65 @@ func cmdRootRun(_ *cobra.Command, _ []string) {
 
 	doStartupChecks(config, &providers)
 
+	if config.Storage.Retention != nil {
+		go storage.NewRetentionJanitor(config.Storage.Retention, providers.StorageProvider).Start(context.Background())
+	}
+
+	if config.Session.Storage != nil {
+		go providers.SessionProvider.StartGC(context.Background())
+	}
+
+	if len(config.Storage.DecryptionKeys) != 0 {
+		go doStorageReencrypt(providers.StorageProvider)
+	}
+
+	if config.Server.Admin != nil {
+		go server.StartAdmin(*config, providers)
+	}
+
+	if config.Server.Metrics.Enabled && !config.Server.Metrics.ServerListener {
+		go server.StartMetrics(*config)
+	}
+
 	server.Start(*config, providers)
 }
 
+// doLogReopen reopens the log file each time the SIGHUP signal is received, which allows rotating the log file with an
+// external tool such as logrotate.
+func doLogReopen() {
+	logger := logging.Logger()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	for range signals {
+		if err := logging.Reopen(); err != nil {
+			logger.Errorf("Cannot reopen the log file: %v", err)
+
+			continue
+		}
+
+		logger.Debug("Reopened the log file")
+	}
+}
+
+// doStorageReencrypt encrypts the values which are encrypted with one of the decryption keys again with the encryption
+// key while the server is running, after which the decryption keys can be removed from the configuration.
+func doStorageReencrypt(provider storage.Provider) {
+	logger := logging.Logger()
+
+	logger.Info("Storage values encrypted with the decryption keys are being encrypted again with the encryption key")
+
+	count, err := provider.SchemaEncryptionReencrypt(context.Background())
+	if err != nil {
+		logger.Errorf("Error occurred encrypting the storage values again with the encryption key after %d values: %+v", count, err)
+
+		return
+	}
+
+	logger.Infof("Storage values encrypted again with the encryption key: %d, the decryption keys are no longer required", count)
+}
+
 func doStartupChecks(config *schema.Configuration, providers *middlewares.Providers) {
 	logger := logging.Logger()
 
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewSessionsCmd returns a new sessions *cobra.Command.
+func NewSessionsCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:               "sessions",
+		Short:             "Manage the Authelia user sessions",
+		Args:              cobra.NoArgs,
+		PersistentPreRunE: sessionsPersistentPreRunE,
+	}
+
+	cmd.PersistentFlags().StringSliceP("config", "c", []string{"config.yml"}, "configuration file to load for the session provider")
+
+	cmd.AddCommand(
+		newSessionsListCmd(),
+		newSessionsRevokeCmd(),
+	)
+
+	return cmd
+}
+
+func newSessionsListCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List the active sessions of a user",
+		Args:  cobra.NoArgs,
+		RunE:  sessionsListRunE,
+	}
+
+	cmd.Flags().String("user", "", "the username of the user")
+
+	_ = cmd.MarkFlagRequired("user")
+
+	return cmd
+}
+
+func newSessionsRevokeCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "revoke",
+		Short: "Revoke the active sessions of a user",
+		Args:  cobra.NoArgs,
+		RunE:  sessionsRevokeRunE,
+	}
+
+	cmd.Flags().String("user", "", "the username of the user")
+	cmd.Flags().String("id", "", "the id of the session to revoke as shown by the list command, all sessions of the user are revoked if not provided")
+
+	_ = cmd.MarkFlagRequired("user")
+
+	return cmd
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func sessionsPersistentPreRunE(cmd *cobra.Command, args []string) (err error) {
+	return newConfigurationPersistentPreRunE(func(val *schema.StructValidator) {
+		validator.ValidateSession(&config.Session, val)
+		validator.ValidateStorage(config.Storage, val)
+	})(cmd, args)
+}
+
+func getSessionProvider(storageProvider storage.Provider) (provider *session.Provider, err error) {
+	// The memory session provider only exists within the Authelia process.
+	if config.Session.Redis == nil && config.Session.Storage == nil {
+		return nil, errors.New("listing and revoking individual sessions from the command line requires the redis or storage session provider, use the user sessions API instead")
+	}
+
+	certPool, _, errs := utils.NewX509CertPool(config.CertificatesDirectory)
+	if len(errs) != 0 {
+		return nil, errs[0]
+	}
+
+	return session.NewProvider(config.Session, certPool, storageProvider), nil
+}
+
+func sessionsListRunE(cmd *cobra.Command, _ []string) (err error) {
+	username, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return err
+	}
+
+	storageProvider := getStorageProvider()
+
+	defer func() {
+		_ = storageProvider.Close()
+	}()
+
+	provider, err := getSessionProvider(storageProvider)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := provider.ListSessions(username)
+	if err != nil {
+		return err
+	}
+
+	if len(sessions) == 0 {
+		fmt.Printf("User '%s' has no active sessions.\n", username)
+
+		return nil
+	}
+
+	fmt.Printf("id,remote_ip,device,created,last_activity,second_factor_authn\n")
+
+	for _, s := range sessions {
+		fmt.Printf("%s,%s,%s,%s,%s,%s\n", s.ID, s.RemoteIP, s.Device, formatSessionTime(s.Created), formatSessionTime(s.LastActivity), formatSessionTime(s.SecondFactorAuthn))
+	}
+
+	return nil
+}
+
+func sessionsRevokeRunE(cmd *cobra.Command, _ []string) (err error) {
+	username, err := cmd.Flags().GetString("user")
+	if err != nil {
+		return err
+	}
+
+	id, err := cmd.Flags().GetString("id")
+	if err != nil {
+		return err
+	}
+
+	storageProvider := getStorageProvider()
+
+	defer func() {
+		_ = storageProvider.Close()
+	}()
+
+	if id != "" {
+		provider, err := getSessionProvider(storageProvider)
+		if err != nil {
+			return err
+		}
+
+		if err = provider.RevokeSession(username, id); err != nil {
+			return fmt.Errorf("can't revoke session '%s' of user '%s': %w", id, username, err)
+		}
+
+		fmt.Printf("Revoked session '%s' of user '%s'.\n", id, username)
+
+		return nil
+	}
+
+	ctx := context.Background()
+
+	if err = checkStorageSchemaUpToDate(ctx, storageProvider); err != nil {
+		return err
+	}
+
+	// Incrementing the session generation invalidates all sessions of the user regardless of the session provider.
+	if err = storageProvider.IncrementSessionGeneration(ctx, username); err != nil {
+		return fmt.Errorf("can't revoke sessions of user '%s': %w", username, err)
+	}
+
+	if config.Session.Redis != nil || config.Session.Storage != nil {
+		provider, err := getSessionProvider(storageProvider)
+		if err != nil {
+			return err
+		}
+
+		if _, err = provider.RevokeSessions(username); err != nil {
+			return fmt.Errorf("can't destroy sessions of user '%s': %w", username, err)
+		}
+	}
+
+	fmt.Printf("Revoked all sessions of user '%s'.\n", username)
+
+	return nil
+}
+
+func formatSessionTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
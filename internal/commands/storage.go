@@ -41,6 +41,9 @@ func NewStorageCmd() (cmd *cobra.Command) {
 		newStorageSchemaInfoCmd(),
 		newStorageEncryptionCmd(),
 		newStorageTOTPCmd(),
+		newStorageExportCmd(),
+		newStorageImportCmd(),
+		newStoragePruneCmd(),
 	)
 
 	return cmd
@@ -55,6 +58,7 @@ func newStorageEncryptionCmd() (cmd *cobra.Command) {
 	cmd.AddCommand(
 		newStorageEncryptionChangeKeyCmd(),
 		newStorageEncryptionCheckCmd(),
+		newStorageEncryptionReencryptCmd(),
 	)
 
 	return cmd
@@ -84,6 +88,17 @@ func newStorageEncryptionChangeKeyCmd() (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageEncryptionReencryptCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "re-encrypt",
+		Short: "Encrypts the data encrypted with the decryption keys again with the encryption key",
+		Args:  cobra.NoArgs,
+		RunE:  storageSchemaEncryptionReencryptRunE,
+	}
+
+	return cmd
+}
+
 func newStorageTOTPCmd() (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:   "totp",
@@ -139,6 +154,49 @@ func newStorageTOTPExportCmd() (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageExportCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "export",
+		Short: "Exports the data of every table as NDJSON which can be imported into any storage provider",
+		Args:  cobra.NoArgs,
+		RunE:  storageExportRunE,
+	}
+
+	cmd.Flags().String("file", "", "the file to write the export to, by default it's written to stdout")
+
+	return cmd
+}
+
+func newStorageImportCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "import",
+		Short: "Imports the data from an export into an empty database",
+		Args:  cobra.NoArgs,
+		RunE:  storageImportRunE,
+	}
+
+	cmd.Flags().String("file", "", "the file to read the export from")
+
+	return cmd
+}
+
+func newStoragePruneCmd() (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Prunes the authentication logs, identity verifications, and regulation bans older than the retention",
+		Args:  cobra.NoArgs,
+		RunE:  storagePruneRunE,
+	}
+
+	cmd.Flags().String("authentication-logs", "", "the retention of the authentication logs, overrides the configuration")
+	cmd.Flags().String("identity-verification", "", "the retention of the identity verifications, overrides the configuration")
+	cmd.Flags().String("regulation-bans", "", "the retention of the regulation bans, overrides the configuration")
+	cmd.Flags().Int("batch-size", 0, "the number of rows deleted at a time, overrides the configuration")
+	cmd.Flags().Bool("dry-run", false, "counts the rows which would be pruned without deleting them")
+
+	return cmd
+}
+
 func newStorageSchemaInfoCmd() (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:   "schema-info",
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -13,8 +14,12 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/totp"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func storagePersistentPreRunE(cmd *cobra.Command, _ []string) (err error) {
@@ -60,6 +65,11 @@ func storagePersistentPreRunE(cmd *cobra.Command, _ []string) (err error) {
 		"postgres.ssl.certificate":      "storage.postgres.ssl.certificate",
 		"postgres.ssl.key":              "storage.postgres.ssl.key",
 
+		"authentication-logs":   "storage.retention.authentication_logs",
+		"identity-verification": "storage.retention.identity_verification",
+		"regulation-bans":       "storage.retention.regulation_bans",
+		"batch-size":            "storage.retention.batch_size",
+
 		"period":    "totp.period",
 		"digits":    "totp.digits",
 		"algorithm": "totp.algorithm",
@@ -149,6 +159,32 @@ func storageSchemaEncryptionCheckRunE(cmd *cobra.Command, args []string) (err er
 	return nil
 }
 
+func storageSchemaEncryptionReencryptRunE(_ *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		ctx      = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	count, err := provider.SchemaEncryptionReencrypt(ctx)
+	if err != nil {
+		return fmt.Errorf("error encrypting the data again after %d values: %w", count, err)
+	}
+
+	fmt.Printf("Encrypted %d values again with the encryption key. The decryption keys can be removed from the configuration.\n", count)
+
+	return nil
+}
+
 func storageSchemaEncryptionChangeKeyRunE(cmd *cobra.Command, args []string) (err error) {
 	var (
 		provider storage.Provider
@@ -261,8 +297,187 @@ func storageTOTPDeleteRunE(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("can't delete configuration for user '%s': %+v", user, err)
 	}
 
+	// Removing a second factor device invalidates all sessions of the user.
+	if err = provider.IncrementSessionGeneration(ctx, user); err != nil {
+		return fmt.Errorf("can't invalidate sessions for user '%s': %+v", user, err)
+	}
+
 	fmt.Printf("Deleted TOTP configuration for user '%s'.", user)
 
+	storageNotifySecurityEvent(notification.SecurityEvent{
+		Type:     notification.SecurityEventDeviceRemoved,
+		Username: user,
+		Device:   regulation.AuthTypeTOTP,
+		Time:     time.Now(),
+	})
+
+	return nil
+}
+
+// storageNotifySecurityEvent notifies the user of a security event caused by a storage command if the configuration has
+// a notifier. Failing to notify the user is only reported as the command itself has succeeded.
+func storageNotifySecurityEvent(event notification.SecurityEvent) {
+	if config.Notifier == nil {
+		return
+	}
+
+	val := schema.NewStructValidator()
+
+	validator.ValidateNotifier(config.Notifier, val)
+	validator.ValidateAuthenticationBackend(&config.AuthenticationBackend, val)
+
+	if val.HasErrors() {
+		fmt.Printf("\nNot notifying user '%s' as the notifier or authentication backend configuration is invalid: %v", event.Username, val.Errors()[0])
+
+		return
+	}
+
+	certPool, _, errs := utils.NewX509CertPool(config.CertificatesDirectory)
+	if len(errs) != 0 {
+		fmt.Printf("\nNot notifying user '%s' as the certificates could not be loaded: %v", event.Username, errs[0])
+
+		return
+	}
+
+	templatesProvider, err := templates.NewProvider(config.Notifier.TemplatePath, config.Notifier.TemplateLocale)
+	if err != nil {
+		fmt.Printf("\nNot notifying user '%s' as the email templates could not be loaded: %v", event.Username, err)
+
+		return
+	}
+
+	securityEvents := notification.NewSecurityEventNotifier(config.Notifier, getNotifier(certPool), templatesProvider, getUserProvider(certPool))
+
+	if err = securityEvents.Notify(event); err != nil {
+		fmt.Printf("\nUnable to notify user '%s': %v", event.Username, err)
+	}
+}
+
+func storageExportRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		ctx      = context.Background()
+	)
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	path, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+
+	if path != "" {
+		if out, err = os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600); err != nil {
+			return fmt.Errorf("can't create the export file: %w", err)
+		}
+
+		defer func() {
+			_ = out.Close()
+		}()
+	}
+
+	count, err := provider.ExportData(ctx, out)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d rows. The export contains the TOTP secrets and U2F public keys in clear text.\n", count)
+
+	return nil
+}
+
+func storageImportRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		ctx      = context.Background()
+	)
+
+	path, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+
+	if path == "" {
+		return errors.New("you must set the --file flag")
+	}
+
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("can't open the import file: %w", err)
+	}
+
+	defer func() {
+		_ = in.Close()
+	}()
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	count, err := provider.ImportData(ctx, in)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported %d rows.\n", count)
+
+	return nil
+}
+
+func storagePruneRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		provider storage.Provider
+		ctx      = context.Background()
+	)
+
+	if config.Storage.Retention == nil {
+		return errors.New("the storage retention must be configured or set with the flags")
+	}
+
+	dryRun, err := cmd.Flags().GetBool("dry-run")
+	if err != nil {
+		return err
+	}
+
+	provider = getStorageProvider()
+
+	defer func() {
+		_ = provider.Close()
+	}()
+
+	if err = checkStorageSchemaUpToDate(ctx, provider); err != nil {
+		return err
+	}
+
+	result, err := provider.PruneData(ctx, storage.NewRetentionJanitor(config.Storage.Retention, provider).Options(dryRun))
+	if err != nil {
+		return err
+	}
+
+	action := "Pruned"
+	if dryRun {
+		action = "Would prune"
+	}
+
+	fmt.Printf("%s %d authentication logs, %d identity verifications, and %d regulation bans.\n", action,
+		result.AuthenticationLogs, result.IdentityVerification, result.RegulationBans)
+
 	return nil
 }
 
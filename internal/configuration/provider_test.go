@@ -223,6 +223,24 @@ func TestShouldValidateAndRaiseErrorsOnBadConfiguration(t *testing.T) {
 	assert.EqualError(t, val.Errors()[1], "invalid configuration key 'logs_level' was replaced by 'log.level'")
 }
 
+func TestShouldLoadStorageReplicas(t *testing.T) {
+	testReset()
+
+	val := schema.NewStructValidator()
+	keys, config, err := Load(val, NewDefaultSources([]string{"./test_resources/config_storage_replicas.yml"}, DefaultEnvPrefix, DefaultEnvDelimiter)...)
+
+	assert.NoError(t, err)
+
+	validator.ValidateKeys(keys, DefaultEnvPrefix, val)
+
+	assert.Len(t, val.Errors(), 0)
+	assert.Len(t, val.Warnings(), 0)
+
+	require.Len(t, config.Storage.MySQL.Replicas, 2)
+	assert.Equal(t, schema.SQLStorageReplicaConfiguration{Host: "replica1.example.com"}, config.Storage.MySQL.Replicas[0])
+	assert.Equal(t, schema.SQLStorageReplicaConfiguration{Host: "replica2.example.com", Port: 3307}, config.Storage.MySQL.Replicas[1])
+}
+
 func TestShouldRaiseErrOnInvalidNotifierSMTPSender(t *testing.T) {
 	testReset()
 
@@ -5,6 +5,7 @@ type AccessControlConfiguration struct {
 	DefaultPolicy string       `koanf:"default_policy"`
 	Networks      []ACLNetwork `koanf:"networks"`
 	Rules         []ACLRule    `koanf:"rules"`
+	RulesDir      string       `koanf:"rules_dir"`
 }
 
 // ACLNetwork represents one ACL network group entry; "weak" coerces a single value into slice.
@@ -21,6 +22,8 @@ type ACLRule struct {
 	Networks  []string   `koanf:"networks"`
 	Resources []string   `koanf:"resources"`
 	Methods   []string   `koanf:"methods"`
+
+	MaxAuthenticationAge string `koanf:"max_authentication_age"`
 }
 
 // DefaultACLNetwork represents the default configuration related to access control network group configuration.
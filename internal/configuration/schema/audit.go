@@ -0,0 +1,36 @@
+package schema
+
+import (
+	"time"
+)
+
+// AuditConfiguration represents the configuration of the audit log. Exactly one of the sinks must be configured.
+type AuditConfiguration struct {
+	File   *AuditFileConfiguration `koanf:"file"`
+	Syslog *SyslogConfiguration    `koanf:"syslog"`
+	HTTP   *AuditHTTPConfiguration `koanf:"http"`
+}
+
+// AuditFileConfiguration represents the configuration of the file the audit events are appended to.
+type AuditFileConfiguration struct {
+	Path string `koanf:"path"`
+}
+
+// AuditHTTPConfiguration represents the configuration of the HTTP endpoint the audit events are sent to.
+type AuditHTTPConfiguration struct {
+	URL     string                       `koanf:"url"`
+	Timeout time.Duration                `koanf:"timeout"`
+	Headers []WebhookHeaderConfiguration `koanf:"headers"`
+	TLS     *TLSConfig                   `koanf:"tls"`
+}
+
+// DefaultAuditSyslogFacility represents the default syslog facility of the audit events.
+const DefaultAuditSyslogFacility = "authpriv"
+
+// DefaultAuditHTTPConfiguration represents the default values of the AuditHTTPConfiguration.
+var DefaultAuditHTTPConfiguration = AuditHTTPConfiguration{
+	Timeout: time.Second * 5,
+	TLS: &TLSConfig{
+		MinimumVersion: "TLS1.2",
+	},
+}
@@ -22,6 +22,7 @@ type LDAPAuthenticationBackendConfiguration struct {
 	UsernameAttribute    string `koanf:"username_attribute"`
 	MailAttribute        string `koanf:"mail_attribute"`
 	DisplayNameAttribute string `koanf:"display_name_attribute"`
+	LocaleAttribute      string `koanf:"locale_attribute"`
 
 	User     string `koanf:"user"`
 	Password string `koanf:"password"`
@@ -15,8 +15,11 @@ type Configuration struct {
 	DuoAPI                *DuoAPIConfiguration               `koanf:"duo_api"`
 	AccessControl         AccessControlConfiguration         `koanf:"access_control"`
 	NTP                   *NTPConfiguration                  `koanf:"ntp"`
+	GeoIP                 *GeoIPConfiguration                `koanf:"geoip"`
 	Regulation            *RegulationConfiguration           `koanf:"regulation"`
 	Storage               StorageConfiguration               `koanf:"storage"`
 	Notifier              *NotifierConfiguration             `koanf:"notifier"`
 	Server                ServerConfiguration                `koanf:"server"`
+	Tracing               TracingConfiguration               `koanf:"tracing"`
+	Audit                 *AuditConfiguration                `koanf:"audit"`
 }
@@ -35,3 +35,49 @@ var (
 	// TOTPPossibleAlgorithms is a list of valid TOTP Algorithms.
 	TOTPPossibleAlgorithms = []string{TOTPAlgorithmSHA1, TOTPAlgorithmSHA256, TOTPAlgorithmSHA512}
 )
+
+// Administrative API scopes.
+const (
+	AdminScopeDevicesRead     = "devices:read"
+	AdminScopeDevicesWrite    = "devices:write"
+	AdminScopeRegulationWrite = "regulation:write"
+	AdminScopeSessionsRead    = "sessions:read"
+	AdminScopeSessionsWrite   = "sessions:write"
+	AdminScopeLogsRead        = "logs:read"
+)
+
+var (
+	// AdminPossibleScopes is a list of valid administrative API scopes.
+	AdminPossibleScopes = []string{
+		AdminScopeDevicesRead, AdminScopeDevicesWrite, AdminScopeRegulationWrite,
+		AdminScopeSessionsRead, AdminScopeSessionsWrite, AdminScopeLogsRead,
+	}
+)
+
+// Tracing exporters.
+const (
+	TracingExporterOTLP = "otlp"
+	TracingExporterFile = "file"
+)
+
+// Tracing OTLP protocols.
+const (
+	TracingOTLPProtocolGRPC = "grpc"
+	TracingOTLPProtocolHTTP = "http"
+)
+
+// Syslog networks.
+const (
+	SyslogNetworkUDP  = "udp"
+	SyslogNetworkTCP  = "tcp"
+	SyslogNetworkUnix = "unix"
+)
+
+var (
+	// SyslogFacilities is the code of each syslog facility by name.
+	SyslogFacilities = map[string]int{
+		"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7, "uucp": 8,
+		"cron": 9, "authpriv": 10, "ftp": 11, "local0": 16, "local1": 17, "local2": 18, "local3": 19,
+		"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+	}
+)
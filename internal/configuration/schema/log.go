@@ -1,11 +1,28 @@
 package schema
 
+import (
+	"time"
+)
+
 // LogConfiguration represents the logging configuration.
 type LogConfiguration struct {
 	Level      string `koanf:"level"`
 	Format     string `koanf:"format"`
 	FilePath   string `koanf:"file_path"`
 	KeepStdout bool   `koanf:"keep_stdout"`
+
+	Rotation *LogRotationConfiguration `koanf:"rotation"`
+	Syslog   *SyslogConfiguration      `koanf:"syslog"`
+	Journald bool                      `koanf:"journald"`
+}
+
+// LogRotationConfiguration represents the configuration of the rotation of the log file. The file is rotated when it
+// exceeds MaxSize megabytes or when Interval has elapsed, whichever happens first.
+type LogRotationConfiguration struct {
+	MaxSize    int           `koanf:"max_size"`
+	Interval   time.Duration `koanf:"interval"`
+	MaxBackups int           `koanf:"max_backups"`
+	Compress   bool          `koanf:"compress"`
 }
 
 // DefaultLoggingConfiguration is the default logging configuration.
@@ -13,3 +30,6 @@ var DefaultLoggingConfiguration = LogConfiguration{
 	Level:  "info",
 	Format: "text",
 }
+
+// DefaultLogSyslogFacility represents the default syslog facility of the log messages.
+const DefaultLogSyslogFacility = "daemon"
@@ -26,11 +26,68 @@ type SMTPNotifierConfiguration struct {
 	TLS                 *TLSConfig    `koanf:"tls"`
 }
 
+// WebhookNotifierConfiguration represents the configuration of the HTTP endpoint to send notifications to.
+type WebhookNotifierConfiguration struct {
+	URL     string                       `koanf:"url"`
+	Method  string                       `koanf:"method"`
+	Timeout time.Duration                `koanf:"timeout"`
+	Headers []WebhookHeaderConfiguration `koanf:"headers"`
+	Fields  []WebhookFieldConfiguration  `koanf:"fields"`
+	TLS     *TLSConfig                   `koanf:"tls"`
+}
+
+// WebhookHeaderConfiguration represents a HTTP header sent with each webhook request.
+type WebhookHeaderConfiguration struct {
+	Name  string `koanf:"name"`
+	Value string `koanf:"value"`
+}
+
+// WebhookFieldConfiguration represents a templated field of the JSON object sent with each webhook request.
+type WebhookFieldConfiguration struct {
+	Name     string `koanf:"name"`
+	Template string `koanf:"template"`
+}
+
+// SendmailNotifierConfiguration represents the configuration of the sendmail compatible command to send emails with.
+type SendmailNotifierConfiguration struct {
+	Path              string        `koanf:"path"`
+	Args              []string      `koanf:"args"`
+	Timeout           time.Duration `koanf:"timeout"`
+	Sender            mail.Address  `koanf:"sender"`
+	Subject           string        `koanf:"subject"`
+	DisableHTMLEmails bool          `koanf:"disable_html_emails"`
+}
+
+// SecurityEventsNotifierConfiguration represents the configuration of the notifications sent to users about security
+// relevant changes to their accounts.
+type SecurityEventsNotifierConfiguration struct {
+	Disable                 bool   `koanf:"disable"`
+	DisablePasswordChanged  bool   `koanf:"disable_password_changed"`
+	DisableDeviceRegistered bool   `koanf:"disable_device_registered"`
+	DisableDeviceRemoved    bool   `koanf:"disable_device_removed"`
+	DisableNewLogin         bool   `koanf:"disable_new_login"`
+	DisableAccountBanned    bool   `koanf:"disable_account_banned"`
+	NewLoginLookback        string `koanf:"new_login_lookback"`
+}
+
 // NotifierConfiguration represents the configuration of the notifier to use when sending notifications to users.
 type NotifierConfiguration struct {
-	DisableStartupCheck bool                             `koanf:"disable_startup_check"`
-	FileSystem          *FileSystemNotifierConfiguration `koanf:"filesystem"`
-	SMTP                *SMTPNotifierConfiguration       `koanf:"smtp"`
+	DisableStartupCheck bool                                `koanf:"disable_startup_check"`
+	TemplatePath        string                              `koanf:"template_path"`
+	TemplateLocale      string                              `koanf:"template_locale"`
+	SecurityEvents      SecurityEventsNotifierConfiguration `koanf:"security_events"`
+	FileSystem          *FileSystemNotifierConfiguration    `koanf:"filesystem"`
+	SMTP                *SMTPNotifierConfiguration          `koanf:"smtp"`
+	Webhook             *WebhookNotifierConfiguration       `koanf:"webhook"`
+	Sendmail            *SendmailNotifierConfiguration      `koanf:"sendmail"`
+}
+
+// DefaultNotifierConfiguration represents default configuration parameters for the notifier.
+var DefaultNotifierConfiguration = NotifierConfiguration{
+	TemplateLocale: "en",
+	SecurityEvents: SecurityEventsNotifierConfiguration{
+		NewLoginLookback: "90d",
+	},
 }
 
 // DefaultSMTPNotifierConfiguration represents default configuration parameters for the SMTP notifier.
@@ -43,3 +100,26 @@ var DefaultSMTPNotifierConfiguration = SMTPNotifierConfiguration{
 		MinimumVersion: "TLS1.2",
 	},
 }
+
+// DefaultWebhookNotifierConfiguration represents default configuration parameters for the webhook notifier.
+var DefaultWebhookNotifierConfiguration = WebhookNotifierConfiguration{
+	Method:  "POST",
+	Timeout: time.Second * 5,
+	Fields: []WebhookFieldConfiguration{
+		{Name: "recipient", Template: "{{ .Recipient }}"},
+		{Name: "subject", Template: "[Authelia] {{ .Title }}"},
+		{Name: "body", Template: "{{ .Body }}"},
+		{Name: "html_body", Template: "{{ .HTMLBody }}"},
+	},
+	TLS: &TLSConfig{
+		MinimumVersion: "TLS1.2",
+	},
+}
+
+// DefaultSendmailNotifierConfiguration represents default configuration parameters for the sendmail notifier.
+var DefaultSendmailNotifierConfiguration = SendmailNotifierConfiguration{
+	Path:    "/usr/sbin/sendmail",
+	Args:    []string{"-t", "-i"},
+	Timeout: time.Second * 5,
+	Subject: "[Authelia] {title}",
+}
@@ -5,6 +5,46 @@ type RegulationConfiguration struct {
 	MaxRetries int    `koanf:"max_retries"`
 	FindTime   string `koanf:"find_time,weak"`
 	BanTime    string `koanf:"ban_time,weak"`
+
+	TrustedCountries  []string `koanf:"trusted_countries"`
+	ForeignMaxRetries int      `koanf:"foreign_max_retries"`
+
+	IP     *RegulationLimitConfiguration  `koanf:"ip"`
+	Subnet *RegulationSubnetConfiguration `koanf:"subnet"`
+	Global *RegulationLimitConfiguration  `koanf:"global"`
+
+	TOTP                 *RegulationLimitConfiguration                `koanf:"totp"`
+	IdentityVerification *RegulationIdentityVerificationConfiguration `koanf:"identity_verification"`
+
+	Backoff *RegulationBackoffConfiguration `koanf:"backoff"`
+}
+
+// RegulationLimitConfiguration represents the thresholds of a regulation limit.
+type RegulationLimitConfiguration struct {
+	MaxRetries int    `koanf:"max_retries"`
+	FindTime   string `koanf:"find_time,weak"`
+	BanTime    string `koanf:"ban_time,weak"`
+}
+
+// RegulationSubnetConfiguration represents the thresholds of the subnet regulation limit and the size of the subnets.
+type RegulationSubnetConfiguration struct {
+	RegulationLimitConfiguration `koanf:",squash"`
+
+	IPv4PrefixLength int `koanf:"ipv4_prefix_length"`
+	IPv6PrefixLength int `koanf:"ipv6_prefix_length"`
+}
+
+// RegulationIdentityVerificationConfiguration represents the limits of the identity verification requests.
+type RegulationIdentityVerificationConfiguration struct {
+	User *RegulationLimitConfiguration `koanf:"user"`
+	IP   *RegulationLimitConfiguration `koanf:"ip"`
+}
+
+// RegulationBackoffConfiguration represents the configuration of the escalation of the ban time for repeat offenders.
+type RegulationBackoffConfiguration struct {
+	Multiplier int    `koanf:"multiplier"`
+	MaxBanTime string `koanf:"max_ban_time,weak"`
+	ResetTime  string `koanf:"reset_time,weak"`
 }
 
 // DefaultRegulationConfiguration represents default configuration parameters for the regulator.
@@ -13,3 +53,16 @@ var DefaultRegulationConfiguration = RegulationConfiguration{
 	FindTime:   "2m",
 	BanTime:    "5m",
 }
+
+// DefaultRegulationSubnetConfiguration represents default configuration parameters for the subnet regulation limit.
+var DefaultRegulationSubnetConfiguration = RegulationSubnetConfiguration{
+	IPv4PrefixLength: 24,
+	IPv6PrefixLength: 64,
+}
+
+// DefaultRegulationBackoffConfiguration represents default configuration parameters for the escalation of the ban time.
+var DefaultRegulationBackoffConfiguration = RegulationBackoffConfiguration{
+	Multiplier: 2,
+	MaxBanTime: "1d",
+	ResetTime:  "1d",
+}
@@ -12,7 +12,15 @@ type ServerConfiguration struct {
 	EnableExpvars      bool   `koanf:"enable_endpoint_expvars"`
 	DisableHealthcheck bool   `koanf:"disable_healthcheck"`
 
+	TrustedProxies        []string `koanf:"trusted_proxies"`
+	EnableProxyProtocol   bool     `koanf:"enable_proxy_protocol"`
+	EnableForwardedHeader bool     `koanf:"enable_forwarded_header"`
+
 	TLS ServerTLSConfiguration `koanf:"tls"`
+
+	Admin *ServerAdminConfiguration `koanf:"admin"`
+
+	Metrics ServerMetricsConfiguration `koanf:"metrics"`
 }
 
 // ServerTLSConfiguration represents the configuration of the http servers TLS options.
@@ -21,6 +29,30 @@ type ServerTLSConfiguration struct {
 	Key         string `koanf:"key"`
 }
 
+// ServerAdminConfiguration represents the configuration of the administrative API server.
+type ServerAdminConfiguration struct {
+	Host   string                          `koanf:"host"`
+	Port   int                             `koanf:"port"`
+	TLS    ServerTLSConfiguration          `koanf:"tls"`
+	Tokens []ServerAdminTokenConfiguration `koanf:"tokens"`
+}
+
+// ServerAdminTokenConfiguration represents a bearer token of the administrative API and the scopes it's granted.
+type ServerAdminTokenConfiguration struct {
+	Name   string   `koanf:"name"`
+	Token  string   `koanf:"token"`
+	Scopes []string `koanf:"scopes"`
+}
+
+// ServerMetricsConfiguration represents the configuration of the metrics endpoint. The metrics are served on their own
+// listener unless they're explicitly served by the server.
+type ServerMetricsConfiguration struct {
+	Enabled        bool   `koanf:"enabled"`
+	Host           string `koanf:"host"`
+	Port           int    `koanf:"port"`
+	ServerListener bool   `koanf:"server_listener"`
+}
+
 // DefaultServerConfiguration represents the default values of the ServerConfiguration.
 var DefaultServerConfiguration = ServerConfiguration{
 	Host:            "0.0.0.0",
@@ -28,3 +60,15 @@ var DefaultServerConfiguration = ServerConfiguration{
 	ReadBufferSize:  4096,
 	WriteBufferSize: 4096,
 }
+
+// DefaultServerAdminConfiguration represents the default values of the ServerAdminConfiguration.
+var DefaultServerAdminConfiguration = ServerAdminConfiguration{
+	Host: "127.0.0.1",
+	Port: 9092,
+}
+
+// DefaultServerMetricsConfiguration represents the default values of the ServerMetricsConfiguration.
+var DefaultServerMetricsConfiguration = ServerMetricsConfiguration{
+	Host: "127.0.0.1",
+	Port: 9959,
+}
@@ -6,14 +6,27 @@ type RedisNode struct {
 	Port int    `koanf:"port"`
 }
 
+// RedisRouteConfiguration holds the options routing the read-only commands to the replicas.
+type RedisRouteConfiguration struct {
+	RouteByLatency bool `koanf:"route_by_latency"`
+	RouteRandomly  bool `koanf:"route_randomly"`
+}
+
 // RedisHighAvailabilityConfiguration holds configuration variables for Redis Cluster/Sentinel.
 type RedisHighAvailabilityConfiguration struct {
 	SentinelName     string      `koanf:"sentinel_name"`
 	SentinelUsername string      `koanf:"sentinel_username"`
 	SentinelPassword string      `koanf:"sentinel_password"`
 	Nodes            []RedisNode `koanf:"nodes"`
-	RouteByLatency   bool        `koanf:"route_by_latency"`
-	RouteRandomly    bool        `koanf:"route_randomly"`
+
+	RedisRouteConfiguration `koanf:",squash"`
+}
+
+// RedisClusterConfiguration holds configuration variables for Redis Cluster.
+type RedisClusterConfiguration struct {
+	Nodes []RedisNode `koanf:"nodes"`
+
+	RedisRouteConfiguration `koanf:",squash"`
 }
 
 // RedisSessionConfiguration represents the configuration related to redis session store.
@@ -27,18 +40,35 @@ type RedisSessionConfiguration struct {
 	MinimumIdleConnections   int                                 `koanf:"minimum_idle_connections"`
 	TLS                      *TLSConfig                          `koanf:"tls"`
 	HighAvailability         *RedisHighAvailabilityConfiguration `koanf:"high_availability"`
+	Cluster                  *RedisClusterConfiguration          `koanf:"cluster"`
+}
+
+// SessionCookieConfiguration represents the configuration of the session cookie of a single domain.
+type SessionCookieConfiguration struct {
+	Domain      string `koanf:"domain"`
+	AutheliaURL string `koanf:"authelia_url"`
+	Name        string `koanf:"name"`
+	SameSite    string `koanf:"same_site"`
+	Expiration  string `koanf:"expiration"`
+}
+
+// SessionStorageConfiguration represents the configuration related to the storage session store.
+type SessionStorageConfiguration struct {
+	GarbageCollectionInterval string `koanf:"garbage_collection_interval"`
 }
 
 // SessionConfiguration represents the configuration related to user sessions.
 type SessionConfiguration struct {
-	Name               string                     `koanf:"name"`
-	Domain             string                     `koanf:"domain"`
-	SameSite           string                     `koanf:"same_site"`
-	Secret             string                     `koanf:"secret"`
-	Expiration         string                     `koanf:"expiration"`
-	Inactivity         string                     `koanf:"inactivity"`
-	RememberMeDuration string                     `koanf:"remember_me_duration"`
-	Redis              *RedisSessionConfiguration `koanf:"redis"`
+	Name               string                       `koanf:"name"`
+	Domain             string                       `koanf:"domain"`
+	SameSite           string                       `koanf:"same_site"`
+	Secret             string                       `koanf:"secret"`
+	Expiration         string                       `koanf:"expiration"`
+	Inactivity         string                       `koanf:"inactivity"`
+	RememberMeDuration string                       `koanf:"remember_me_duration"`
+	Cookies            []SessionCookieConfiguration `koanf:"cookies"`
+	Redis              *RedisSessionConfiguration   `koanf:"redis"`
+	Storage            *SessionStorageConfiguration `koanf:"storage"`
 }
 
 // DefaultSessionConfiguration is the default session configuration.
@@ -49,3 +79,8 @@ var DefaultSessionConfiguration = SessionConfiguration{
 	RememberMeDuration: "1M",
 	SameSite:           "lax",
 }
+
+// DefaultSessionStorageConfiguration is the default storage session store configuration.
+var DefaultSessionStorageConfiguration = SessionStorageConfiguration{
+	GarbageCollectionInterval: "5m",
+}
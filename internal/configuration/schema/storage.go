@@ -15,6 +15,26 @@ type SQLStorageConfiguration struct {
 	Username string        `koanf:"username"`
 	Password string        `koanf:"password"`
 	Timeout  time.Duration `koanf:"timeout"`
+
+	QueryTimeout time.Duration `koanf:"query_timeout"`
+
+	Pool     SQLStoragePoolConfiguration      `koanf:"pool"`
+	Replicas []SQLStorageReplicaConfiguration `koanf:"replicas"`
+}
+
+// SQLStoragePoolConfiguration represents the configuration of the pool of connections to the SQL database.
+type SQLStoragePoolConfiguration struct {
+	MaxOpen     int           `koanf:"max_open"`
+	MaxIdle     int           `koanf:"max_idle"`
+	MaxLifetime time.Duration `koanf:"max_lifetime"`
+	MaxIdleTime time.Duration `koanf:"max_idle_time"`
+}
+
+// SQLStorageReplicaConfiguration represents the configuration of a read only replica of the SQL database. The other
+// connection options of the replica are the same as the ones of the database.
+type SQLStorageReplicaConfiguration struct {
+	Host string `koanf:"host"`
+	Port int    `koanf:"port"`
 }
 
 // MySQLStorageConfiguration represents the configuration of a MySQL database.
@@ -47,7 +67,21 @@ type StorageConfiguration struct {
 	MySQL      *MySQLStorageConfiguration      `koanf:"mysql"`
 	PostgreSQL *PostgreSQLStorageConfiguration `koanf:"postgres"`
 
-	EncryptionKey string `koanf:"encryption_key"`
+	EncryptionKey  string   `koanf:"encryption_key"`
+	DecryptionKeys []string `koanf:"decryption_keys"`
+
+	Retention *StorageRetentionConfiguration `koanf:"retention"`
+}
+
+// StorageRetentionConfiguration represents the configuration of how long the rows of the tables which grow with every
+// request are kept before they're pruned.
+type StorageRetentionConfiguration struct {
+	AuthenticationLogs   string `koanf:"authentication_logs,weak"`
+	IdentityVerification string `koanf:"identity_verification,weak"`
+	RegulationBans       string `koanf:"regulation_bans,weak"`
+
+	Interval  string `koanf:"interval,weak"`
+	BatchSize int    `koanf:"batch_size"`
 }
 
 // DefaultSQLStorageConfiguration represents the default SQL configuration.
@@ -55,6 +89,12 @@ var DefaultSQLStorageConfiguration = SQLStorageConfiguration{
 	Timeout: 5 * time.Second,
 }
 
+// DefaultStorageRetentionConfiguration represents the default retention configuration.
+var DefaultStorageRetentionConfiguration = StorageRetentionConfiguration{
+	Interval:  "1h",
+	BatchSize: 1000,
+}
+
 // DefaultPostgreSQLStorageConfiguration represents the default PostgreSQL configuration.
 var DefaultPostgreSQLStorageConfiguration = PostgreSQLStorageConfiguration{
 	Schema: "public",
@@ -0,0 +1,22 @@
+package schema
+
+// SyslogConfiguration represents the configuration of a syslog server the messages are sent to.
+type SyslogConfiguration struct {
+	Network  string `koanf:"network"`
+	Address  string `koanf:"address"`
+	AppName  string `koanf:"app_name"`
+	Facility string `koanf:"facility"`
+}
+
+// DefaultSyslogConfiguration represents the default values of the SyslogConfiguration.
+var DefaultSyslogConfiguration = SyslogConfiguration{
+	Network: SyslogNetworkUDP,
+	AppName: "authelia",
+}
+
+// DefaultSyslogAddresses represents the default addresses of the syslog networks.
+var DefaultSyslogAddresses = map[string]string{
+	SyslogNetworkUDP:  "localhost:514",
+	SyslogNetworkTCP:  "localhost:514",
+	SyslogNetworkUnix: "/dev/log",
+}
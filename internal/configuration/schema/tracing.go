@@ -0,0 +1,40 @@
+package schema
+
+// TracingConfiguration represents the configuration related to the OpenTelemetry tracing.
+type TracingConfiguration struct {
+	Enabled       bool    `koanf:"enabled"`
+	ServiceName   string  `koanf:"service_name"`
+	SamplingRatio float64 `koanf:"sampling_ratio"`
+	Exporter      string  `koanf:"exporter"`
+
+	OTLP TracingOTLPConfiguration `koanf:"otlp"`
+	File TracingFileConfiguration `koanf:"file"`
+}
+
+// TracingOTLPConfiguration represents the configuration of the OTLP exporter of the spans.
+type TracingOTLPConfiguration struct {
+	Endpoint string `koanf:"endpoint"`
+	Protocol string `koanf:"protocol"`
+	Insecure bool   `koanf:"insecure"`
+}
+
+// TracingFileConfiguration represents the configuration of the exporter which writes the spans to a file.
+type TracingFileConfiguration struct {
+	Path string `koanf:"path"`
+}
+
+// DefaultTracingConfiguration represents the default values of the TracingConfiguration.
+var DefaultTracingConfiguration = TracingConfiguration{
+	ServiceName:   "authelia",
+	SamplingRatio: 1,
+	Exporter:      TracingExporterOTLP,
+	OTLP: TracingOTLPConfiguration{
+		Protocol: TracingOTLPProtocolGRPC,
+	},
+}
+
+// DefaultTracingOTLPEndpoints represents the default endpoints of the OTLP protocols.
+var DefaultTracingOTLPEndpoints = map[string]string{
+	TracingOTLPProtocolGRPC: "localhost:4317",
+	TracingOTLPProtocolHTTP: "localhost:4318",
+}
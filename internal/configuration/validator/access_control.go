@@ -3,7 +3,9 @@ package validator
 import (
 	"fmt"
 	"net"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -23,7 +25,19 @@ func IsResourceValid(resource string) (err error) {
 
 // IsSubjectValid check if a subject is valid.
 func IsSubjectValid(subject string) (isValid bool) {
-	return subject == "" || strings.HasPrefix(subject, "user:") || strings.HasPrefix(subject, "group:")
+	if subject == "" {
+		return true
+	}
+
+	subject = strings.TrimPrefix(subject, subjectPrefixNegation)
+
+	for _, prefix := range validACLSubjectPrefixes {
+		if strings.HasPrefix(subject, prefix) && strings.Trim(subject[len(prefix):], " ") != "" {
+			return true
+		}
+	}
+
+	return false
 }
 
 // IsNetworkGroupValid check if a network group is valid.
@@ -39,6 +53,20 @@ func IsNetworkGroupValid(configuration schema.AccessControlConfiguration, networ
 	return false
 }
 
+// IsNetworkGeoIPValid check if a network is a valid GeoIP country or ASN criteria i.e. 'country:NZ' or 'asn:13335'.
+func IsNetworkGeoIPValid(network string) (isValid bool) {
+	switch {
+	case strings.HasPrefix(network, networkPrefixCountry):
+		return reISOCountryCode.MatchString(strings.TrimSpace(network[len(networkPrefixCountry):]))
+	case strings.HasPrefix(network, networkPrefixASN):
+		_, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(network[len(networkPrefixASN):]), "AS"), 10, 32)
+
+		return err == nil
+	default:
+		return false
+	}
+}
+
 // IsNetworkValid check if a network is valid.
 func IsNetworkValid(network string) (isValid bool) {
 	if net.ParseIP(network) == nil {
@@ -68,11 +96,30 @@ func ValidateAccessControl(configuration *schema.AccessControlConfiguration, val
 			}
 		}
 	}
+
+	if configuration.RulesDir != "" {
+		validateRulesDir(configuration.RulesDir, validator)
+	}
+}
+
+func validateRulesDir(dir string, validator *schema.StructValidator) {
+	info, err := os.Stat(dir)
+
+	switch {
+	case err != nil:
+		validator.Push(fmt.Errorf("Rules directory %s is invalid: %w", dir, err))
+	case !info.IsDir():
+		validator.Push(fmt.Errorf("Rules directory %s is invalid: it must be a directory", dir))
+	}
 }
 
 // ValidateRules validates an ACL Rule configuration.
 func ValidateRules(configuration schema.AccessControlConfiguration, validator *schema.StructValidator) {
 	if configuration.Rules == nil || len(configuration.Rules) == 0 {
+		if configuration.RulesDir != "" {
+			return
+		}
+
 		if configuration.DefaultPolicy != policyOneFactor && configuration.DefaultPolicy != policyTwoFactor {
 			validator.Push(fmt.Errorf("Default Policy [%s] is invalid, access control rules must be provided or a policy must either be 'one_factor' or 'two_factor'", configuration.DefaultPolicy))
 
@@ -99,10 +146,12 @@ func ValidateRules(configuration schema.AccessControlConfiguration, validator *s
 
 		validateResources(rulePosition, rule, validator)
 
-		validateSubjects(rulePosition, rule, validator)
+		validateSubjects(rulePosition, rule, configuration, validator)
 
 		validateMethods(rulePosition, rule, validator)
 
+		validateMaxAuthenticationAge(rulePosition, rule, validator)
+
 		if rule.Policy == policyBypass && len(rule.Subjects) != 0 {
 			validator.Push(fmt.Errorf(errAccessControlInvalidPolicyWithSubjects, rulePosition, rule.Domains, rule.Subjects))
 		}
@@ -111,7 +160,7 @@ func ValidateRules(configuration schema.AccessControlConfiguration, validator *s
 
 func validateNetworks(rulePosition int, rule schema.ACLRule, configuration schema.AccessControlConfiguration, validator *schema.StructValidator) {
 	for _, network := range rule.Networks {
-		if !IsNetworkValid(network) {
+		if !IsNetworkValid(network) && !IsNetworkGeoIPValid(network) {
 			if !IsNetworkGroupValid(configuration, network) {
 				validator.Push(fmt.Errorf("Network %s for rule #%d domain: %s is not a valid network or network group", rule.Networks, rulePosition, rule.Domains))
 			}
@@ -127,11 +176,28 @@ func validateResources(rulePosition int, rule schema.ACLRule, validator *schema.
 	}
 }
 
-func validateSubjects(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+func validateSubjects(rulePosition int, rule schema.ACLRule, configuration schema.AccessControlConfiguration, validator *schema.StructValidator) {
 	for _, subjectRule := range rule.Subjects {
 		for _, subject := range subjectRule {
 			if !IsSubjectValid(subject) {
-				validator.Push(fmt.Errorf("Subject %s for rule #%d domain: %s is invalid, must start with 'user:' or 'group:'", subjectRule, rulePosition, rule.Domains))
+				validator.Push(fmt.Errorf(errFmtAccessControlInvalidSubject, subjectRule, rulePosition, rule.Domains, strings.Join(validACLSubjectPrefixes, "', '")))
+
+				continue
+			}
+
+			subject = strings.TrimPrefix(subject, subjectPrefixNegation)
+
+			switch {
+			case strings.HasPrefix(subject, subjectPrefixEmail):
+				if !strings.Contains(subject, "@") {
+					validator.Push(fmt.Errorf("Subject %s for rule #%d domain: %s is invalid, the email pattern '%s' must contain an '@'", subjectRule, rulePosition, rule.Domains, subject[len(subjectPrefixEmail):]))
+				}
+			case strings.HasPrefix(subject, subjectPrefixNetwork):
+				network := strings.Trim(subject[len(subjectPrefixNetwork):], " ")
+
+				if !IsNetworkValid(network) && !IsNetworkGroupValid(configuration, network) {
+					validator.Push(fmt.Errorf("Subject %s for rule #%d domain: %s is invalid, '%s' is not a valid network or network group", subjectRule, rulePosition, rule.Domains, network))
+				}
 			}
 		}
 	}
@@ -144,3 +210,17 @@ func validateMethods(rulePosition int, rule schema.ACLRule, validator *schema.St
 		}
 	}
 }
+
+func validateMaxAuthenticationAge(rulePosition int, rule schema.ACLRule, validator *schema.StructValidator) {
+	if rule.MaxAuthenticationAge == "" {
+		return
+	}
+
+	if _, err := utils.ParseDurationString(rule.MaxAuthenticationAge); err != nil {
+		validator.Push(fmt.Errorf("Max authentication age %s for rule #%d domain: %s is invalid, %s", rule.MaxAuthenticationAge, rulePosition, rule.Domains, err))
+	}
+
+	if rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf("Max authentication age for rule #%d domain: %s is invalid, it can only be configured with the 'two_factor' policy", rulePosition, rule.Domains))
+	}
+}
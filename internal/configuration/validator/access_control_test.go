@@ -2,6 +2,8 @@ package validator
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,6 +23,7 @@ func (suite *AccessControl) SetupTest() {
 	suite.configuration.DefaultPolicy = policyDeny
 	suite.configuration.Networks = schema.DefaultACLNetwork
 	suite.configuration.Rules = schema.DefaultACLRule
+	suite.configuration.RulesDir = ""
 }
 
 func (suite *AccessControl) TestShouldValidateCompleteConfiguration() {
@@ -81,6 +84,32 @@ func (suite *AccessControl) TestShouldRaiseWarningWithNoRulesDefined() {
 	suite.Assert().EqualError(suite.validator.Warnings()[0], "No access control rules have been defined so the default policy two_factor will be applied to all requests")
 }
 
+func (suite *AccessControl) TestShouldNotRaiseErrorWithNoRulesDefinedWhenRulesDirDefined() {
+	suite.configuration.Rules = []schema.ACLRule{}
+	suite.configuration.RulesDir = suite.T().TempDir()
+
+	ValidateAccessControl(&suite.configuration, suite.validator)
+	ValidateRules(suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidRulesDir() {
+	file := filepath.Join(suite.T().TempDir(), "rules.yml")
+
+	suite.Require().NoError(os.WriteFile(file, []byte("rules: []"), 0600))
+
+	suite.configuration.RulesDir = file
+
+	ValidateAccessControl(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], fmt.Sprintf("Rules directory %s is invalid: it must be a directory", file))
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorsWithEmptyRules() {
 	suite.configuration.Rules = []schema.ACLRule{{}, {}}
 
@@ -128,6 +157,29 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidNetwork() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "Network [abc.def.ghi.jkl/32] for rule #1 domain: [public.example.com] is not a valid network or network group")
 }
 
+func (suite *AccessControl) TestShouldValidateGeoIPNetworks() {
+	suite.configuration.Rules = []schema.ACLRule{
+		{
+			Domains:  []string{"public.example.com"},
+			Policy:   "bypass",
+			Networks: []string{"country:NZ", "asn:13335", "asn:AS9500"},
+		},
+		{
+			Domains:  []string{"private.example.com"},
+			Policy:   "bypass",
+			Networks: []string{"country:NZL", "asn:abc"},
+		},
+	}
+
+	ValidateRules(suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "Network [country:NZL asn:abc] for rule #2 domain: [private.example.com] is not a valid network or network group")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "Network [country:NZL asn:abc] for rule #2 domain: [private.example.com] is not a valid network or network group")
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorInvalidMethod() {
 	suite.configuration.Rules = []schema.ACLRule{
 		{
@@ -178,10 +230,84 @@ func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	suite.Require().Len(suite.validator.Warnings(), 0)
 	suite.Require().Len(suite.validator.Errors(), 2)
 
-	suite.Assert().EqualError(suite.validator.Errors()[0], "Subject [invalid] for rule #1 domain: [public.example.com] is invalid, must start with 'user:' or 'group:'")
+	suite.Assert().EqualError(suite.validator.Errors()[0], "Subject [invalid] for rule #1 domain: [public.example.com] is invalid, must start with one of 'user:', 'group:', 'email:', 'oidc:', 'network:', optionally prefixed with '!' to negate it")
 	suite.Assert().EqualError(suite.validator.Errors()[1], fmt.Sprintf(errAccessControlInvalidPolicyWithSubjects, 1, domains, subjects))
 }
 
+func (suite *AccessControl) TestShouldValidateExtendedSubjects() {
+	suite.configuration.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "two_factor",
+			Subjects: [][]string{
+				{"group:employees", "!group:contractors"},
+				{"email:*@example.com"},
+				{"oidc:grafana"},
+				{"user:john", "network:internal"},
+				{"!network:10.0.0.0/8"},
+			},
+		},
+	}
+
+	ValidateRules(suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidExtendedSubjects() {
+	suite.configuration.Rules = []schema.ACLRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "two_factor",
+			Subjects: [][]string{
+				{"!"},
+				{"!group:"},
+				{"email:example.com"},
+				{"network:external"},
+			},
+		},
+	}
+
+	ValidateRules(suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "Subject [!] for rule #1 domain: [public.example.com] is invalid, must start with one of 'user:', 'group:', 'email:', 'oidc:', 'network:', optionally prefixed with '!' to negate it")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "Subject [!group:] for rule #1 domain: [public.example.com] is invalid, must start with one of 'user:', 'group:', 'email:', 'oidc:', 'network:', optionally prefixed with '!' to negate it")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "Subject [email:example.com] for rule #1 domain: [public.example.com] is invalid, the email pattern 'example.com' must contain an '@'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "Subject [network:external] for rule #1 domain: [public.example.com] is invalid, 'external' is not a valid network or network group")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAuthenticationAge() {
+	suite.configuration.Rules = []schema.ACLRule{
+		{
+			Domains:              []string{"public.example.com"},
+			Policy:               "two_factor",
+			MaxAuthenticationAge: "10x",
+		},
+		{
+			Domains:              []string{"secure.example.com"},
+			Policy:               "one_factor",
+			MaxAuthenticationAge: "10m",
+		},
+		{
+			Domains:              []string{"admin.example.com"},
+			Policy:               "two_factor",
+			MaxAuthenticationAge: "10m",
+		},
+	}
+
+	ValidateRules(suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "Max authentication age 10x for rule #1 domain: [public.example.com] is invalid, could not convert the input string of 10x into a duration")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "Max authentication age for rule #2 domain: [secure.example.com] is invalid, it can only be configured with the 'two_factor' policy")
+}
+
 func TestAccessControl(t *testing.T) {
 	suite.Run(t, new(AccessControl))
 }
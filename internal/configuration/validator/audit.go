@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateAudit validates and updates the audit log configuration.
+func ValidateAudit(configuration *schema.AuditConfiguration, validator *schema.StructValidator) {
+	if configuration == nil {
+		return
+	}
+
+	switch count := countConfiguredAuditSinks(configuration); {
+	case count == 0:
+		validator.Push(fmt.Errorf(errStrAuditNoSink))
+
+		return
+	case count > 1:
+		validator.Push(fmt.Errorf(errStrAuditMultipleSinks))
+
+		return
+	}
+
+	switch {
+	case configuration.File != nil:
+		if configuration.File.Path == "" {
+			validator.Push(fmt.Errorf(errStrAuditFilePath))
+		}
+	case configuration.Syslog != nil:
+		validateSyslog("audit: syslog", configuration.Syslog, schema.DefaultAuditSyslogFacility, validator)
+	default:
+		validateAuditHTTP(configuration.HTTP, validator)
+	}
+}
+
+func countConfiguredAuditSinks(configuration *schema.AuditConfiguration) (count int) {
+	if configuration.File != nil {
+		count++
+	}
+
+	if configuration.Syslog != nil {
+		count++
+	}
+
+	if configuration.HTTP != nil {
+		count++
+	}
+
+	return count
+}
+
+func validateAuditHTTP(configuration *schema.AuditHTTPConfiguration, validator *schema.StructValidator) {
+	if configuration.URL == "" {
+		validator.Push(fmt.Errorf(errStrAuditHTTPURL))
+	} else if u, err := url.Parse(configuration.URL); err != nil || (u.Scheme != schemeHTTP && u.Scheme != schemeHTTPS) || u.Host == "" {
+		validator.Push(fmt.Errorf(errFmtAuditHTTPInvalidURL, configuration.URL))
+	}
+
+	if configuration.Timeout == 0 {
+		configuration.Timeout = schema.DefaultAuditHTTPConfiguration.Timeout
+	}
+
+	for i, header := range configuration.Headers {
+		if header.Name == "" {
+			validator.Push(fmt.Errorf(errFmtAuditHTTPHeaderNoName, i+1))
+		}
+	}
+
+	if configuration.TLS == nil {
+		configuration.TLS = schema.DefaultAuditHTTPConfiguration.TLS
+	}
+}
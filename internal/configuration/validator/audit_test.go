@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldNotValidateDisabledAudit(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(nil, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+}
+
+func TestShouldRaiseErrorWhenNoAuditSinkIsConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrAuditNoSink)
+}
+
+func TestShouldRaiseErrorWhenMultipleAuditSinksAreConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{
+		File:   &schema.AuditFileConfiguration{Path: "/var/log/authelia/audit.log"},
+		Syslog: &schema.SyslogConfiguration{},
+	}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrAuditMultipleSinks)
+}
+
+func TestShouldRaiseErrorWhenAuditFilePathIsNotConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{File: &schema.AuditFileConfiguration{}}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrAuditFilePath)
+}
+
+func TestShouldSetDefaultAuditSyslogValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuditConfiguration{Syslog: &schema.SyslogConfiguration{}}
+
+	ValidateAudit(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.SyslogNetworkUDP, config.Syslog.Network)
+	assert.Equal(t, "localhost:514", config.Syslog.Address)
+	assert.Equal(t, "authelia", config.Syslog.AppName)
+	assert.Equal(t, "authpriv", config.Syslog.Facility)
+}
+
+func TestShouldSetDefaultAuditSyslogUnixAddress(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuditConfiguration{Syslog: &schema.SyslogConfiguration{Network: schema.SyslogNetworkUnix, Facility: "local3"}}
+
+	ValidateAudit(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "/dev/log", config.Syslog.Address)
+	assert.Equal(t, "local3", config.Syslog.Facility)
+}
+
+func TestShouldRaiseErrorOnInvalidAuditSyslogValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{Syslog: &schema.SyslogConfiguration{Network: "udp", Facility: "local9"}}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.Contains(t, validator.Errors()[0].Error(), "audit: syslog: 'facility' configuration option must be one of 'auth', 'authpriv', 'cron', ")
+	assert.Contains(t, validator.Errors()[0].Error(), "but it is configured as 'local9'")
+
+	validator = schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{Syslog: &schema.SyslogConfiguration{Network: "quic"}}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "audit: syslog: 'network' configuration option must be one of 'udp', 'tcp', 'unix' but it is configured as 'quic'")
+}
+
+func TestShouldSetDefaultAuditHTTPValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.AuditConfiguration{HTTP: &schema.AuditHTTPConfiguration{URL: "https://siem.example.com/events"}}
+
+	ValidateAudit(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, time.Second*5, config.HTTP.Timeout)
+	assert.Equal(t, "TLS1.2", config.HTTP.TLS.MinimumVersion)
+}
+
+func TestShouldRaiseErrorOnInvalidAuditHTTPValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{HTTP: &schema.AuditHTTPConfiguration{
+		URL:     "siem.example.com/events",
+		Headers: []schema.WebhookHeaderConfiguration{{Value: "abc"}},
+	}}, validator)
+
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], fmt.Sprintf(errFmtAuditHTTPInvalidURL, "siem.example.com/events"))
+	assert.EqualError(t, validator.Errors()[1], fmt.Sprintf(errFmtAuditHTTPHeaderNoName, 1))
+
+	validator = schema.NewStructValidator()
+
+	ValidateAudit(&schema.AuditConfiguration{HTTP: &schema.AuditHTTPConfiguration{}}, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrAuditHTTPURL)
+}
@@ -60,6 +60,8 @@ func ValidateConfiguration(configuration *schema.Configuration, validator *schem
 		ValidateNotifier(configuration.Notifier, validator)
 	}
 
+	ValidateStorageRetention(configuration, validator)
+
 	ValidateIdentityProviders(&configuration.IdentityProviders, validator)
 
 	if configuration.NTP == nil {
@@ -67,4 +69,10 @@ func ValidateConfiguration(configuration *schema.Configuration, validator *schem
 	}
 
 	ValidateNTP(configuration.NTP, validator)
+
+	ValidateGeoIP(configuration, validator)
+
+	ValidateTracing(&configuration.Tracing, validator)
+
+	ValidateAudit(configuration.Audit, validator)
 }
@@ -15,6 +15,21 @@ const (
 	policyDeny      = "deny"
 )
 
+// Access control subject constants.
+const (
+	subjectPrefixNegation = "!"
+	subjectPrefixEmail    = "email:"
+	subjectPrefixNetwork  = "network:"
+)
+
+var validACLSubjectPrefixes = []string{"user:", "group:", subjectPrefixEmail, "oidc:", subjectPrefixNetwork}
+
+// Access control GeoIP network constants.
+const (
+	networkPrefixCountry = "country:"
+	networkPrefixASN     = "asn:"
+)
+
 // Hashing constants.
 const (
 	hashArgon2id = "argon2id"
@@ -47,11 +62,25 @@ const (
 // Notifier Error constants.
 const (
 	errFmtNotifierMultipleConfigured = "notifier: you can't configure more than one notifier, please ensure " +
-		"only 'smtp' or 'filesystem' is configured"
-	errFmtNotifierNotConfigured = "notifier: you must ensure either the 'smtp' or 'filesystem' notifier " +
-		"is configured"
+		"only one of 'smtp', 'filesystem', 'webhook' or 'sendmail' is configured"
+	errFmtNotifierNotConfigured = "notifier: you must ensure one of the 'smtp', 'filesystem', 'webhook' or " +
+		"'sendmail' notifiers is configured"
+	errFmtNotifierTemplateLocaleInvalid = "notifier: the 'template_locale' must be a valid locale but " +
+		"it's configured as '%s': %w"
+	errFmtNotifierSecurityEventsLookbackInvalid = "notifier: security_events: the 'new_login_lookback' " +
+		"could not be parsed: %w"
 	errFmtNotifierFileSystemFileNameNotConfigured = "filesystem notifier: the 'filename' must be configured"
 	errFmtNotifierSMTPNotConfigured               = "smtp notifier: the '%s' must be configured"
+	errFmtNotifierWebhookNotConfigured            = "webhook notifier: the '%s' must be configured"
+	errFmtNotifierWebhookInvalidURL               = "webhook notifier: the 'url' must be an absolute http or " +
+		"https URL but it's configured as '%s'"
+	errFmtNotifierWebhookInvalidMethod = "webhook notifier: the 'method' must be one of 'POST', 'PUT' or 'PATCH' " +
+		"but it's configured as '%s'"
+	errFmtNotifierWebhookHeaderNoName   = "webhook notifier: header #%d must have a 'name' configured"
+	errFmtNotifierWebhookFieldNoName    = "webhook notifier: field #%d must have a 'name' configured"
+	errFmtNotifierWebhookFieldDuplicate = "webhook notifier: field '%s' is configured more than once"
+	errFmtNotifierWebhookFieldTemplate  = "webhook notifier: field '%s' has an invalid template: %w"
+	errFmtNotifierSendmailNotConfigured = "sendmail notifier: the '%s' must be configured"
 )
 
 // TOTP Error constants.
@@ -61,14 +90,63 @@ const (
 	errFmtTOTPInvalidDigits    = "totp: digits '%d' is invalid: must be 6 or 8"
 )
 
+// Server Error constants.
+const (
+	errStrServerAdminNoTokens          = "server: admin: 'tokens' configuration option must have at least one token"
+	errStrServerAdminPortInUse         = "server: admin: 'port' configuration option must not be the same as the server port"
+	errStrServerAdminTLSNoCertificate  = "server: admin: tls: 'certificate' configuration option must be provided with the 'key' configuration option"
+	errStrServerAdminTLSNoKey          = "server: admin: tls: 'key' configuration option must be provided with the 'certificate' configuration option"
+	errFmtServerAdminTokenNoName       = "server: admin: tokens: token %d: 'name' configuration option must be provided"
+	errFmtServerAdminTokenDuplicate    = "server: admin: tokens: token '%s': '%s' configuration option must be unique"
+	errFmtServerAdminTokenTooShort     = "server: admin: tokens: token '%s': 'token' configuration option must be %d characters or longer"
+	errFmtServerAdminTokenNoScopes     = "server: admin: tokens: token '%s': 'scopes' configuration option must have at least one scope"
+	errFmtServerAdminTokenInvalidScope = "server: admin: tokens: token '%s': 'scopes' configuration option has an invalid scope '%s', must be one of: '%s'"
+	errStrServerMetricsServerListener  = "server: metrics: 'host' and 'port' configuration options must not be provided with the 'server_listener' configuration option"
+	errFmtServerMetricsPortInUse       = "server: metrics: 'port' configuration option must not be the same as the %s port"
+)
+
+const serverAdminTokenMinLength = 32
+
+// Tracing Error constants.
+const (
+	errFmtTracingSamplingRatio = "tracing: 'sampling_ratio' configuration option must be greater than 0 and less than or equal to 1 but it is configured as '%v'"
+	errFmtTracingExporter      = "tracing: 'exporter' configuration option must be one of '%s' but it is configured as '%s'"
+	errFmtTracingOTLPProtocol  = "tracing: otlp: 'protocol' configuration option must be one of '%s' but it is configured as '%s'"
+)
+
+// Audit Error constants.
+const (
+	errStrAuditNoSink           = "audit: one of the 'file', 'syslog' or 'http' sinks must be configured"
+	errStrAuditMultipleSinks    = "audit: you can't configure more than one sink, please ensure only one of the 'file', 'syslog' or 'http' sinks is configured"
+	errStrAuditFilePath         = "audit: file: the 'path' must be configured"
+	errStrAuditHTTPURL          = "audit: http: the 'url' must be configured"
+	errFmtAuditHTTPInvalidURL   = "audit: http: the 'url' must be an absolute http or https URL but it's configured as '%s'"
+	errFmtAuditHTTPHeaderNoName = "audit: http: header #%d must have a 'name' configured"
+)
+
+// Syslog Error constants.
+const (
+	errFmtSyslogNetwork  = "%s: 'network' configuration option must be one of '%s' but it is configured as '%s'"
+	errFmtSyslogFacility = "%s: 'facility' configuration option must be one of '%s' but it is configured as '%s'"
+)
+
 // Storage Error constants.
 const (
-	errStrStorage                            = "storage: configuration for a 'local', 'mysql' or 'postgres' database must be provided"
-	errStrStorageEncryptionKeyMustBeProvided = "storage: 'encryption_key' configuration option must be provided"
-	errStrStorageEncryptionKeyTooShort       = "storage: 'encryption_key' configuration option must be 20 characters or longer"
-	errFmtStorageUserPassMustBeProvided      = "storage: %s: 'username' and 'password' configuration options must be provided" //nolint: gosec
-	errFmtStorageOptionMustBeProvided        = "storage: %s: '%s' configuration option must be provided"
-	errFmtStoragePostgreSQLInvalidSSLMode    = "storage: postgres: ssl: 'mode' configuration option '%s' is invalid: must be one of '%s'"
+	errStrStorage                             = "storage: configuration for a 'local', 'mysql' or 'postgres' database must be provided"
+	errStrStorageEncryptionKeyMustBeProvided  = "storage: 'encryption_key' configuration option must be provided"
+	errStrStorageEncryptionKeyTooShort        = "storage: 'encryption_key' configuration option must be 20 characters or longer"
+	errFmtStorageDecryptionKeyTooShort        = "storage: 'decryption_keys' configuration option key %d must be 20 characters or longer"
+	errFmtStorageDecryptionKeyIsEncryptionKey = "storage: 'decryption_keys' configuration option key %d must not be the same as the 'encryption_key'"
+	errFmtStorageUserPassMustBeProvided       = "storage: %s: 'username' and 'password' configuration options must be provided" //nolint: gosec
+	errFmtStorageOptionMustBeProvided         = "storage: %s: '%s' configuration option must be provided"
+	errFmtStorageOptionMustNotBeNegative      = "storage: %s: '%s' configuration option must be 0 or greater but it is %v"
+	errFmtStorageReplicaHostMustBeProvided    = "storage: %s: replicas: replica %d: 'host' configuration option must be provided"
+	errFmtStoragePostgreSQLInvalidSSLMode     = "storage: postgres: ssl: 'mode' configuration option '%s' is invalid: must be one of '%s'"
+	errFmtStorageRetentionParse               = "storage: retention: '%s' configuration option could not be parsed: %w"
+	errFmtStorageRetentionIntervalPositive    = "storage: retention: 'interval' configuration option must be greater than 0"
+	errFmtStorageRetentionBatchSize           = "storage: retention: 'batch_size' configuration option must be greater than 0 but it is %d"
+	errFmtStorageRetentionTooShort            = "storage: retention: '%s' configuration option must be 0 or at least %s " +
+		"as the rows are required by the '%s' configuration option but it is %s"
 )
 
 var storagePostgreSQLValidSSLModes = []string{testModeDisabled, "require", "verify-ca", "verify-full"}
@@ -120,15 +198,35 @@ const (
 
 	errFmtLoggingLevelInvalid = "the log level '%s' is invalid, must be one of: %s"
 
+	errStrLoggingRotationFilePath  = "log: rotation: the 'file_path' must be configured to rotate the log file"
+	errStrLoggingRotationNoTrigger = "log: rotation: either the 'max_size' or the 'interval' must be configured"
+	errFmtLoggingRotationNegative  = "log: rotation: '%s' configuration option must not be negative but it is configured as '%v'"
+
 	errFmtSessionSecretRedisProvider      = "the session secret must be set when using the %s session provider"
 	errFmtSessionRedisPortRange           = "the port must be between 1 and 65535 for the %s session provider"
 	errFmtSessionRedisHostRequired        = "the host must be provided when using the %s session provider"
 	errFmtSessionRedisHostOrNodesRequired = "either the host or a node must be provided when using the %s session provider"
+	errFmtSessionRedisClusterAndSentinel  = "the redis cluster and high_availability options can't both be configured"
+	errFmtSessionStorageAndRedis          = "the redis and storage session providers can't both be configured"
+	errFmtSessionStorageGCInterval        = "the storage session provider garbage_collection_interval could not be parsed: %w"
+	errFmtSessionRedisClusterDatabase     = "the database_index must be 0 when using the redis cluster session provider as it's configured as %d"
+
+	errFmtSessionCookieDomainRequired    = "session: cookies: option 'domain' is required for cookie #%d"
+	errFmtSessionCookieDomainWildcard    = "session: cookies: option 'domain' must be the root domain you're protecting instead of a wildcard domain but it's configured as '%s'"
+	errFmtSessionCookieDomainDuplicate   = "session: cookies: option 'domain' must be unique but '%s' is configured more than once"
+	errFmtSessionCookieSameSite          = "session: cookies: option 'same_site' for domain '%s' must be one of 'none', 'lax', or 'strict' but it's configured as '%s'"
+	errFmtSessionCookieExpiration        = "session: cookies: option 'expiration' for domain '%s' could not be parsed: %w"
+	errFmtSessionCookieAutheliaURL       = "session: cookies: option 'authelia_url' for domain '%s' could not be parsed: %w"
+	errFmtSessionCookieAutheliaURLScheme = "session: cookies: option 'authelia_url' for domain '%s' must have the 'https' scheme but it's configured as '%s'"
+	errFmtSessionCookieAutheliaURLDomain = "session: cookies: option 'authelia_url' for domain '%s' must be on the domain of the cookie but it's configured as '%s'"
 
 	errFileHashing  = "config key incorrect: authentication_backend.file.hashing should be authentication_backend.file.password"
 	errFilePHashing = "config key incorrect: authentication_backend.file.password_hashing should be authentication_backend.file.password"
 	errFilePOptions = "config key incorrect: authentication_backend.file.password_options should be authentication_backend.file.password"
 
+	errFmtAccessControlInvalidSubject = "Subject %s for rule #%d domain: %s is invalid, must start with one of '%s', " +
+		"optionally prefixed with '!' to negate it"
+
 	errAccessControlInvalidPolicyWithSubjects = "policy [bypass] for rule #%d domain %s with subjects %s is invalid. It is " +
 		"not supported to configure both policy bypass and subjects. For more information see: " +
 		"https://www.authelia.com/docs/configuration/access-control.html#combining-subjects-and-the-bypass-policy"
@@ -144,6 +242,8 @@ var validOIDCUserinfoAlgorithms = []string{"none", "RS256"}
 
 var reKeyReplacer = regexp.MustCompile(`\[\d+]`)
 
+var reISOCountryCode = regexp.MustCompile(`^[A-Za-z]{2}$`)
+
 // ValidKeys is a list of valid keys that are not secret names. For the sake of consistency please place any secret in
 // the secret names map and reuse it in relevant sections.
 var ValidKeys = []string{
@@ -158,6 +258,15 @@ var ValidKeys = []string{
 	"log.format",
 	"log.file_path",
 	"log.keep_stdout",
+	"log.rotation.max_size",
+	"log.rotation.interval",
+	"log.rotation.max_backups",
+	"log.rotation.compress",
+	"log.syslog.network",
+	"log.syslog.address",
+	"log.syslog.app_name",
+	"log.syslog.facility",
+	"log.journald",
 
 	// Server Keys.
 	"server.host",
@@ -169,8 +278,23 @@ var ValidKeys = []string{
 	"server.enable_pprof",
 	"server.enable_expvars",
 	"server.disable_healthcheck",
+	"server.trusted_proxies",
+	"server.enable_proxy_protocol",
+	"server.enable_forwarded_header",
 	"server.tls.key",
 	"server.tls.certificate",
+	"server.admin.host",
+	"server.admin.port",
+	"server.admin.tls.key",
+	"server.admin.tls.certificate",
+	"server.admin.tokens",
+	"server.admin.tokens[].name",
+	"server.admin.tokens[].token",
+	"server.admin.tokens[].scopes",
+	"server.metrics.enabled",
+	"server.metrics.host",
+	"server.metrics.port",
+	"server.metrics.server_listener",
 
 	// TOTP Keys.
 	"totp.issuer",
@@ -195,6 +319,8 @@ var ValidKeys = []string{
 	"access_control.rules[].subject",
 	"access_control.rules[].policy",
 	"access_control.rules[].resources",
+	"access_control.rules[].max_authentication_age",
+	"access_control.rules_dir",
 
 	// Session Keys.
 	"session.name",
@@ -204,6 +330,7 @@ var ValidKeys = []string{
 	"session.expiration",
 	"session.inactivity",
 	"session.remember_me_duration",
+	"session.cookies",
 
 	// Redis Session Keys.
 	"session.redis.host",
@@ -221,6 +348,12 @@ var ValidKeys = []string{
 	"session.redis.high_availability.nodes",
 	"session.redis.high_availability.route_by_latency",
 	"session.redis.high_availability.route_randomly",
+	"session.redis.cluster.nodes",
+	"session.redis.cluster.route_by_latency",
+	"session.redis.cluster.route_randomly",
+
+	// Storage Session Keys.
+	"session.storage.garbage_collection_interval",
 	"session.redis.timeouts.dial",
 	"session.redis.timeouts.idle",
 	"session.redis.timeouts.pool",
@@ -228,6 +361,14 @@ var ValidKeys = []string{
 	"session.redis.timeouts.write",
 
 	"storage.encryption_key",
+	"storage.decryption_keys",
+
+	// Storage Retention Keys.
+	"storage.retention.authentication_logs",
+	"storage.retention.identity_verification",
+	"storage.retention.regulation_bans",
+	"storage.retention.interval",
+	"storage.retention.batch_size",
 
 	// Local Storage Keys.
 	"storage.local.path",
@@ -239,6 +380,14 @@ var ValidKeys = []string{
 	"storage.mysql.username",
 	"storage.mysql.password",
 	"storage.mysql.timeout",
+	"storage.mysql.query_timeout",
+	"storage.mysql.pool.max_open",
+	"storage.mysql.pool.max_idle",
+	"storage.mysql.pool.max_lifetime",
+	"storage.mysql.pool.max_idle_time",
+	"storage.mysql.replicas",
+	"storage.mysql.replicas[].host",
+	"storage.mysql.replicas[].port",
 
 	// PostgreSQL Storage Keys.
 	"storage.postgres.host",
@@ -247,6 +396,14 @@ var ValidKeys = []string{
 	"storage.postgres.username",
 	"storage.postgres.password",
 	"storage.postgres.timeout",
+	"storage.postgres.query_timeout",
+	"storage.postgres.pool.max_open",
+	"storage.postgres.pool.max_idle",
+	"storage.postgres.pool.max_lifetime",
+	"storage.postgres.pool.max_idle_time",
+	"storage.postgres.replicas",
+	"storage.postgres.replicas[].host",
+	"storage.postgres.replicas[].port",
 	"storage.postgres.schema",
 	"storage.postgres.ssl.mode",
 	"storage.postgres.ssl.root_certificate",
@@ -258,6 +415,17 @@ var ValidKeys = []string{
 	// FileSystem Notifier Keys.
 	"notifier.filesystem.filename",
 	"notifier.disable_startup_check",
+	"notifier.template_path",
+	"notifier.template_locale",
+
+	// Security Events Notifier Keys.
+	"notifier.security_events.disable",
+	"notifier.security_events.disable_password_changed",
+	"notifier.security_events.disable_device_registered",
+	"notifier.security_events.disable_device_removed",
+	"notifier.security_events.disable_new_login",
+	"notifier.security_events.disable_account_banned",
+	"notifier.security_events.new_login_lookback",
 
 	// SMTP Notifier Keys.
 	"notifier.smtp.host",
@@ -275,10 +443,57 @@ var ValidKeys = []string{
 	"notifier.smtp.tls.skip_verify",
 	"notifier.smtp.tls.server_name",
 
+	// Webhook Notifier Keys.
+	"notifier.webhook.url",
+	"notifier.webhook.method",
+	"notifier.webhook.timeout",
+	"notifier.webhook.headers",
+	"notifier.webhook.headers[].name",
+	"notifier.webhook.headers[].value",
+	"notifier.webhook.fields",
+	"notifier.webhook.fields[].name",
+	"notifier.webhook.fields[].template",
+	"notifier.webhook.tls.minimum_version",
+	"notifier.webhook.tls.skip_verify",
+	"notifier.webhook.tls.server_name",
+
+	// Sendmail Notifier Keys.
+	"notifier.sendmail.path",
+	"notifier.sendmail.args",
+	"notifier.sendmail.timeout",
+	"notifier.sendmail.sender",
+	"notifier.sendmail.subject",
+	"notifier.sendmail.disable_html_emails",
+
 	// Regulation Keys.
 	"regulation.max_retries",
 	"regulation.find_time",
 	"regulation.ban_time",
+	"regulation.trusted_countries",
+	"regulation.foreign_max_retries",
+	"regulation.ip.max_retries",
+	"regulation.ip.find_time",
+	"regulation.ip.ban_time",
+	"regulation.subnet.max_retries",
+	"regulation.subnet.find_time",
+	"regulation.subnet.ban_time",
+	"regulation.subnet.ipv4_prefix_length",
+	"regulation.subnet.ipv6_prefix_length",
+	"regulation.global.max_retries",
+	"regulation.global.find_time",
+	"regulation.global.ban_time",
+	"regulation.totp.max_retries",
+	"regulation.totp.find_time",
+	"regulation.totp.ban_time",
+	"regulation.identity_verification.user.max_retries",
+	"regulation.identity_verification.user.find_time",
+	"regulation.identity_verification.user.ban_time",
+	"regulation.identity_verification.ip.max_retries",
+	"regulation.identity_verification.ip.find_time",
+	"regulation.identity_verification.ip.ban_time",
+	"regulation.backoff.multiplier",
+	"regulation.backoff.max_ban_time",
+	"regulation.backoff.reset_time",
 
 	// Authentication Backend Keys.
 	"authentication_backend.disable_reset_password",
@@ -297,6 +512,7 @@ var ValidKeys = []string{
 	"authentication_backend.ldap.group_name_attribute",
 	"authentication_backend.ldap.mail_attribute",
 	"authentication_backend.ldap.display_name_attribute",
+	"authentication_backend.ldap.locale_attribute",
 	"authentication_backend.ldap.user",
 	"authentication_backend.ldap.password",
 	"authentication_backend.ldap.start_tls",
@@ -338,6 +554,35 @@ var ValidKeys = []string{
 	"ntp.max_desync",
 	"ntp.disable_startup_check",
 	"ntp.disable_failure",
+
+	// GeoIP Keys.
+	"geoip.country_database",
+	"geoip.asn_database",
+
+	// Tracing Keys.
+	"tracing.enabled",
+	"tracing.service_name",
+	"tracing.sampling_ratio",
+	"tracing.exporter",
+	"tracing.otlp.endpoint",
+	"tracing.otlp.protocol",
+	"tracing.otlp.insecure",
+	"tracing.file.path",
+
+	// Audit Keys.
+	"audit.file.path",
+	"audit.syslog.network",
+	"audit.syslog.address",
+	"audit.syslog.app_name",
+	"audit.syslog.facility",
+	"audit.http.url",
+	"audit.http.timeout",
+	"audit.http.headers",
+	"audit.http.headers[].name",
+	"audit.http.headers[].value",
+	"audit.http.tls.minimum_version",
+	"audit.http.tls.skip_verify",
+	"audit.http.tls.server_name",
 }
 
 var replacedKeys = map[string]string{
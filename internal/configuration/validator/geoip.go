@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateGeoIP validates the GeoIP configuration and that the options which depend on it are only used when the
+// relevant databases are configured.
+func ValidateGeoIP(configuration *schema.Configuration, validator *schema.StructValidator) {
+	var countryDatabase, asnDatabase bool
+
+	if configuration.GeoIP != nil {
+		if configuration.GeoIP.CountryDatabase == "" && configuration.GeoIP.ASNDatabase == "" {
+			validator.Push(fmt.Errorf("geoip: at least one of the 'country_database' or 'asn_database' options must be configured"))
+		}
+
+		countryDatabase = validateGeoIPDatabase("country_database", configuration.GeoIP.CountryDatabase, validator)
+		asnDatabase = validateGeoIPDatabase("asn_database", configuration.GeoIP.ASNDatabase, validator)
+	}
+
+	for i, rule := range configuration.AccessControl.Rules {
+		for _, network := range rule.Networks {
+			switch {
+			case strings.HasPrefix(network, networkPrefixCountry) && !countryDatabase:
+				validator.Push(fmt.Errorf("Network %s for rule #%d domain: %s requires the 'geoip.country_database' option to be configured", network, i+1, rule.Domains))
+			case strings.HasPrefix(network, networkPrefixASN) && !asnDatabase:
+				validator.Push(fmt.Errorf("Network %s for rule #%d domain: %s requires the 'geoip.asn_database' option to be configured", network, i+1, rule.Domains))
+			}
+		}
+	}
+
+	if configuration.Regulation != nil && len(configuration.Regulation.TrustedCountries) != 0 && !countryDatabase {
+		validator.Push(fmt.Errorf("regulation: option 'trusted_countries' requires the 'geoip.country_database' option to be configured"))
+	}
+}
+
+func validateGeoIPDatabase(name, path string, validator *schema.StructValidator) (configured bool) {
+	if path == "" {
+		return false
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		validator.Push(fmt.Errorf("geoip: error occurred checking the '%s' file %s: %w", name, path, err))
+	}
+
+	return true
+}
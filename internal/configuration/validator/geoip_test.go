@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldNotRaiseErrorsWithoutGeoIP(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+
+	ValidateGeoIP(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+}
+
+func TestShouldValidateGeoIPDatabases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "GeoLite2-Country.mmdb")
+
+	require.NoError(t, os.WriteFile(path, []byte{}, 0600))
+
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{
+		GeoIP: &schema.GeoIPConfiguration{
+			CountryDatabase: path,
+		},
+		AccessControl: schema.AccessControlConfiguration{
+			Rules: []schema.ACLRule{
+				{
+					Domains:  []string{"admin.example.com"},
+					Policy:   "two_factor",
+					Networks: []string{"country:NZ"},
+				},
+			},
+		},
+		Regulation: &schema.RegulationConfiguration{
+			TrustedCountries: []string{"NZ"},
+		},
+	}
+
+	ValidateGeoIP(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+}
+
+func TestShouldRaiseErrorsWhenGeoIPDatabasesMissing(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControlConfiguration{
+			Rules: []schema.ACLRule{
+				{
+					Domains:  []string{"admin.example.com"},
+					Policy:   "two_factor",
+					Networks: []string{"10.0.0.0/8", "country:NZ", "asn:13335"},
+				},
+			},
+		},
+		Regulation: &schema.RegulationConfiguration{
+			TrustedCountries: []string{"NZ"},
+		},
+	}
+
+	ValidateGeoIP(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 3)
+
+	assert.EqualError(t, validator.Errors()[0], "Network country:NZ for rule #1 domain: [admin.example.com] requires the 'geoip.country_database' option to be configured")
+	assert.EqualError(t, validator.Errors()[1], "Network asn:13335 for rule #1 domain: [admin.example.com] requires the 'geoip.asn_database' option to be configured")
+	assert.EqualError(t, validator.Errors()[2], "regulation: option 'trusted_countries' requires the 'geoip.country_database' option to be configured")
+}
+
+func TestShouldRaiseErrorsOnInvalidGeoIPConfiguration(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{
+		GeoIP: &schema.GeoIPConfiguration{},
+	}
+
+	ValidateGeoIP(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "geoip: at least one of the 'country_database' or 'asn_database' options must be configured")
+
+	validator = schema.NewStructValidator()
+	config.GeoIP.ASNDatabase = "/path/does/not/exist.mmdb"
+
+	ValidateGeoIP(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "geoip: error occurred checking the 'asn_database' file /path/does/not/exist.mmdb: stat /path/does/not/exist.mmdb: no such file or directory")
+}
@@ -21,4 +21,33 @@ func ValidateLogging(configuration *schema.Configuration, validator *schema.Stru
 	if !utils.IsStringInSlice(configuration.Log.Level, validLoggingLevels) {
 		validator.Push(fmt.Errorf(errFmtLoggingLevelInvalid, configuration.Log.Level, strings.Join(validLoggingLevels, ", ")))
 	}
+
+	if configuration.Log.Rotation != nil {
+		validateLoggingRotation(configuration.Log, validator)
+	}
+
+	if configuration.Log.Syslog != nil {
+		validateSyslog("log: syslog", configuration.Log.Syslog, schema.DefaultLogSyslogFacility, validator)
+	}
+}
+
+func validateLoggingRotation(configuration schema.LogConfiguration, validator *schema.StructValidator) {
+	if configuration.FilePath == "" {
+		validator.Push(fmt.Errorf(errStrLoggingRotationFilePath))
+	}
+
+	rotation := configuration.Rotation
+
+	switch {
+	case rotation.MaxSize < 0:
+		validator.Push(fmt.Errorf(errFmtLoggingRotationNegative, "max_size", rotation.MaxSize))
+	case rotation.Interval < 0:
+		validator.Push(fmt.Errorf(errFmtLoggingRotationNegative, "interval", rotation.Interval))
+	case rotation.MaxSize == 0 && rotation.Interval == 0:
+		validator.Push(fmt.Errorf(errStrLoggingRotationNoTrigger))
+	}
+
+	if rotation.MaxBackups < 0 {
+		validator.Push(fmt.Errorf(errFmtLoggingRotationNegative, "max_backups", rotation.MaxBackups))
+	}
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -42,3 +43,117 @@ func TestShouldRaiseErrorOnInvalidLoggingLevel(t *testing.T) {
 
 	assert.EqualError(t, validator.Errors()[0], "the log level 'TRACE' is invalid, must be one of: trace, debug, info, warn, error")
 }
+
+func TestShouldValidateLoggingRotation(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			FilePath: "/var/log/authelia/authelia.log",
+			Rotation: &schema.LogRotationConfiguration{
+				MaxSize:    100,
+				Interval:   time.Hour * 24,
+				MaxBackups: 7,
+				Compress:   true,
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+}
+
+func TestShouldRaiseErrorWhenLoggingRotationHasNoFilePath(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			Rotation: &schema.LogRotationConfiguration{
+				MaxSize: 100,
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrLoggingRotationFilePath)
+}
+
+func TestShouldRaiseErrorWhenLoggingRotationHasNoTrigger(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			FilePath: "/var/log/authelia/authelia.log",
+			Rotation: &schema.LogRotationConfiguration{
+				MaxBackups: 7,
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], errStrLoggingRotationNoTrigger)
+}
+
+func TestShouldRaiseErrorWhenLoggingRotationOptionsAreNegative(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			FilePath: "/var/log/authelia/authelia.log",
+			Rotation: &schema.LogRotationConfiguration{
+				MaxSize:    -1,
+				MaxBackups: -2,
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "log: rotation: 'max_size' configuration option must not be negative but it is configured as '-1'")
+	assert.EqualError(t, validator.Errors()[1], "log: rotation: 'max_backups' configuration option must not be negative but it is configured as '-2'")
+}
+
+func TestShouldSetDefaultLoggingSyslogValues(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			Syslog: &schema.SyslogConfiguration{},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, schema.SyslogNetworkUDP, config.Log.Syslog.Network)
+	assert.Equal(t, "localhost:514", config.Log.Syslog.Address)
+	assert.Equal(t, "authelia", config.Log.Syslog.AppName)
+	assert.Equal(t, "daemon", config.Log.Syslog.Facility)
+}
+
+func TestShouldRaiseErrorOnInvalidLoggingSyslogFacility(t *testing.T) {
+	config := &schema.Configuration{
+		Log: schema.LogConfiguration{
+			Syslog: &schema.SyslogConfiguration{
+				Network:  schema.SyslogNetworkUnix,
+				Facility: "system",
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateLogging(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.Contains(t, validator.Errors()[0].Error(), "log: syslog: 'facility' configuration option must be one of 'auth', 'authpriv'")
+	assert.Equal(t, "/dev/log", config.Log.Syslog.Address)
+}
@@ -2,31 +2,73 @@ package validator
 
 import (
 	"fmt"
+	"net/http"
+	"net/url"
+	"text/template"
+
+	"golang.org/x/text/language"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // ValidateNotifier validates and update notifier configuration.
 func ValidateNotifier(configuration *schema.NotifierConfiguration, validator *schema.StructValidator) {
-	if configuration.SMTP == nil && configuration.FileSystem == nil {
+	switch count := countConfiguredNotifiers(configuration); {
+	case count == 0:
 		validator.Push(fmt.Errorf(errFmtNotifierNotConfigured))
 
 		return
-	} else if configuration.SMTP != nil && configuration.FileSystem != nil {
+	case count > 1:
 		validator.Push(fmt.Errorf(errFmtNotifierMultipleConfigured))
 
 		return
 	}
 
-	if configuration.FileSystem != nil {
+	if configuration.TemplateLocale == "" {
+		configuration.TemplateLocale = schema.DefaultNotifierConfiguration.TemplateLocale
+	} else if _, err := language.Parse(configuration.TemplateLocale); err != nil {
+		validator.Push(fmt.Errorf(errFmtNotifierTemplateLocaleInvalid, configuration.TemplateLocale, err))
+	}
+
+	if configuration.SecurityEvents.NewLoginLookback == "" {
+		configuration.SecurityEvents.NewLoginLookback = schema.DefaultNotifierConfiguration.SecurityEvents.NewLoginLookback
+	} else if _, err := utils.ParseDurationString(configuration.SecurityEvents.NewLoginLookback); err != nil {
+		validator.Push(fmt.Errorf(errFmtNotifierSecurityEventsLookbackInvalid, err))
+	}
+
+	switch {
+	case configuration.FileSystem != nil:
 		if configuration.FileSystem.Filename == "" {
 			validator.Push(fmt.Errorf(errFmtNotifierFileSystemFileNameNotConfigured))
 		}
+	case configuration.Webhook != nil:
+		validateWebhookNotifier(configuration.Webhook, validator)
+	case configuration.Sendmail != nil:
+		validateSendmailNotifier(configuration.Sendmail, validator)
+	default:
+		validateSMTPNotifier(configuration.SMTP, validator)
+	}
+}
 
-		return
+func countConfiguredNotifiers(configuration *schema.NotifierConfiguration) (count int) {
+	if configuration.SMTP != nil {
+		count++
+	}
+
+	if configuration.FileSystem != nil {
+		count++
 	}
 
-	validateSMTPNotifier(configuration.SMTP, validator)
+	if configuration.Webhook != nil {
+		count++
+	}
+
+	if configuration.Sendmail != nil {
+		count++
+	}
+
+	return count
 }
 
 func validateSMTPNotifier(configuration *schema.SMTPNotifierConfiguration, validator *schema.StructValidator) {
@@ -66,3 +108,82 @@ func validateSMTPNotifier(configuration *schema.SMTPNotifierConfiguration, valid
 		configuration.TLS.ServerName = configuration.Host
 	}
 }
+
+func validateWebhookNotifier(configuration *schema.WebhookNotifierConfiguration, validator *schema.StructValidator) {
+	if configuration.URL == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNotConfigured, "url"))
+	} else if u, err := url.Parse(configuration.URL); err != nil || (u.Scheme != schemeHTTP && u.Scheme != schemeHTTPS) || u.Host == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookInvalidURL, configuration.URL))
+	}
+
+	switch configuration.Method {
+	case "":
+		configuration.Method = schema.DefaultWebhookNotifierConfiguration.Method
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookInvalidMethod, configuration.Method))
+	}
+
+	if configuration.Timeout == 0 {
+		configuration.Timeout = schema.DefaultWebhookNotifierConfiguration.Timeout
+	}
+
+	for i, header := range configuration.Headers {
+		if header.Name == "" {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookHeaderNoName, i+1))
+		}
+	}
+
+	if len(configuration.Fields) == 0 {
+		configuration.Fields = schema.DefaultWebhookNotifierConfiguration.Fields
+	}
+
+	var names []string
+
+	for i, field := range configuration.Fields {
+		if field.Name == "" {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookFieldNoName, i+1))
+
+			continue
+		}
+
+		if utils.IsStringInSlice(field.Name, names) {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookFieldDuplicate, field.Name))
+
+			continue
+		}
+
+		names = append(names, field.Name)
+
+		if _, err := template.New(field.Name).Parse(field.Template); err != nil {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookFieldTemplate, field.Name, err))
+		}
+	}
+
+	if configuration.TLS == nil {
+		configuration.TLS = schema.DefaultWebhookNotifierConfiguration.TLS
+	}
+}
+
+func validateSendmailNotifier(configuration *schema.SendmailNotifierConfiguration, validator *schema.StructValidator) {
+	if configuration.Path == "" {
+		configuration.Path = schema.DefaultSendmailNotifierConfiguration.Path
+	}
+
+	if configuration.Args == nil {
+		configuration.Args = schema.DefaultSendmailNotifierConfiguration.Args
+	}
+
+	if configuration.Timeout == 0 {
+		configuration.Timeout = schema.DefaultSendmailNotifierConfiguration.Timeout
+	}
+
+	if configuration.Sender.Address == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierSendmailNotConfigured, "sender"))
+	}
+
+	if configuration.Subject == "" {
+		configuration.Subject = schema.DefaultSendmailNotifierConfiguration.Subject
+	}
+}
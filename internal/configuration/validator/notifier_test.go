@@ -26,6 +26,10 @@ func (suite *NotifierSuite) SetupTest() {
 		Port:     25,
 	}
 	suite.configuration.FileSystem = nil
+	suite.configuration.Webhook = nil
+	suite.configuration.Sendmail = nil
+	suite.configuration.TemplateLocale = ""
+	suite.configuration.SecurityEvents = schema.SecurityEventsNotifierConfiguration{}
 }
 
 /*
@@ -68,6 +72,46 @@ func (suite *NotifierSuite) TestShouldEnsureEitherSMTPOrFilesystemIsProvided() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], errFmtNotifierMultipleConfigured)
 }
 
+func (suite *NotifierSuite) TestShouldSetDefaultTemplateLocale() {
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+
+	suite.Assert().Equal("en", suite.configuration.TemplateLocale)
+}
+
+func (suite *NotifierSuite) TestShouldRaiseErrorOnInvalidTemplateLocale() {
+	suite.configuration.TemplateLocale = "not_a_locale!"
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "notifier: the 'template_locale' must be a valid locale but it's configured as 'not_a_locale!': language: tag is not well-formed")
+}
+
+func (suite *NotifierSuite) TestShouldSetDefaultSecurityEventsNewLoginLookback() {
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+
+	suite.Assert().Equal("90d", suite.configuration.SecurityEvents.NewLoginLookback)
+}
+
+func (suite *NotifierSuite) TestShouldRaiseErrorOnInvalidSecurityEventsNewLoginLookback() {
+	suite.configuration.SecurityEvents.NewLoginLookback = "abc"
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "notifier: security_events: the 'new_login_lookback' could not be parsed: could not convert the input string of abc into a duration")
+}
+
 /*
 	SMTP Tests.
 */
@@ -159,6 +203,99 @@ func (suite *NotifierSuite) TestFileShouldEnsureFilenameIsProvided() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], errFmtNotifierFileSystemFileNameNotConfigured)
 }
 
+/*
+	Webhook Tests.
+*/
+func (suite *NotifierSuite) TestWebhookShouldSetDefaults() {
+	suite.configuration.SMTP = nil
+	suite.configuration.Webhook = &schema.WebhookNotifierConfiguration{
+		URL: "https://api.example.com/notify",
+	}
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+
+	suite.Assert().Equal("POST", suite.configuration.Webhook.Method)
+	suite.Assert().Equal(schema.DefaultWebhookNotifierConfiguration.Timeout, suite.configuration.Webhook.Timeout)
+	suite.Assert().Equal(schema.DefaultWebhookNotifierConfiguration.Fields, suite.configuration.Webhook.Fields)
+	suite.Assert().Equal("TLS1.2", suite.configuration.Webhook.TLS.MinimumVersion)
+}
+
+func (suite *NotifierSuite) TestWebhookShouldRaiseErrorsOnInvalidConfiguration() {
+	suite.configuration.SMTP = nil
+	suite.configuration.Webhook = &schema.WebhookNotifierConfiguration{
+		URL:    "ftp://api.example.com/notify",
+		Method: "GET",
+		Headers: []schema.WebhookHeaderConfiguration{
+			{Value: "abc"},
+		},
+		Fields: []schema.WebhookFieldConfiguration{
+			{Template: "{{ .Recipient }}"},
+			{Name: "to", Template: "{{ .Recipient }}"},
+			{Name: "to", Template: "{{ .Body }}"},
+			{Name: "subject", Template: "{{ .Title "},
+		},
+	}
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 6)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], fmt.Sprintf(errFmtNotifierWebhookInvalidURL, "ftp://api.example.com/notify"))
+	suite.Assert().EqualError(suite.validator.Errors()[1], fmt.Sprintf(errFmtNotifierWebhookInvalidMethod, "GET"))
+	suite.Assert().EqualError(suite.validator.Errors()[2], fmt.Sprintf(errFmtNotifierWebhookHeaderNoName, 1))
+	suite.Assert().EqualError(suite.validator.Errors()[3], fmt.Sprintf(errFmtNotifierWebhookFieldNoName, 1))
+	suite.Assert().EqualError(suite.validator.Errors()[4], fmt.Sprintf(errFmtNotifierWebhookFieldDuplicate, "to"))
+	suite.Assert().Contains(suite.validator.Errors()[5].Error(), "webhook notifier: field 'subject' has an invalid template: ")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldEnsureURLIsProvided() {
+	suite.configuration.SMTP = nil
+	suite.configuration.Webhook = &schema.WebhookNotifierConfiguration{}
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], fmt.Sprintf(errFmtNotifierWebhookNotConfigured, "url"))
+}
+
+/*
+	Sendmail Tests.
+*/
+func (suite *NotifierSuite) TestSendmailShouldSetDefaults() {
+	suite.configuration.SMTP = nil
+	suite.configuration.Sendmail = &schema.SendmailNotifierConfiguration{
+		Sender: mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+	}
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Assert().False(suite.validator.HasErrors())
+
+	suite.Assert().Equal("/usr/sbin/sendmail", suite.configuration.Sendmail.Path)
+	suite.Assert().Equal([]string{"-t", "-i"}, suite.configuration.Sendmail.Args)
+	suite.Assert().Equal(schema.DefaultSendmailNotifierConfiguration.Timeout, suite.configuration.Sendmail.Timeout)
+	suite.Assert().Equal("[Authelia] {title}", suite.configuration.Sendmail.Subject)
+}
+
+func (suite *NotifierSuite) TestSendmailShouldEnsureSenderIsProvided() {
+	suite.configuration.SMTP = nil
+	suite.configuration.Sendmail = &schema.SendmailNotifierConfiguration{}
+
+	ValidateNotifier(&suite.configuration, suite.validator)
+
+	suite.Assert().False(suite.validator.HasWarnings())
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], fmt.Sprintf(errFmtNotifierSendmailNotConfigured, "sender"))
+}
+
 func TestNotifierSuite(t *testing.T) {
 	suite.Run(t, new(NotifierSuite))
 }
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -30,4 +31,130 @@ func ValidateRegulation(configuration *schema.RegulationConfiguration, validator
 	if findTime > banTime {
 		validator.Push(fmt.Errorf("find_time cannot be greater than ban_time"))
 	}
+
+	for _, country := range configuration.TrustedCountries {
+		if !reISOCountryCode.MatchString(country) {
+			validator.Push(fmt.Errorf("regulation: trusted country '%s' must be an ISO 3166-1 alpha-2 country code", country))
+		}
+	}
+
+	if configuration.ForeignMaxRetries < 0 {
+		validator.Push(fmt.Errorf("regulation: foreign_max_retries must be 0 or above"))
+	} else if configuration.ForeignMaxRetries != 0 && len(configuration.TrustedCountries) == 0 {
+		validator.Push(fmt.Errorf("regulation: foreign_max_retries requires the trusted_countries option to be configured"))
+	}
+
+	if configuration.IP != nil {
+		validateRegulationLimit("ip", configuration.IP, configuration, validator)
+	}
+
+	if configuration.Subnet != nil {
+		validateRegulationSubnet(configuration, validator)
+	}
+
+	if configuration.Global != nil {
+		validateRegulationLimit("global", configuration.Global, configuration, validator)
+	}
+
+	if configuration.TOTP != nil {
+		validateRegulationLimit("totp", configuration.TOTP, configuration, validator)
+	}
+
+	if configuration.IdentityVerification != nil {
+		if configuration.IdentityVerification.User != nil {
+			validateRegulationLimit("identity_verification: user", configuration.IdentityVerification.User, configuration, validator)
+		}
+
+		if configuration.IdentityVerification.IP != nil {
+			validateRegulationLimit("identity_verification: ip", configuration.IdentityVerification.IP, configuration, validator)
+		}
+	}
+
+	if configuration.Backoff != nil {
+		validateRegulationBackoff(configuration, banTime, validator)
+	}
+}
+
+func validateRegulationBackoff(configuration *schema.RegulationConfiguration, banTime time.Duration, validator *schema.StructValidator) {
+	backoff := configuration.Backoff
+
+	switch {
+	case backoff.Multiplier == 0:
+		backoff.Multiplier = schema.DefaultRegulationBackoffConfiguration.Multiplier
+	case backoff.Multiplier < 1:
+		validator.Push(fmt.Errorf("regulation: backoff: multiplier must be 1 or above but it is configured as %d", backoff.Multiplier))
+	}
+
+	if backoff.MaxBanTime == "" {
+		backoff.MaxBanTime = schema.DefaultRegulationBackoffConfiguration.MaxBanTime
+	}
+
+	if backoff.ResetTime == "" {
+		backoff.ResetTime = schema.DefaultRegulationBackoffConfiguration.ResetTime
+	}
+
+	maxBanTime, err := utils.ParseDurationString(backoff.MaxBanTime)
+	if err != nil {
+		validator.Push(fmt.Errorf("regulation: backoff: error occurred parsing max_ban_time string: %w", err))
+	} else if maxBanTime < banTime {
+		validator.Push(fmt.Errorf("regulation: backoff: max_ban_time cannot be less than ban_time"))
+	}
+
+	if _, err = utils.ParseDurationString(backoff.ResetTime); err != nil {
+		validator.Push(fmt.Errorf("regulation: backoff: error occurred parsing reset_time string: %w", err))
+	}
+}
+
+func validateRegulationSubnet(configuration *schema.RegulationConfiguration, validator *schema.StructValidator) {
+	subnet := configuration.Subnet
+
+	validateRegulationLimit("subnet", &subnet.RegulationLimitConfiguration, configuration, validator)
+
+	switch {
+	case subnet.IPv4PrefixLength == 0:
+		subnet.IPv4PrefixLength = schema.DefaultRegulationSubnetConfiguration.IPv4PrefixLength
+	case subnet.IPv4PrefixLength < 1 || subnet.IPv4PrefixLength > 32:
+		validator.Push(fmt.Errorf("regulation: subnet: ipv4_prefix_length must be between 1 and 32 but it is configured as %d", subnet.IPv4PrefixLength))
+	}
+
+	switch {
+	case subnet.IPv6PrefixLength == 0:
+		subnet.IPv6PrefixLength = schema.DefaultRegulationSubnetConfiguration.IPv6PrefixLength
+	case subnet.IPv6PrefixLength < 1 || subnet.IPv6PrefixLength > 128:
+		validator.Push(fmt.Errorf("regulation: subnet: ipv6_prefix_length must be between 1 and 128 but it is configured as %d", subnet.IPv6PrefixLength))
+	}
+}
+
+// validateRegulationLimit validates a regulation limit. The find_time and ban_time options default to the ones of the
+// user limit.
+func validateRegulationLimit(name string, limit *schema.RegulationLimitConfiguration, configuration *schema.RegulationConfiguration, validator *schema.StructValidator) {
+	if limit.MaxRetries < 0 {
+		validator.Push(fmt.Errorf("regulation: %s: max_retries must be 0 or above", name))
+	}
+
+	if limit.FindTime == "" {
+		limit.FindTime = configuration.FindTime
+	}
+
+	if limit.BanTime == "" {
+		limit.BanTime = configuration.BanTime
+	}
+
+	findTime, err := utils.ParseDurationString(limit.FindTime)
+	if err != nil {
+		validator.Push(fmt.Errorf("regulation: %s: error occurred parsing find_time string: %w", name, err))
+
+		return
+	}
+
+	banTime, err := utils.ParseDurationString(limit.BanTime)
+	if err != nil {
+		validator.Push(fmt.Errorf("regulation: %s: error occurred parsing ban_time string: %w", name, err))
+
+		return
+	}
+
+	if findTime > banTime {
+		validator.Push(fmt.Errorf("regulation: %s: find_time cannot be greater than ban_time", name))
+	}
 }
@@ -57,3 +57,111 @@ func TestShouldRaiseErrorOnBadDurationStrings(t *testing.T) {
 	assert.EqualError(t, validator.Errors()[0], "Error occurred parsing regulation find_time string: could not convert the input string of a year into a duration")
 	assert.EqualError(t, validator.Errors()[1], "Error occurred parsing regulation ban_time string: could not convert the input string of forever into a duration")
 }
+
+func TestShouldRaiseErrorOnInvalidForeignRegulation(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.TrustedCountries = []string{"NZ", "NZL"}
+	config.ForeignMaxRetries = -1
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "regulation: trusted country 'NZL' must be an ISO 3166-1 alpha-2 country code")
+	assert.EqualError(t, validator.Errors()[1], "regulation: foreign_max_retries must be 0 or above")
+
+	validator = schema.NewStructValidator()
+	config = newDefaultRegulationConfig()
+	config.ForeignMaxRetries = 1
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "regulation: foreign_max_retries requires the trusted_countries option to be configured")
+}
+
+func TestShouldSetDefaultRegulationLimits(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.FindTime = "1m"
+	config.IP = &schema.RegulationLimitConfiguration{MaxRetries: 10}
+	config.Subnet = &schema.RegulationSubnetConfiguration{RegulationLimitConfiguration: schema.RegulationLimitConfiguration{MaxRetries: 30}}
+	config.Global = &schema.RegulationLimitConfiguration{MaxRetries: 500, FindTime: "30s", BanTime: "2m"}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "1m", config.IP.FindTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.BanTime, config.IP.BanTime)
+	assert.Equal(t, "1m", config.Subnet.FindTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.BanTime, config.Subnet.BanTime)
+	assert.Equal(t, 24, config.Subnet.IPv4PrefixLength)
+	assert.Equal(t, 64, config.Subnet.IPv6PrefixLength)
+	assert.Equal(t, "30s", config.Global.FindTime)
+	assert.Equal(t, "2m", config.Global.BanTime)
+}
+
+func TestShouldRaiseErrorOnInvalidRegulationLimits(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.IP = &schema.RegulationLimitConfiguration{MaxRetries: -1, FindTime: "10m", BanTime: "5m"}
+	config.Subnet = &schema.RegulationSubnetConfiguration{
+		RegulationLimitConfiguration: schema.RegulationLimitConfiguration{MaxRetries: 30, FindTime: "a year"},
+		IPv4PrefixLength:             33,
+		IPv6PrefixLength:             -1,
+	}
+	config.Global = &schema.RegulationLimitConfiguration{MaxRetries: 500, BanTime: "forever"}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 6)
+	assert.EqualError(t, validator.Errors()[0], "regulation: ip: max_retries must be 0 or above")
+	assert.EqualError(t, validator.Errors()[1], "regulation: ip: find_time cannot be greater than ban_time")
+	assert.EqualError(t, validator.Errors()[2], "regulation: subnet: error occurred parsing find_time string: could not convert the input string of a year into a duration")
+	assert.EqualError(t, validator.Errors()[3], "regulation: subnet: ipv4_prefix_length must be between 1 and 32 but it is configured as 33")
+	assert.EqualError(t, validator.Errors()[4], "regulation: subnet: ipv6_prefix_length must be between 1 and 128 but it is configured as -1")
+	assert.EqualError(t, validator.Errors()[5], "regulation: global: error occurred parsing ban_time string: could not convert the input string of forever into a duration")
+}
+
+func TestShouldSetDefaultRegulationBackoff(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.Backoff = &schema.RegulationBackoffConfiguration{}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultRegulationBackoffConfiguration, *config.Backoff)
+}
+
+func TestShouldRaiseErrorOnInvalidRegulationBackoff(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.BanTime = "1h"
+	config.Backoff = &schema.RegulationBackoffConfiguration{Multiplier: -1, MaxBanTime: "30m", ResetTime: "forever"}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 3)
+	assert.EqualError(t, validator.Errors()[0], "regulation: backoff: multiplier must be 1 or above but it is configured as -1")
+	assert.EqualError(t, validator.Errors()[1], "regulation: backoff: max_ban_time cannot be less than ban_time")
+	assert.EqualError(t, validator.Errors()[2], "regulation: backoff: error occurred parsing reset_time string: could not convert the input string of forever into a duration")
+}
+
+func TestShouldValidateRegulationTOTPAndIdentityVerificationLimits(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+	config.TOTP = &schema.RegulationLimitConfiguration{MaxRetries: 5}
+	config.IdentityVerification = &schema.RegulationIdentityVerificationConfiguration{
+		User: &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "10m", BanTime: "1h"},
+		IP:   &schema.RegulationLimitConfiguration{MaxRetries: -1},
+	}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "regulation: identity_verification: ip: max_retries must be 0 or above")
+	assert.Equal(t, schema.DefaultRegulationConfiguration.FindTime, config.TOTP.FindTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.BanTime, config.TOTP.BanTime)
+	assert.Equal(t, "10m", config.IdentityVerification.User.FindTime)
+}
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"fmt"
 	"path"
 	"strings"
@@ -46,4 +47,123 @@ func ValidateServer(configuration *schema.Configuration, validator *schema.Struc
 	} else if configuration.Server.WriteBufferSize < 0 {
 		validator.Push(fmt.Errorf("server write buffer size must be above 0"))
 	}
+
+	validateServerTrustedProxies(configuration, validator)
+
+	if configuration.Server.Admin != nil {
+		validateServerAdmin(configuration, validator)
+	}
+
+	if configuration.Server.Metrics.Enabled {
+		validateServerMetrics(configuration, validator)
+	}
+}
+
+func validateServerTrustedProxies(configuration *schema.Configuration, validator *schema.StructValidator) {
+	for _, proxy := range configuration.Server.TrustedProxies {
+		if _, err := utils.ParseHostCIDR(proxy); err != nil {
+			validator.Push(fmt.Errorf("server: trusted proxy '%s' must be a valid IP or CIDR", proxy))
+		}
+	}
+
+	if len(configuration.Server.TrustedProxies) == 0 {
+		if configuration.Server.EnableProxyProtocol {
+			validator.Push(fmt.Errorf("server: option 'enable_proxy_protocol' requires the 'trusted_proxies' option to be configured"))
+		}
+
+		if configuration.Server.EnableForwardedHeader {
+			validator.Push(fmt.Errorf("server: option 'enable_forwarded_header' requires the 'trusted_proxies' option to be configured"))
+		}
+	}
+}
+
+func validateServerAdmin(configuration *schema.Configuration, validator *schema.StructValidator) {
+	admin := configuration.Server.Admin
+
+	if admin.Host == "" {
+		admin.Host = schema.DefaultServerAdminConfiguration.Host
+	}
+
+	if admin.Port == 0 {
+		admin.Port = schema.DefaultServerAdminConfiguration.Port
+	}
+
+	if admin.Port == configuration.Server.Port {
+		validator.Push(errors.New(errStrServerAdminPortInUse))
+	}
+
+	if admin.TLS.Key != "" && admin.TLS.Certificate == "" {
+		validator.Push(errors.New(errStrServerAdminTLSNoCertificate))
+	} else if admin.TLS.Key == "" && admin.TLS.Certificate != "" {
+		validator.Push(errors.New(errStrServerAdminTLSNoKey))
+	}
+
+	if len(admin.Tokens) == 0 {
+		validator.Push(errors.New(errStrServerAdminNoTokens))
+
+		return
+	}
+
+	names, tokens := map[string]bool{}, map[string]bool{}
+
+	for i, token := range admin.Tokens {
+		if token.Name == "" {
+			validator.Push(fmt.Errorf(errFmtServerAdminTokenNoName, i+1))
+
+			continue
+		}
+
+		if names[token.Name] {
+			validator.Push(fmt.Errorf(errFmtServerAdminTokenDuplicate, token.Name, "name"))
+		}
+
+		names[token.Name] = true
+
+		switch {
+		case len(token.Token) < serverAdminTokenMinLength:
+			validator.Push(fmt.Errorf(errFmtServerAdminTokenTooShort, token.Name, serverAdminTokenMinLength))
+		case tokens[token.Token]:
+			validator.Push(fmt.Errorf(errFmtServerAdminTokenDuplicate, token.Name, "token"))
+		}
+
+		tokens[token.Token] = true
+
+		if len(token.Scopes) == 0 {
+			validator.Push(fmt.Errorf(errFmtServerAdminTokenNoScopes, token.Name))
+		}
+
+		for _, scope := range token.Scopes {
+			if !utils.IsStringInSlice(scope, schema.AdminPossibleScopes) {
+				validator.Push(fmt.Errorf(errFmtServerAdminTokenInvalidScope, token.Name, scope, strings.Join(schema.AdminPossibleScopes, "', '")))
+			}
+		}
+	}
+}
+
+func validateServerMetrics(configuration *schema.Configuration, validator *schema.StructValidator) {
+	metrics := &configuration.Server.Metrics
+
+	if metrics.ServerListener {
+		if metrics.Host != "" || metrics.Port != 0 {
+			validator.Push(errors.New(errStrServerMetricsServerListener))
+		}
+
+		return
+	}
+
+	if metrics.Host == "" {
+		metrics.Host = schema.DefaultServerMetricsConfiguration.Host
+	}
+
+	if metrics.Port == 0 {
+		metrics.Port = schema.DefaultServerMetricsConfiguration.Port
+	}
+
+	if metrics.Port == configuration.Server.Port {
+		validator.Push(fmt.Errorf(errFmtServerMetricsPortInUse, "server"))
+	}
+
+	if configuration.Server.Admin != nil && metrics.Port == configuration.Server.Admin.Port {
+		validator.Push(fmt.Errorf(errFmtServerMetricsPortInUse, "admin"))
+	}
 }
@@ -167,3 +167,136 @@ func TestShouldValidateAndUpdatePort(t *testing.T) {
 	require.Len(t, validator.Errors(), 0)
 	assert.Equal(t, 9091, config.Server.Port)
 }
+
+func TestShouldRaiseErrorOnInvalidTrustedProxies(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.TrustedProxies = []string{"10.0.0.0/8", "192.168.1.1", "fe80::/10", "10.0.0.300", "proxy.example.com"}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 2)
+
+	assert.EqualError(t, validator.Errors()[0], "server: trusted proxy '10.0.0.300' must be a valid IP or CIDR")
+	assert.EqualError(t, validator.Errors()[1], "server: trusted proxy 'proxy.example.com' must be a valid IP or CIDR")
+}
+
+func TestShouldRaiseErrorWhenProxyOptionsEnabledWithoutTrustedProxies(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.EnableProxyProtocol = true
+	config.Server.EnableForwardedHeader = true
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 2)
+
+	assert.EqualError(t, validator.Errors()[0], "server: option 'enable_proxy_protocol' requires the 'trusted_proxies' option to be configured")
+	assert.EqualError(t, validator.Errors()[1], "server: option 'enable_forwarded_header' requires the 'trusted_proxies' option to be configured")
+}
+
+func TestShouldSetDefaultAdminHostAndPort(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Admin = &schema.ServerAdminConfiguration{
+		Tokens: []schema.ServerAdminTokenConfiguration{
+			{Name: "helpdesk", Token: "a-very-long-administrative-api-token-value", Scopes: []string{"devices:read", "devices:write"}},
+		},
+	}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "127.0.0.1", config.Server.Admin.Host)
+	assert.Equal(t, 9092, config.Server.Admin.Port)
+}
+
+func TestShouldRaiseErrorOnAdminWithoutTokens(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Admin = &schema.ServerAdminConfiguration{Port: 9091, TLS: schema.ServerTLSConfiguration{Key: "/key.pem"}}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 3)
+
+	assert.EqualError(t, validator.Errors()[0], "server: admin: 'port' configuration option must not be the same as the server port")
+	assert.EqualError(t, validator.Errors()[1], "server: admin: tls: 'certificate' configuration option must be provided with the 'key' configuration option")
+	assert.EqualError(t, validator.Errors()[2], "server: admin: 'tokens' configuration option must have at least one token")
+}
+
+func TestShouldRaiseErrorOnInvalidAdminTokens(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Admin = &schema.ServerAdminConfiguration{
+		Tokens: []schema.ServerAdminTokenConfiguration{
+			{Name: "helpdesk", Token: "a-very-long-administrative-api-token-value", Scopes: []string{"devices:read"}},
+			{Token: "another-very-long-administrative-api-token", Scopes: []string{"devices:read"}},
+			{Name: "helpdesk", Token: "a-very-long-administrative-api-token-value"},
+			{Name: "short", Token: "abc", Scopes: []string{"users:write"}},
+		},
+	}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 6)
+
+	assert.EqualError(t, validator.Errors()[0], "server: admin: tokens: token 2: 'name' configuration option must be provided")
+	assert.EqualError(t, validator.Errors()[1], "server: admin: tokens: token 'helpdesk': 'name' configuration option must be unique")
+	assert.EqualError(t, validator.Errors()[2], "server: admin: tokens: token 'helpdesk': 'token' configuration option must be unique")
+	assert.EqualError(t, validator.Errors()[3], "server: admin: tokens: token 'helpdesk': 'scopes' configuration option must have at least one scope")
+	assert.EqualError(t, validator.Errors()[4], "server: admin: tokens: token 'short': 'token' configuration option must be 32 characters or longer")
+	assert.EqualError(t, validator.Errors()[5], "server: admin: tokens: token 'short': 'scopes' configuration option has an invalid scope 'users:write', must be one of: 'devices:read', 'devices:write', 'regulation:write', 'sessions:read', 'sessions:write', 'logs:read'")
+}
+
+func TestShouldSetDefaultMetricsListener(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Metrics = schema.ServerMetricsConfiguration{Enabled: true}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "127.0.0.1", config.Server.Metrics.Host)
+	assert.Equal(t, 9959, config.Server.Metrics.Port)
+}
+
+func TestShouldNotSetDefaultMetricsListenerWhenServedByServer(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Metrics = schema.ServerMetricsConfiguration{Enabled: true, ServerListener: true}
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "", config.Server.Metrics.Host)
+	assert.Equal(t, 0, config.Server.Metrics.Port)
+}
+
+func TestShouldRaiseErrorOnInvalidMetricsListener(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := &schema.Configuration{}
+	config.Server.Metrics = schema.ServerMetricsConfiguration{Enabled: true, Host: "127.0.0.1", ServerListener: true}
+
+	ValidateServer(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "server: metrics: 'host' and 'port' configuration options must not be provided with the 'server_listener' configuration option")
+
+	validator = schema.NewStructValidator()
+	config.Server.Metrics = schema.ServerMetricsConfiguration{Enabled: true, Port: 9091}
+
+	ValidateServer(config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "server: metrics: 'port' configuration option must not be the same as the server port")
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -16,7 +17,9 @@ func ValidateSession(configuration *schema.SessionConfiguration, validator *sche
 	}
 
 	if configuration.Redis != nil {
-		if configuration.Redis.HighAvailability != nil {
+		if configuration.Redis.Cluster != nil {
+			validateRedisCluster(configuration, validator)
+		} else if configuration.Redis.HighAvailability != nil {
 			if configuration.Redis.HighAvailability.SentinelName != "" {
 				validateRedisSentinel(configuration, validator)
 			} else {
@@ -27,9 +30,27 @@ func ValidateSession(configuration *schema.SessionConfiguration, validator *sche
 		}
 	}
 
+	if configuration.Storage != nil {
+		validateSessionStorage(configuration, validator)
+	}
+
 	validateSession(configuration, validator)
 }
 
+func validateSessionStorage(configuration *schema.SessionConfiguration, validator *schema.StructValidator) {
+	if configuration.Redis != nil {
+		validator.Push(errors.New(errFmtSessionStorageAndRedis))
+	}
+
+	if configuration.Storage.GarbageCollectionInterval == "" {
+		configuration.Storage.GarbageCollectionInterval = schema.DefaultSessionStorageConfiguration.GarbageCollectionInterval
+	} else if duration, err := utils.ParseDurationString(configuration.Storage.GarbageCollectionInterval); err != nil {
+		validator.Push(fmt.Errorf(errFmtSessionStorageGCInterval, err))
+	} else if duration <= 0 {
+		validator.Push(fmt.Errorf(errFmtSessionStorageGCInterval, errors.New("the interval must be greater than 0")))
+	}
+}
+
 func validateSession(configuration *schema.SessionConfiguration, validator *schema.StructValidator) {
 	if configuration.Expiration == "" {
 		configuration.Expiration = schema.DefaultSessionConfiguration.Expiration // 1 hour
@@ -49,7 +70,7 @@ func validateSession(configuration *schema.SessionConfiguration, validator *sche
 		validator.Push(fmt.Errorf("Error occurred parsing session remember_me_duration string: %s", err))
 	}
 
-	if configuration.Domain == "" {
+	if configuration.Domain == "" && len(configuration.Cookies) == 0 {
 		validator.Push(errors.New("Set domain of the session object"))
 	}
 
@@ -62,6 +83,70 @@ func validateSession(configuration *schema.SessionConfiguration, validator *sche
 	} else if configuration.SameSite != "none" && configuration.SameSite != "lax" && configuration.SameSite != "strict" {
 		validator.Push(errors.New("session same_site is configured incorrectly, must be one of 'none', 'lax', or 'strict'"))
 	}
+
+	validateSessionCookies(configuration, validator)
+}
+
+func validateSessionCookies(configuration *schema.SessionConfiguration, validator *schema.StructValidator) {
+	domains := make([]string, 0, len(configuration.Cookies)+1)
+
+	if configuration.Domain != "" {
+		domains = append(domains, configuration.Domain)
+	}
+
+	for i := range configuration.Cookies {
+		cookie := &configuration.Cookies[i]
+
+		switch {
+		case cookie.Domain == "":
+			validator.Push(fmt.Errorf(errFmtSessionCookieDomainRequired, i+1))
+		case strings.Contains(cookie.Domain, "*"):
+			validator.Push(fmt.Errorf(errFmtSessionCookieDomainWildcard, cookie.Domain))
+		case utils.IsStringInSliceFold(cookie.Domain, domains):
+			validator.Push(fmt.Errorf(errFmtSessionCookieDomainDuplicate, cookie.Domain))
+		default:
+			domains = append(domains, cookie.Domain)
+		}
+
+		if cookie.Name == "" {
+			cookie.Name = configuration.Name
+		}
+
+		if cookie.SameSite == "" {
+			cookie.SameSite = configuration.SameSite
+		} else if cookie.SameSite != "none" && cookie.SameSite != "lax" && cookie.SameSite != "strict" {
+			validator.Push(fmt.Errorf(errFmtSessionCookieSameSite, cookie.Domain, cookie.SameSite))
+		}
+
+		if cookie.Expiration == "" {
+			cookie.Expiration = configuration.Expiration
+		} else if _, err := utils.ParseDurationString(cookie.Expiration); err != nil {
+			validator.Push(fmt.Errorf(errFmtSessionCookieExpiration, cookie.Domain, err))
+		}
+
+		if cookie.AutheliaURL != "" {
+			validateSessionCookieAutheliaURL(cookie, validator)
+		}
+	}
+}
+
+func validateSessionCookieAutheliaURL(cookie *schema.SessionCookieConfiguration, validator *schema.StructValidator) {
+	autheliaURL, err := url.Parse(cookie.AutheliaURL)
+	if err != nil {
+		validator.Push(fmt.Errorf(errFmtSessionCookieAutheliaURL, cookie.Domain, err))
+
+		return
+	}
+
+	if autheliaURL.Scheme != schemeHTTPS {
+		validator.Push(fmt.Errorf(errFmtSessionCookieAutheliaURLScheme, cookie.Domain, autheliaURL.Scheme))
+
+		return
+	}
+
+	if hostname := autheliaURL.Hostname(); cookie.Domain != "" && hostname != cookie.Domain && !strings.HasSuffix(hostname, "."+cookie.Domain) {
+		validator.Push(fmt.Errorf(errFmtSessionCookieAutheliaURLDomain, cookie.Domain, cookie.AutheliaURL))
+	}
 }
 
 func validateRedis(configuration *schema.SessionConfiguration, validator *schema.StructValidator) {
@@ -94,6 +179,51 @@ func validateRedisSentinel(configuration *schema.SessionConfiguration, validator
 	validateHighAvailability(configuration, validator, "redis sentinel")
 }
 
+func validateRedisCluster(configuration *schema.SessionConfiguration, validator *schema.StructValidator) {
+	const provider = "redis cluster"
+
+	if configuration.Redis.HighAvailability != nil {
+		validator.Push(errors.New(errFmtSessionRedisClusterAndSentinel))
+	}
+
+	if configuration.Redis.Host == "" && len(configuration.Redis.Cluster.Nodes) == 0 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisHostOrNodesRequired, provider))
+	}
+
+	if configuration.Secret == "" {
+		validator.Push(fmt.Errorf(errFmtSessionSecretRedisProvider, provider))
+	}
+
+	if configuration.Redis.Port == 0 {
+		configuration.Redis.Port = 6379
+	} else if configuration.Redis.Port < 0 || configuration.Redis.Port > 65535 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisPortRange, provider))
+	}
+
+	// Redis Cluster only supports the first database.
+	if configuration.Redis.DatabaseIndex != 0 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisClusterDatabase, configuration.Redis.DatabaseIndex))
+	}
+
+	for i, node := range configuration.Redis.Cluster.Nodes {
+		if node.Host == "" {
+			validator.Push(fmt.Errorf("The %s nodes require a host set but you have not set the host for one or more nodes", provider))
+			break
+		}
+
+		if node.Port == 0 {
+			configuration.Redis.Cluster.Nodes[i].Port = 6379
+		} else if node.Port < 0 || node.Port > 65535 {
+			validator.Push(fmt.Errorf(errFmtSessionRedisPortRange, provider))
+			break
+		}
+	}
+
+	if configuration.Redis.MaximumActiveConnections <= 0 {
+		configuration.Redis.MaximumActiveConnections = 8
+	}
+}
+
 func validateHighAvailability(configuration *schema.SessionConfiguration, validator *schema.StructValidator, provider string) {
 	if configuration.Redis.Host == "" && len(configuration.Redis.HighAvailability.Nodes) == 0 {
 		validator.Push(fmt.Errorf(errFmtSessionRedisHostOrNodesRequired, provider))
@@ -269,8 +269,10 @@ func TestShouldRaiseErrorsWhenRedisSentinelOptionsIncorrectlyConfigured(t *testi
 					Port: 26379,
 				},
 			},
-			RouteByLatency: true,
-			RouteRandomly:  true,
+			RedisRouteConfiguration: schema.RedisRouteConfiguration{
+				RouteByLatency: true,
+				RouteRandomly:  true,
+			},
 		},
 	}
 
@@ -315,8 +317,10 @@ func TestShouldNotRaiseErrorsAndSetDefaultPortWhenRedisSentinelPortBlank(t *test
 					Port: 26379,
 				},
 			},
-			RouteByLatency: true,
-			RouteRandomly:  true,
+			RedisRouteConfiguration: schema.RedisRouteConfiguration{
+				RouteByLatency: true,
+				RouteRandomly:  true,
+			},
 		},
 	}
 
@@ -337,8 +341,10 @@ func TestShouldRaiseErrorWhenRedisHostAndHighAvailabilityNodesEmpty(t *testing.T
 		HighAvailability: &schema.RedisHighAvailabilityConfiguration{
 			SentinelName:     "sentinel",
 			SentinelPassword: "abc123",
-			RouteByLatency:   true,
-			RouteRandomly:    true,
+			RedisRouteConfiguration: schema.RedisRouteConfiguration{
+				RouteByLatency: true,
+				RouteRandomly:  true,
+			},
 		},
 	}
 
@@ -456,3 +462,158 @@ func TestShouldSetDefaultRememberMeDuration(t *testing.T) {
 	assert.False(t, validator.HasErrors())
 	assert.Equal(t, config.RememberMeDuration, schema.DefaultSessionConfiguration.RememberMeDuration)
 }
+
+func TestShouldNotRequireDomainWhenCookiesAreConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Domain = ""
+	config.Cookies = []schema.SessionCookieConfiguration{
+		{Domain: "example.com"},
+		{Domain: "example.org", AutheliaURL: "https://auth.example.org", Name: "authelia_session_org", SameSite: "strict", Expiration: "2h"},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	assert.False(t, validator.HasErrors())
+
+	assert.Equal(t, schema.DefaultSessionConfiguration.Name, config.Cookies[0].Name)
+	assert.Equal(t, schema.DefaultSessionConfiguration.SameSite, config.Cookies[0].SameSite)
+	assert.Equal(t, schema.DefaultSessionConfiguration.Expiration, config.Cookies[0].Expiration)
+
+	assert.Equal(t, "authelia_session_org", config.Cookies[1].Name)
+	assert.Equal(t, "strict", config.Cookies[1].SameSite)
+	assert.Equal(t, "2h", config.Cookies[1].Expiration)
+}
+
+func TestShouldRaiseErrorsWhenCookiesAreMisconfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Cookies = []schema.SessionCookieConfiguration{
+		{},
+		{Domain: "*.example.org"},
+		{Domain: "Example.com"},
+		{Domain: "example.net", SameSite: "NOne", Expiration: "1 year"},
+		{Domain: "example.io", AutheliaURL: "http://auth.example.io"},
+		{Domain: "example.dev", AutheliaURL: "https://auth.example.io"},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	require.Len(t, validator.Errors(), 7)
+
+	assert.EqualError(t, validator.Errors()[0], "session: cookies: option 'domain' is required for cookie #1")
+	assert.EqualError(t, validator.Errors()[1], "session: cookies: option 'domain' must be the root domain you're protecting instead of a wildcard domain but it's configured as '*.example.org'")
+	assert.EqualError(t, validator.Errors()[2], "session: cookies: option 'domain' must be unique but 'Example.com' is configured more than once")
+	assert.EqualError(t, validator.Errors()[3], "session: cookies: option 'same_site' for domain 'example.net' must be one of 'none', 'lax', or 'strict' but it's configured as 'NOne'")
+	assert.EqualError(t, validator.Errors()[4], "session: cookies: option 'expiration' for domain 'example.net' could not be parsed: could not convert the input string of 1 year into a duration")
+	assert.EqualError(t, validator.Errors()[5], "session: cookies: option 'authelia_url' for domain 'example.io' must have the 'https' scheme but it's configured as 'http'")
+	assert.EqualError(t, validator.Errors()[6], "session: cookies: option 'authelia_url' for domain 'example.dev' must be on the domain of the cookie but it's configured as 'https://auth.example.io'")
+}
+
+func TestShouldSetDefaultsWhenRedisClusterConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Redis = &schema.RedisSessionConfiguration{
+		Cluster: &schema.RedisClusterConfiguration{
+			Nodes: []schema.RedisNode{
+				{Host: "redis1"},
+				{Host: "redis2", Port: 7000},
+			},
+			RedisRouteConfiguration: schema.RedisRouteConfiguration{RouteRandomly: true},
+		},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	assert.False(t, validator.HasErrors())
+
+	assert.Equal(t, 6379, config.Redis.Port)
+	assert.Equal(t, 8, config.Redis.MaximumActiveConnections)
+	assert.Equal(t, 6379, config.Redis.Cluster.Nodes[0].Port)
+	assert.Equal(t, 7000, config.Redis.Cluster.Nodes[1].Port)
+	assert.True(t, config.Redis.Cluster.RouteRandomly)
+}
+
+func TestShouldRaiseErrorsWhenRedisClusterMisconfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Secret = ""
+	config.Redis = &schema.RedisSessionConfiguration{
+		Port:          -1,
+		DatabaseIndex: 2,
+		HighAvailability: &schema.RedisHighAvailabilityConfiguration{
+			SentinelName: "sentinel",
+		},
+		Cluster: &schema.RedisClusterConfiguration{},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	require.Len(t, validator.Errors(), 5)
+
+	assert.EqualError(t, validator.Errors()[0], "the redis cluster and high_availability options can't both be configured")
+	assert.EqualError(t, validator.Errors()[1], "either the host or a node must be provided when using the redis cluster session provider")
+	assert.EqualError(t, validator.Errors()[2], "the session secret must be set when using the redis cluster session provider")
+	assert.EqualError(t, validator.Errors()[3], "the port must be between 1 and 65535 for the redis cluster session provider")
+	assert.EqualError(t, validator.Errors()[4], "the database_index must be 0 when using the redis cluster session provider as it's configured as 2")
+}
+
+func TestShouldRaiseErrorWhenRedisClusterNodeHasNoHost(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Redis = &schema.RedisSessionConfiguration{
+		Cluster: &schema.RedisClusterConfiguration{
+			Nodes: []schema.RedisNode{
+				{Host: "redis1"},
+				{Port: 7000},
+				{Port: 7001},
+			},
+		},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "The redis cluster nodes require a host set but you have not set the host for one or more nodes")
+}
+
+func TestShouldSetDefaultStorageSessionGarbageCollectionInterval(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Storage = &schema.SessionStorageConfiguration{}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	assert.False(t, validator.HasErrors())
+	assert.Equal(t, schema.DefaultSessionStorageConfiguration.GarbageCollectionInterval, config.Storage.GarbageCollectionInterval)
+}
+
+func TestShouldRaiseErrorsWhenStorageSessionMisconfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Storage = &schema.SessionStorageConfiguration{GarbageCollectionInterval: "1 year"}
+	config.Redis = &schema.RedisSessionConfiguration{Host: "redis", Port: 6379}
+
+	ValidateSession(&config, validator)
+
+	assert.False(t, validator.HasWarnings())
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "the redis and storage session providers can't both be configured")
+	assert.EqualError(t, validator.Errors()[1], "the storage session provider garbage_collection_interval could not be parsed: could not convert the input string of 1 year into a duration")
+
+	validator.Clear()
+
+	config.Redis = nil
+	config.Storage.GarbageCollectionInterval = "0"
+
+	ValidateSession(&config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "the storage session provider garbage_collection_interval could not be parsed: the interval must be greater than 0")
+}
@@ -29,6 +29,127 @@ func ValidateStorage(configuration schema.StorageConfiguration, validator *schem
 	} else if len(configuration.EncryptionKey) < 20 {
 		validator.Push(errors.New(errStrStorageEncryptionKeyTooShort))
 	}
+
+	for i, key := range configuration.DecryptionKeys {
+		switch {
+		case len(key) < 20:
+			validator.Push(fmt.Errorf(errFmtStorageDecryptionKeyTooShort, i+1))
+		case key == configuration.EncryptionKey:
+			validator.Push(fmt.Errorf(errFmtStorageDecryptionKeyIsEncryptionKey, i+1))
+		}
+	}
+
+	if configuration.Retention != nil {
+		validateStorageRetention(configuration.Retention, validator)
+	}
+}
+
+// ValidateStorageRetention validates the retention of the storage is long enough to keep the rows required by the rest
+// of the configuration. It must be called after the regulation and notifier configurations have been validated.
+func ValidateStorageRetention(configuration *schema.Configuration, validator *schema.StructValidator) {
+	retention := configuration.Storage.Retention
+	if retention == nil {
+		return
+	}
+
+	var authenticationLogs, identityVerification, regulationBans []storageRetentionRequirement
+
+	if regulation := configuration.Regulation; regulation != nil {
+		authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"regulation.ban_time", regulation.BanTime})
+
+		if regulation.IP != nil {
+			authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"regulation.ip.ban_time", regulation.IP.BanTime})
+		}
+
+		if regulation.Subnet != nil {
+			authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"regulation.subnet.ban_time", regulation.Subnet.BanTime})
+		}
+
+		if regulation.Global != nil {
+			authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"regulation.global.ban_time", regulation.Global.BanTime})
+		}
+
+		if regulation.TOTP != nil {
+			authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"regulation.totp.ban_time", regulation.TOTP.BanTime})
+		}
+
+		if regulation.IdentityVerification != nil {
+			if regulation.IdentityVerification.User != nil {
+				identityVerification = append(identityVerification, storageRetentionRequirement{"regulation.identity_verification.user.ban_time", regulation.IdentityVerification.User.BanTime})
+			}
+
+			if regulation.IdentityVerification.IP != nil {
+				identityVerification = append(identityVerification, storageRetentionRequirement{"regulation.identity_verification.ip.ban_time", regulation.IdentityVerification.IP.BanTime})
+			}
+		}
+
+		if regulation.Backoff != nil {
+			regulationBans = append(regulationBans, storageRetentionRequirement{"regulation.backoff.reset_time", regulation.Backoff.ResetTime})
+		}
+	}
+
+	if notifier := configuration.Notifier; notifier != nil && !notifier.SecurityEvents.Disable && !notifier.SecurityEvents.DisableNewLogin {
+		authenticationLogs = append(authenticationLogs, storageRetentionRequirement{"notifier.security_events.new_login_lookback", notifier.SecurityEvents.NewLoginLookback})
+	}
+
+	validateStorageRetentionMinimum("authentication_logs", retention.AuthenticationLogs, authenticationLogs, validator)
+	validateStorageRetentionMinimum("identity_verification", retention.IdentityVerification, identityVerification, validator)
+	validateStorageRetentionMinimum("regulation_bans", retention.RegulationBans, regulationBans, validator)
+}
+
+func validateStorageRetention(configuration *schema.StorageRetentionConfiguration, validator *schema.StructValidator) {
+	for _, option := range []storageRetentionRequirement{
+		{"authentication_logs", configuration.AuthenticationLogs},
+		{"identity_verification", configuration.IdentityVerification},
+		{"regulation_bans", configuration.RegulationBans},
+	} {
+		if option.value == "" {
+			continue
+		}
+
+		if _, err := utils.ParseDurationString(option.value); err != nil {
+			validator.Push(fmt.Errorf(errFmtStorageRetentionParse, option.option, err))
+		}
+	}
+
+	if configuration.Interval == "" {
+		configuration.Interval = schema.DefaultStorageRetentionConfiguration.Interval
+	} else if interval, err := utils.ParseDurationString(configuration.Interval); err != nil {
+		validator.Push(fmt.Errorf(errFmtStorageRetentionParse, "interval", err))
+	} else if interval <= 0 {
+		validator.Push(errors.New(errFmtStorageRetentionIntervalPositive))
+	}
+
+	switch {
+	case configuration.BatchSize == 0:
+		configuration.BatchSize = schema.DefaultStorageRetentionConfiguration.BatchSize
+	case configuration.BatchSize < 0:
+		validator.Push(fmt.Errorf(errFmtStorageRetentionBatchSize, configuration.BatchSize))
+	}
+}
+
+// validateStorageRetentionMinimum ensures a retention which prunes rows keeps them for at least as long as each of the
+// required options reads them. The options which can't be parsed are reported by their own validation.
+func validateStorageRetentionMinimum(name, value string, required []storageRetentionRequirement, validator *schema.StructValidator) {
+	if value == "" {
+		return
+	}
+
+	retention, err := utils.ParseDurationString(value)
+	if err != nil || retention <= 0 {
+		return
+	}
+
+	for _, option := range required {
+		minimum, err := utils.ParseDurationString(option.value)
+		if err != nil {
+			continue
+		}
+
+		if retention < minimum {
+			validator.Push(fmt.Errorf(errFmtStorageRetentionTooShort, name, option.value, option.option, value))
+		}
+	}
 }
 
 func validateSQLConfiguration(configuration *schema.SQLStorageConfiguration, validator *schema.StructValidator, provider string) {
@@ -47,6 +168,32 @@ func validateSQLConfiguration(configuration *schema.SQLStorageConfiguration, val
 	if configuration.Database == "" {
 		validator.Push(fmt.Errorf(errFmtStorageOptionMustBeProvided, provider, "database"))
 	}
+
+	if configuration.QueryTimeout < 0 {
+		validator.Push(fmt.Errorf(errFmtStorageOptionMustNotBeNegative, provider, "query_timeout", configuration.QueryTimeout))
+	}
+
+	if configuration.Pool.MaxOpen < 0 {
+		validator.Push(fmt.Errorf(errFmtStorageOptionMustNotBeNegative, provider, "pool.max_open", configuration.Pool.MaxOpen))
+	}
+
+	if configuration.Pool.MaxIdle < 0 {
+		validator.Push(fmt.Errorf(errFmtStorageOptionMustNotBeNegative, provider, "pool.max_idle", configuration.Pool.MaxIdle))
+	}
+
+	if configuration.Pool.MaxLifetime < 0 {
+		validator.Push(fmt.Errorf(errFmtStorageOptionMustNotBeNegative, provider, "pool.max_lifetime", configuration.Pool.MaxLifetime))
+	}
+
+	if configuration.Pool.MaxIdleTime < 0 {
+		validator.Push(fmt.Errorf(errFmtStorageOptionMustNotBeNegative, provider, "pool.max_idle_time", configuration.Pool.MaxIdleTime))
+	}
+
+	for i, replica := range configuration.Replicas {
+		if replica.Host == "" {
+			validator.Push(fmt.Errorf(errFmtStorageReplicaHostMustBeProvided, provider, i+1))
+		}
+	}
 }
 
 func validatePostgreSQLConfiguration(configuration *schema.PostgreSQLStorageConfiguration, validator *schema.StructValidator) {
@@ -73,3 +220,9 @@ func validateLocalStorageConfiguration(configuration *schema.LocalStorageConfigu
 		validator.Push(fmt.Errorf(errFmtStorageOptionMustBeProvided, "local", "path"))
 	}
 }
+
+// storageRetentionRequirement is a configuration option and its duration value.
+type storageRetentionRequirement struct {
+	option string
+	value  string
+}
@@ -2,7 +2,10 @@ package validator
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -20,6 +23,8 @@ func (suite *StorageSuite) SetupTest() {
 	suite.configuration.Local = nil
 	suite.configuration.PostgreSQL = nil
 	suite.configuration.MySQL = nil
+	suite.configuration.Retention = nil
+	suite.configuration.DecryptionKeys = nil
 }
 
 func (suite *StorageSuite) TestShouldValidateOneStorageIsConfigured() {
@@ -212,6 +217,122 @@ func (suite *StorageSuite) TestShouldRaiseErrorOnShortEncryptionKey() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: 'encryption_key' configuration option must be 20 characters or longer")
 }
 
+func (suite *StorageSuite) TestShouldRaiseErrorOnInvalidDecryptionKeys() {
+	suite.configuration.Local = &schema.LocalStorageConfiguration{
+		Path: "/this/is/a/path",
+	}
+	suite.configuration.DecryptionKeys = []string{"an-old-encryption-key-which-is-long-enough", "abc", testEncryptionKey}
+
+	ValidateStorage(suite.configuration, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: 'decryption_keys' configuration option key 2 must be 20 characters or longer")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "storage: 'decryption_keys' configuration option key 3 must not be the same as the 'encryption_key'")
+}
+
+func (suite *StorageSuite) TestShouldRaiseErrorOnInvalidPoolAndReplicas() {
+	suite.configuration.PostgreSQL = &schema.PostgreSQLStorageConfiguration{
+		SQLStorageConfiguration: schema.SQLStorageConfiguration{
+			Host:         "db1",
+			Username:     "myuser",
+			Password:     "pass",
+			Database:     "database",
+			QueryTimeout: -time.Second,
+			Pool: schema.SQLStoragePoolConfiguration{
+				MaxOpen:     -1,
+				MaxIdle:     2,
+				MaxLifetime: -time.Minute,
+			},
+			Replicas: []schema.SQLStorageReplicaConfiguration{{Host: "db2"}, {Port: 5432}},
+		},
+	}
+
+	ValidateStorage(suite.configuration, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: postgres: 'query_timeout' configuration option must be 0 or greater but it is -1s")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "storage: postgres: 'pool.max_open' configuration option must be 0 or greater but it is -1")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "storage: postgres: 'pool.max_lifetime' configuration option must be 0 or greater but it is -1m0s")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "storage: postgres: replicas: replica 2: 'host' configuration option must be provided")
+}
+
+func (suite *StorageSuite) TestShouldSetDefaultRetention() {
+	suite.configuration.Local = &schema.LocalStorageConfiguration{
+		Path: "/this/is/a/path",
+	}
+	suite.configuration.Retention = &schema.StorageRetentionConfiguration{
+		AuthenticationLogs: "90d",
+	}
+
+	ValidateStorage(suite.configuration, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal("1h", suite.configuration.Retention.Interval)
+	suite.Assert().Equal(1000, suite.configuration.Retention.BatchSize)
+}
+
+func (suite *StorageSuite) TestShouldRaiseErrorOnInvalidRetention() {
+	suite.configuration.Local = &schema.LocalStorageConfiguration{
+		Path: "/this/is/a/path",
+	}
+	suite.configuration.Retention = &schema.StorageRetentionConfiguration{
+		AuthenticationLogs: "90x",
+		RegulationBans:     "1y",
+		Interval:           "0",
+		BatchSize:          -1,
+	}
+
+	ValidateStorage(suite.configuration, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+	suite.Assert().EqualError(suite.validator.Errors()[0], "storage: retention: 'authentication_logs' configuration option could not be parsed: could not convert the input string of 90x into a duration")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "storage: retention: 'interval' configuration option must be greater than 0")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "storage: retention: 'batch_size' configuration option must be greater than 0 but it is -1")
+}
+
+func TestShouldRaiseErrorOnRetentionShorterThanRequired(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultConfig()
+
+	config.Regulation = &schema.RegulationConfiguration{
+		BanTime: "2h",
+		TOTP:    &schema.RegulationLimitConfiguration{BanTime: "10m"},
+		IdentityVerification: &schema.RegulationIdentityVerificationConfiguration{
+			User: &schema.RegulationLimitConfiguration{BanTime: "1d"},
+		},
+		Backoff: &schema.RegulationBackoffConfiguration{ResetTime: "1d"},
+	}
+	config.Notifier = &schema.NotifierConfiguration{
+		SecurityEvents: schema.SecurityEventsNotifierConfiguration{NewLoginLookback: "90d"},
+	}
+	config.Storage.Retention = &schema.StorageRetentionConfiguration{
+		AuthenticationLogs:   "1h",
+		IdentityVerification: "0",
+		RegulationBans:       "1d",
+	}
+
+	ValidateStorageRetention(&config, validator)
+
+	require.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "storage: retention: 'authentication_logs' configuration option must be 0 or at least 2h as the rows are required by the 'regulation.ban_time' configuration option but it is 1h")
+	assert.EqualError(t, validator.Errors()[1], "storage: retention: 'authentication_logs' configuration option must be 0 or at least 90d as the rows are required by the 'notifier.security_events.new_login_lookback' configuration option but it is 1h")
+
+	validator.Clear()
+
+	config.Notifier.SecurityEvents.DisableNewLogin = true
+	config.Storage.Retention.AuthenticationLogs = "1d"
+
+	ValidateStorageRetention(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+}
+
 func TestShouldRunStorageSuite(t *testing.T) {
 	suite.Run(t, new(StorageSuite))
 }
@@ -0,0 +1,45 @@
+package validator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// validateSyslog validates and updates the configuration of a syslog server. The name is the prefix of the errors.
+func validateSyslog(name string, configuration *schema.SyslogConfiguration, defaultFacility string, validator *schema.StructValidator) {
+	switch configuration.Network {
+	case "":
+		configuration.Network = schema.DefaultSyslogConfiguration.Network
+	case schema.SyslogNetworkUDP, schema.SyslogNetworkTCP, schema.SyslogNetworkUnix:
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtSyslogNetwork, name, strings.Join([]string{schema.SyslogNetworkUDP, schema.SyslogNetworkTCP, schema.SyslogNetworkUnix}, "', '"), configuration.Network))
+
+		return
+	}
+
+	if configuration.Address == "" {
+		configuration.Address = schema.DefaultSyslogAddresses[configuration.Network]
+	}
+
+	if configuration.AppName == "" {
+		configuration.AppName = schema.DefaultSyslogConfiguration.AppName
+	}
+
+	if configuration.Facility == "" {
+		configuration.Facility = defaultFacility
+	} else if _, ok := schema.SyslogFacilities[configuration.Facility]; !ok {
+		facilities := make([]string, 0, len(schema.SyslogFacilities))
+
+		for facility := range schema.SyslogFacilities {
+			facilities = append(facilities, facility)
+		}
+
+		sort.Strings(facilities)
+
+		validator.Push(fmt.Errorf(errFmtSyslogFacility, name, strings.Join(facilities, "', '"), configuration.Facility))
+	}
+}
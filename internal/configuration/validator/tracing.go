@@ -0,0 +1,55 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateTracing validates and updates the tracing configuration.
+func ValidateTracing(configuration *schema.TracingConfiguration, validator *schema.StructValidator) {
+	if !configuration.Enabled {
+		return
+	}
+
+	if configuration.ServiceName == "" {
+		configuration.ServiceName = schema.DefaultTracingConfiguration.ServiceName
+	}
+
+	if configuration.SamplingRatio == 0 {
+		configuration.SamplingRatio = schema.DefaultTracingConfiguration.SamplingRatio
+	} else if configuration.SamplingRatio < 0 || configuration.SamplingRatio > 1 {
+		validator.Push(fmt.Errorf(errFmtTracingSamplingRatio, configuration.SamplingRatio))
+	}
+
+	switch configuration.Exporter {
+	case "":
+		configuration.Exporter = schema.DefaultTracingConfiguration.Exporter
+
+		validateTracingOTLP(&configuration.OTLP, validator)
+	case schema.TracingExporterOTLP:
+		validateTracingOTLP(&configuration.OTLP, validator)
+	case schema.TracingExporterFile:
+		// The spans are written to stdout when the path isn't configured.
+	default:
+		validator.Push(fmt.Errorf(errFmtTracingExporter, strings.Join([]string{schema.TracingExporterOTLP, schema.TracingExporterFile}, "', '"), configuration.Exporter))
+	}
+}
+
+func validateTracingOTLP(configuration *schema.TracingOTLPConfiguration, validator *schema.StructValidator) {
+	switch configuration.Protocol {
+	case "":
+		configuration.Protocol = schema.DefaultTracingConfiguration.OTLP.Protocol
+	case schema.TracingOTLPProtocolGRPC, schema.TracingOTLPProtocolHTTP:
+		// The protocol is valid.
+	default:
+		validator.Push(fmt.Errorf(errFmtTracingOTLPProtocol, strings.Join([]string{schema.TracingOTLPProtocolGRPC, schema.TracingOTLPProtocolHTTP}, "', '"), configuration.Protocol))
+
+		return
+	}
+
+	if configuration.Endpoint == "" {
+		configuration.Endpoint = schema.DefaultTracingOTLPEndpoints[configuration.Protocol]
+	}
+}
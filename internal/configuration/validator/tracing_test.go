@@ -0,0 +1,73 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldNotValidateDisabledTracing(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := schema.TracingConfiguration{Exporter: "zipkin"}
+
+	ValidateTracing(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "", config.ServiceName)
+}
+
+func TestShouldSetDefaultTracingValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := schema.TracingConfiguration{Enabled: true}
+
+	ValidateTracing(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "authelia", config.ServiceName)
+	assert.Equal(t, float64(1), config.SamplingRatio)
+	assert.Equal(t, schema.TracingExporterOTLP, config.Exporter)
+	assert.Equal(t, schema.TracingOTLPProtocolGRPC, config.OTLP.Protocol)
+	assert.Equal(t, "localhost:4317", config.OTLP.Endpoint)
+}
+
+func TestShouldSetDefaultTracingOTLPHTTPEndpoint(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := schema.TracingConfiguration{Enabled: true, OTLP: schema.TracingOTLPConfiguration{Protocol: schema.TracingOTLPProtocolHTTP}}
+
+	ValidateTracing(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "localhost:4318", config.OTLP.Endpoint)
+}
+
+func TestShouldNotValidateOTLPWithFileExporter(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := schema.TracingConfiguration{Enabled: true, Exporter: schema.TracingExporterFile}
+
+	ValidateTracing(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, "", config.OTLP.Endpoint)
+}
+
+func TestShouldRaiseErrorOnInvalidTracingOptions(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := schema.TracingConfiguration{Enabled: true, SamplingRatio: 1.5, Exporter: "zipkin"}
+
+	ValidateTracing(&config, validator)
+
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "tracing: 'sampling_ratio' configuration option must be greater than 0 and less than or equal to 1 but it is configured as '1.5'")
+	assert.EqualError(t, validator.Errors()[1], "tracing: 'exporter' configuration option must be one of 'otlp', 'file' but it is configured as 'zipkin'")
+
+	validator = schema.NewStructValidator()
+	config = schema.TracingConfiguration{Enabled: true, OTLP: schema.TracingOTLPConfiguration{Protocol: "udp"}}
+
+	ValidateTracing(&config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "tracing: otlp: 'protocol' configuration option must be one of 'grpc', 'http' but it is configured as 'udp'")
+}
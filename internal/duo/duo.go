@@ -5,8 +5,10 @@ import (
 	"net/url"
 
 	duoapi "github.com/duosecurity/duo_api_golang"
+	"go.opentelemetry.io/otel/semconv"
 
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/tracing"
 )
 
 // NewDuoAPI create duo API instance.
@@ -21,7 +23,10 @@ func NewDuoAPI(duoAPI *duoapi.DuoApi) *APIImpl {
 func (d *APIImpl) Call(ctx *middlewares.AutheliaCtx, values url.Values, method string, path string) (*Response, error) {
 	var response Response
 
+	_, span := tracing.Start(ctx, "duo.Call", semconv.HTTPMethodKey.String(method), semconv.HTTPTargetKey.String(path))
 	_, responseBytes, err := d.DuoApi.SignedCall(method, path, values)
+	tracing.End(span, err)
+
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,88 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewMaxMindProvider opens the MaxMind format databases of the configuration and returns a MaxMindProvider which
+// implements the geoip.Provider.
+func NewMaxMindProvider(config *schema.GeoIPConfiguration) (provider *MaxMindProvider, err error) {
+	provider = &MaxMindProvider{
+		log: logging.Logger(),
+	}
+
+	if config.CountryDatabase != "" {
+		if provider.country, err = maxminddb.Open(config.CountryDatabase); err != nil {
+			return nil, fmt.Errorf("unable to open the GeoIP country database %s: %w", config.CountryDatabase, err)
+		}
+	}
+
+	if config.ASNDatabase != "" {
+		if provider.asn, err = maxminddb.Open(config.ASNDatabase); err != nil {
+			_ = provider.Close()
+
+			return nil, fmt.Errorf("unable to open the GeoIP ASN database %s: %w", config.ASNDatabase, err)
+		}
+	}
+
+	return provider, nil
+}
+
+// MaxMindProvider is a geoip.Provider which reads MaxMind format databases such as GeoLite2-Country and GeoLite2-ASN.
+type MaxMindProvider struct {
+	country *maxminddb.Reader
+	asn     *maxminddb.Reader
+
+	log *logrus.Logger
+}
+
+// Lookup the location of an IP address.
+func (p *MaxMindProvider) Lookup(ip net.IP) (location Location) {
+	if ip == nil {
+		return location
+	}
+
+	if p.country != nil {
+		var record maxMindCountryRecord
+
+		if err := p.country.Lookup(ip, &record); err != nil {
+			p.log.Debugf("Unable to lookup the country of %s: %v", ip, err)
+		} else {
+			location.Country = record.Country.ISOCode
+		}
+	}
+
+	if p.asn != nil {
+		var record maxMindASNRecord
+
+		if err := p.asn.Lookup(ip, &record); err != nil {
+			p.log.Debugf("Unable to lookup the ASN of %s: %v", ip, err)
+		} else {
+			location.ASN = record.AutonomousSystemNumber
+		}
+	}
+
+	return location
+}
+
+// Close the databases.
+func (p *MaxMindProvider) Close() (err error) {
+	if p.country != nil {
+		err = p.country.Close()
+	}
+
+	if p.asn != nil {
+		if asnErr := p.asn.Close(); asnErr != nil {
+			err = asnErr
+		}
+	}
+
+	return err
+}
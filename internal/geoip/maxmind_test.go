@@ -0,0 +1,111 @@
+package geoip
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// mmdbField is a key value pair of a MaxMind DB map, kept ordered so the output is deterministic.
+type mmdbField struct {
+	key   string
+	value interface{}
+}
+
+func mmdbEncode(value interface{}) (data []byte) {
+	switch v := value.(type) {
+	case string:
+		return append([]byte{2<<5 | byte(len(v))}, v...)
+	case uint16:
+		return []byte{5<<5 | 2, byte(v >> 8), byte(v)}
+	case uint32:
+		return []byte{6<<5 | 4, byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)}
+	case []mmdbField:
+		data = []byte{7<<5 | byte(len(v))}
+
+		for _, field := range v {
+			data = append(data, mmdbEncode(field.key)...)
+			data = append(data, mmdbEncode(field.value)...)
+		}
+
+		return data
+	default:
+		panic("unsupported type")
+	}
+}
+
+// writeTestDatabase writes an IPv4 MaxMind DB with a single node, where addresses of which the first bit is 0 (i.e.
+// 0.0.0.0/1) are associated with the record, and any other address has no data.
+func writeTestDatabase(t *testing.T, record []mmdbField) (path string) {
+	const nodeCount = 1
+
+	// A 24 bit record size node, the left record points to the start of the data section, the right one is empty.
+	data := []byte{0, 0, nodeCount + 16, 0, 0, nodeCount}
+
+	data = append(data, make([]byte, 16)...)
+	data = append(data, mmdbEncode(record)...)
+	data = append(data, "\xAB\xCD\xEFMaxMind.com"...)
+	data = append(data, mmdbEncode([]mmdbField{
+		{"binary_format_major_version", uint16(2)},
+		{"binary_format_minor_version", uint16(0)},
+		{"database_type", "Test"},
+		{"ip_version", uint16(4)},
+		{"node_count", uint32(nodeCount)},
+		{"record_size", uint16(24)},
+	})...)
+
+	path = filepath.Join(t.TempDir(), "test.mmdb")
+
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	return path
+}
+
+func TestShouldLookupCountryAndASN(t *testing.T) {
+	provider, err := NewMaxMindProvider(&schema.GeoIPConfiguration{
+		CountryDatabase: writeTestDatabase(t, []mmdbField{{"country", []mmdbField{{"iso_code", "NZ"}}}}),
+		ASNDatabase:     writeTestDatabase(t, []mmdbField{{"autonomous_system_number", uint32(13335)}}),
+	})
+	require.NoError(t, err)
+
+	defer provider.Close()
+
+	location := provider.Lookup(net.ParseIP("10.0.0.1"))
+	assert.Equal(t, Location{Country: "NZ", ASN: 13335}, location)
+	assert.Equal(t, "NZ AS13335", location.String())
+
+	location = provider.Lookup(net.ParseIP("192.168.0.1"))
+	assert.Equal(t, Location{}, location)
+	assert.Equal(t, "unknown", location.String())
+
+	assert.Equal(t, Location{}, provider.Lookup(net.ParseIP("2001:db8::1")))
+	assert.Equal(t, Location{}, provider.Lookup(nil))
+}
+
+func TestShouldLookupCountryOnly(t *testing.T) {
+	provider, err := NewMaxMindProvider(&schema.GeoIPConfiguration{
+		CountryDatabase: writeTestDatabase(t, []mmdbField{{"country", []mmdbField{{"iso_code", "NZ"}}}}),
+	})
+	require.NoError(t, err)
+
+	defer provider.Close()
+
+	location := provider.Lookup(net.ParseIP("10.0.0.1"))
+	assert.Equal(t, Location{Country: "NZ"}, location)
+	assert.Equal(t, "NZ", location.String())
+}
+
+func TestShouldRaiseErrorOnInvalidDatabase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "invalid.mmdb")
+
+	require.NoError(t, os.WriteFile(path, []byte("invalid"), 0600))
+
+	_, err := NewMaxMindProvider(&schema.GeoIPConfiguration{ASNDatabase: path})
+	assert.EqualError(t, err, "unable to open the GeoIP ASN database "+path+": error opening database: invalid MaxMind DB file")
+}
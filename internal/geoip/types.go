@@ -0,0 +1,43 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+)
+
+// Provider looks up the location of IP addresses.
+type Provider interface {
+	Lookup(ip net.IP) (location Location)
+	Close() (err error)
+}
+
+// Location is the location of an IP address. Values which are not known are left empty.
+type Location struct {
+	// Country is the ISO 3166-1 alpha-2 code of the country the IP address is located in.
+	Country string
+
+	// ASN is the number of the autonomous system the IP address belongs to.
+	ASN uint
+}
+
+// String returns a string representation of the Location.
+func (l Location) String() string {
+	switch {
+	case l.Country == "" && l.ASN == 0:
+		return "unknown"
+	case l.ASN == 0:
+		return l.Country
+	default:
+		return fmt.Sprintf("%s AS%d", l.Country, l.ASN)
+	}
+}
+
+type maxMindCountryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+type maxMindASNRecord struct {
+	AutonomousSystemNumber uint `maxminddb:"autonomous_system_number"`
+}
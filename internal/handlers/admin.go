@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/audit"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// RegisterAdmin registers the administrative API handlers with the fasthttp *router.Router.
+func RegisterAdmin(router *router.Router, middleware middlewares.RequestHandlerBridge) {
+	router.GET(pathAdminUserDevices, middleware(
+		adminAuthorization(schema.AdminScopeDevicesRead, adminActionDevicesList, AdminUserDevicesGet)))
+	router.DELETE(pathAdminUserDevicesTOTP, middleware(
+		adminAuthorization(schema.AdminScopeDevicesWrite, adminActionTOTPDelete, AdminUserTOTPDelete)))
+	router.DELETE(pathAdminUserDevicesU2F, middleware(
+		adminAuthorization(schema.AdminScopeDevicesWrite, adminActionU2FDelete, AdminUserU2FDelete)))
+	router.DELETE(pathAdminUserDevicesDuo, middleware(
+		adminAuthorization(schema.AdminScopeDevicesWrite, adminActionDuoDelete, AdminUserDuoDelete)))
+
+	router.DELETE(pathAdminUserBans, middleware(
+		adminAuthorization(schema.AdminScopeRegulationWrite, adminActionBansDelete, AdminUserBansDelete)))
+
+	router.GET(pathAdminUserSessions, middleware(
+		adminAuthorization(schema.AdminScopeSessionsRead, adminActionSessionsList, AdminUserSessionsGet)))
+	router.DELETE(pathAdminUserSessions, middleware(
+		adminAuthorization(schema.AdminScopeSessionsWrite, adminActionSessionsDelete, AdminUserSessionsDelete)))
+	router.DELETE(pathAdminUserSession, middleware(
+		adminAuthorization(schema.AdminScopeSessionsWrite, adminActionSessionDelete, AdminUserSessionDelete)))
+
+	router.GET(pathAdminUserLogs, middleware(
+		adminAuthorization(schema.AdminScopeLogsRead, adminActionLogsList, AdminUserLogsGet)))
+}
+
+// adminAuthorization only runs the next handler for requests with the bearer token of an administrative API token which
+// is granted the scope. The action is written to the audit log along with whether it was authorized and succeeded, it's
+// the only audit event of the request and the handler adds the details of what it did with adminAuditDetail.
+func adminAuthorization(scope, action string, next middlewares.RequestHandler) middlewares.RequestHandler {
+	return func(ctx *middlewares.AutheliaCtx) {
+		event := audit.Event{
+			Type:    audit.EventAdminAction,
+			Outcome: audit.OutcomeDenied,
+			Target:  audit.Target{Type: audit.TargetUser, ID: adminUsername(ctx)},
+			Details: map[string]string{audit.DetailAction: action},
+		}
+
+		ctx.SetUserValue(adminAuditDetailsUserValue, event.Details)
+
+		defer func() {
+			ctx.Audit(event)
+		}()
+
+		token, err := parseBearerAuth(headerAuthorization, string(ctx.Request.Header.PeekBytes(headerAuthorization)))
+		if err != nil {
+			ctx.Logger.Debugf("Administrative API request is not authorized: %v", err)
+			adminReplyUnauthorized(ctx)
+
+			return
+		}
+
+		admin, found := adminToken(ctx.Configuration.Server.Admin, token)
+		if !found {
+			ctx.Logger.Warn("Administrative API request is not authorized: the bearer token does not match any token")
+			adminReplyUnauthorized(ctx)
+
+			return
+		}
+
+		ctx.Logger = ctx.Logger.WithField("admin_token", admin.Name)
+		ctx.SetUserValue(adminTokenUserValue, admin.Name)
+
+		event.Actor = audit.Actor{Type: audit.ActorAdminToken, ID: admin.Name}
+
+		if !utils.IsStringInSlice(scope, admin.Scopes) {
+			ctx.Logger.Warnf("Administrative API request is forbidden: token '%s' is not granted the '%s' scope", admin.Name, scope)
+			ctx.ReplyForbidden()
+
+			return
+		}
+
+		next(ctx)
+
+		event.Outcome = audit.OutcomeSuccess
+
+		if ctx.Response.StatusCode() >= fasthttp.StatusBadRequest {
+			event.Outcome = audit.OutcomeFailure
+		}
+	}
+}
+
+// adminAuditDetail adds a detail to the audit event of the administrative API request.
+func adminAuditDetail(ctx *middlewares.AutheliaCtx, key, value string) {
+	if details, ok := ctx.UserValue(adminAuditDetailsUserValue).(map[string]string); ok {
+		details[key] = value
+	}
+}
+
+// parseBearerAuth parses an HTTP Bearer Authentication string.
+// "Bearer abc123" returns ("abc123", nil).
+func parseBearerAuth(header []byte, auth string) (token string, err error) {
+	if !strings.HasPrefix(auth, bearerPrefix) {
+		return "", fmt.Errorf("%s prefix not found in %s header", strings.Trim(bearerPrefix, " "), header)
+	}
+
+	token = strings.Trim(auth[len(bearerPrefix):], " ")
+
+	if token == "" {
+		return "", fmt.Errorf("token in %s header must not be empty", header)
+	}
+
+	return token, nil
+}
+
+// adminToken returns the administrative API token matching the bearer token. Every token is compared in constant time
+// so the time taken doesn't reveal which token matched.
+func adminToken(config *schema.ServerAdminConfiguration, bearer string) (token schema.ServerAdminTokenConfiguration, found bool) {
+	if config == nil {
+		return token, false
+	}
+
+	for _, t := range config.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token), []byte(bearer)) == 1 {
+			token, found = t, true
+		}
+	}
+
+	return token, found
+}
+
+// adminReplyUnauthorized replies unauthorized with the authentication challenge, it must be set after the reply as the
+// reply resets the headers.
+func adminReplyUnauthorized(ctx *middlewares.AutheliaCtx) {
+	ctx.ReplyUnauthorized()
+	ctx.Response.Header.Set(fasthttp.HeaderWWWAuthenticate, adminWWWAuthenticate)
+}
@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"github.com/authelia/authelia/v4/internal/audit"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+)
+
+// auditUserEvent writes the audit event of a user acting on their own account. The outcome is a failure when the error
+// isn't nil.
+func auditUserEvent(ctx *middlewares.AutheliaCtx, eventType, username string, err error, details map[string]string) {
+	outcome := audit.OutcomeSuccess
+
+	if err != nil {
+		outcome = audit.OutcomeFailure
+	}
+
+	ctx.Audit(audit.Event{
+		Type:    eventType,
+		Outcome: outcome,
+		Actor:   audit.Actor{ID: username},
+		Target:  audit.Target{Type: audit.TargetUser, ID: username},
+		Details: details,
+	})
+}
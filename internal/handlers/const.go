@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/valyala/fasthttp"
 )
 
@@ -26,6 +28,17 @@ var (
 	headerRemoteEmail     = []byte("Remote-Email")
 )
 
+const (
+	// deviceCookieName is the name of the cookie identifying a device the user has logged in from.
+	deviceCookieName = "authelia_device"
+
+	// deviceCookieExpiration is the duration after which a device is considered new if the user doesn't log in again.
+	deviceCookieExpiration = 365 * 24 * time.Hour
+
+	// deviceIDLength is the length of the random ID of a device in the device cookie.
+	deviceIDLength = 32
+)
+
 const (
 	// Forbidden means the user is forbidden the access to a resource.
 	Forbidden authorizationMatching = iota
@@ -33,6 +46,8 @@ const (
 	NotAuthorized authorizationMatching = iota
 	// Authorized means the user is authorized given her current permissions.
 	Authorized authorizationMatching = iota
+	// ReauthenticationRequired means the user has the required authentication level but completed it too long ago.
+	ReauthenticationRequired authorizationMatching = iota
 )
 
 const (
@@ -42,17 +57,22 @@ const (
 	messageUnableToRegisterSecurityKey     = "Unable to register your security key."
 	messageUnableToResetPassword           = "Unable to reset your password."
 	messageMFAValidationFailed             = "Authentication failed, please retry later."
+	messageSessionNotFound                 = "Session not found."
+	messageDeviceNotFound                  = "Device not found."
+	messageInvalidQueryParameter           = "Invalid query parameter."
 )
 
 const (
-	logFmtErrParseRequestBody     = "Failed to parse %s request body: %+v"
-	logFmtErrWriteResponseBody    = "Failed to write %s response body for user '%s': %+v"
-	logFmtErrRegulationFail       = "Failed to perform %s authentication regulation for user '%s': %+v"
-	logFmtErrSessionRegenerate    = "Could not regenerate session during %s authentication for user '%s': %+v"
-	logFmtErrSessionReset         = "Could not reset session during %s authentication for user '%s': %+v"
-	logFmtErrSessionSave          = "Could not save session with the %s during %s authentication for user '%s': %+v"
-	logFmtErrObtainProfileDetails = "Could not obtain profile details during %s authentication for user '%s': %+v"
-	logFmtTraceProfileDetails     = "Profile details for user '%s' => groups: %s, emails %s"
+	logFmtErrParseRequestBody        = "Failed to parse %s request body: %+v"
+	logFmtErrWriteResponseBody       = "Failed to write %s response body for user '%s': %+v"
+	logFmtErrRegulationFail          = "Failed to perform %s authentication regulation for user '%s': %+v"
+	logFmtErrSessionRegenerate       = "Could not regenerate session during %s authentication for user '%s': %+v"
+	logFmtErrSessionReset            = "Could not reset session during %s authentication for user '%s': %+v"
+	logFmtErrSessionSave             = "Could not save session with the %s during %s authentication for user '%s': %+v"
+	logFmtErrSessionRecord           = "Could not record session in the inventory during %s authentication for user '%s': %+v"
+	logFmtErrObtainProfileDetails    = "Could not obtain profile details during %s authentication for user '%s': %+v"
+	logFmtErrObtainSessionGeneration = "Could not obtain session generation during %s authentication for user '%s': %+v"
+	logFmtTraceProfileDetails        = "Profile details for user '%s' => groups: %s, emails %s"
 )
 
 const (
@@ -90,6 +110,39 @@ const (
 	pathOpenIDConnectConsent = "/api/oidc/consent"
 )
 
+// Administrative API constants.
+const (
+	pathAdminUserDevices     = "/api/admin/users/{username}/devices"
+	pathAdminUserDevicesTOTP = "/api/admin/users/{username}/devices/totp"
+	pathAdminUserDevicesU2F  = "/api/admin/users/{username}/devices/u2f"
+	pathAdminUserDevicesDuo  = "/api/admin/users/{username}/devices/duo"
+	pathAdminUserBans        = "/api/admin/users/{username}/bans"
+	pathAdminUserSessions    = "/api/admin/users/{username}/sessions"
+	pathAdminUserSession     = "/api/admin/users/{username}/sessions/{id}"
+	pathAdminUserLogs        = "/api/admin/users/{username}/logs"
+
+	adminWWWAuthenticate = `Bearer realm="Authelia Administrative API"`
+
+	adminTokenUserValue        = "authelia_admin_token"
+	adminAuditDetailsUserValue = "authelia_admin_audit_details"
+
+	adminLogsDefaultLimit = 50
+	adminLogsMaximumLimit = 500
+)
+
+// Administrative API actions recorded in the audit log.
+const (
+	adminActionDevicesList    = "devices.list"
+	adminActionTOTPDelete     = "devices.totp.delete"
+	adminActionU2FDelete      = "devices.u2f.delete"
+	adminActionDuoDelete      = "devices.duo.delete"
+	adminActionBansDelete     = "bans.delete"
+	adminActionSessionsList   = "sessions.list"
+	adminActionSessionsDelete = "sessions.delete"
+	adminActionSessionDelete  = "session.delete"
+	adminActionLogsList       = "logs.list"
+)
+
 const (
 	accept = "accept"
 	reject = "reject"
@@ -97,6 +150,8 @@ const (
 
 const authPrefix = "Basic "
 
+const bearerPrefix = "Bearer "
+
 const ldapPasswordComplexityCode = "0000052D."
 
 var ldapPasswordComplexityCodes = []string{
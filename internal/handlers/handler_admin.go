@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/audit"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// AdminUserDevicesGet lists the second factor devices of a user.
+func AdminUserDevicesGet(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	var body adminUserDevicesResponse
+
+	config, err := ctx.Providers.StorageProvider.LoadTOTPConfiguration(ctx, username)
+
+	switch {
+	case err == nil:
+		body.TOTP = &adminTOTPDevice{Issuer: config.Issuer, Algorithm: config.Algorithm, Digits: config.Digits, Period: config.Period}
+	case !errors.Is(err, storage.ErrNoTOTPConfiguration):
+		adminError(ctx, fmt.Errorf("unable to load TOTP configuration of user '%s': %w", username, err))
+		return
+	}
+
+	device, err := ctx.Providers.StorageProvider.LoadU2FDevice(ctx, username)
+
+	switch {
+	case err == nil:
+		body.U2F = &adminU2FDevice{Description: device.Description}
+	case !errors.Is(err, storage.ErrNoU2FDeviceHandle):
+		adminError(ctx, fmt.Errorf("unable to load U2F device of user '%s': %w", username, err))
+		return
+	}
+
+	duoDevice, err := ctx.Providers.StorageProvider.LoadPreferredDuoDevice(ctx, username)
+
+	switch {
+	case err == nil:
+		body.Duo = &adminDuoDevice{Device: duoDevice.Device, Method: duoDevice.Method}
+	case !errors.Is(err, storage.ErrNoDuoDevice):
+		adminError(ctx, fmt.Errorf("unable to load Duo device of user '%s': %w", username, err))
+		return
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Logger.Errorf("Unable to set admin user devices response in body: %s", err)
+	}
+}
+
+// AdminUserTOTPDelete deletes the TOTP configuration of a user.
+func AdminUserTOTPDelete(ctx *middlewares.AutheliaCtx) {
+	adminDeviceDelete(ctx, regulation.AuthTypeTOTP, storage.ErrNoTOTPConfiguration,
+		func(c context.Context, username string) (err error) {
+			_, err = ctx.Providers.StorageProvider.LoadTOTPConfiguration(c, username)
+			return err
+		}, ctx.Providers.StorageProvider.DeleteTOTPConfiguration)
+}
+
+// AdminUserU2FDelete deletes the U2F device of a user.
+func AdminUserU2FDelete(ctx *middlewares.AutheliaCtx) {
+	adminDeviceDelete(ctx, regulation.AuthTypeU2F, storage.ErrNoU2FDeviceHandle,
+		func(c context.Context, username string) (err error) {
+			_, err = ctx.Providers.StorageProvider.LoadU2FDevice(c, username)
+			return err
+		}, ctx.Providers.StorageProvider.DeleteU2FDevice)
+}
+
+// AdminUserDuoDelete deletes the preferred Duo device of a user.
+func AdminUserDuoDelete(ctx *middlewares.AutheliaCtx) {
+	adminDeviceDelete(ctx, regulation.AuthTypeDuo, storage.ErrNoDuoDevice,
+		func(c context.Context, username string) (err error) {
+			_, err = ctx.Providers.StorageProvider.LoadPreferredDuoDevice(c, username)
+			return err
+		}, ctx.Providers.StorageProvider.DeletePreferredDuoDevice)
+}
+
+// adminDeviceDelete deletes a second factor device of a user. Like the storage commands, removing a second factor
+// device invalidates all sessions of the user and notifies the user.
+func adminDeviceDelete(ctx *middlewares.AutheliaCtx, device string, errNotFound error, load, remove func(ctx context.Context, username string) error) {
+	username := adminUsername(ctx)
+
+	adminAuditDetail(ctx, audit.DetailMethod, device)
+
+	if err := load(ctx, username); err != nil {
+		if errors.Is(err, errNotFound) {
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			ctx.SetJSONError(messageDeviceNotFound)
+
+			return
+		}
+
+		adminError(ctx, fmt.Errorf("unable to load %s device of user '%s': %w", device, username, err))
+
+		return
+	}
+
+	if err := remove(ctx, username); err != nil {
+		adminError(ctx, fmt.Errorf("unable to delete %s device of user '%s': %w", device, username, err))
+		return
+	}
+
+	if err := ctx.Providers.StorageProvider.IncrementSessionGeneration(ctx, username); err != nil {
+		adminError(ctx, fmt.Errorf("unable to invalidate sessions of user '%s': %w", username, err))
+		return
+	}
+
+	ctx.Logger.Infof("%s device of user '%s' was deleted", device, username)
+
+	notifySecurityEvent(ctx, notification.SecurityEventDeviceRemoved, username, device)
+
+	ctx.ReplyOK()
+}
+
+// AdminUserBansDelete revokes the active regulation bans of a user including their TOTP and identity verification bans.
+func AdminUserBansDelete(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	var count int64
+
+	for _, banType := range []string{regulation.BanTypeUser, regulation.BanTypeTOTP, regulation.BanTypeIdentityVerificationUser} {
+		revoked, err := ctx.Providers.Regulator.Unban(ctx, banType, username)
+		if err != nil {
+			adminError(ctx, fmt.Errorf("unable to revoke %s bans of user '%s': %w", banType, username, err))
+			return
+		}
+
+		count += revoked
+	}
+
+	ctx.Logger.Infof("Revoked %d active bans of user '%s'", count, username)
+
+	adminAuditDetail(ctx, audit.DetailRevoked, strconv.FormatInt(count, 10))
+
+	if err := ctx.SetJSONBody(adminRevokedResponse{Revoked: count}); err != nil {
+		ctx.Logger.Errorf("Unable to set admin user bans response in body: %s", err)
+	}
+}
+
+// AdminUserSessionsGet lists the active sessions of a user.
+func AdminUserSessionsGet(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	sessions, err := ctx.Providers.SessionProvider.ListSessions(username)
+	if err != nil {
+		adminError(ctx, fmt.Errorf("unable to list sessions of user '%s': %w", username, err))
+		return
+	}
+
+	body := make([]userSessionResponse, len(sessions))
+
+	for i, s := range sessions {
+		body[i] = newUserSessionResponse(s)
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Logger.Errorf("Unable to set admin user sessions response in body: %s", err)
+	}
+}
+
+// AdminUserSessionsDelete revokes all sessions of a user.
+func AdminUserSessionsDelete(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	// Incrementing the session generation invalidates all sessions of the user regardless of the session provider.
+	if err := ctx.Providers.StorageProvider.IncrementSessionGeneration(ctx, username); err != nil {
+		adminError(ctx, fmt.Errorf("unable to revoke sessions of user '%s': %w", username, err))
+		return
+	}
+
+	count, err := ctx.Providers.SessionProvider.RevokeSessions(username)
+	if err != nil {
+		adminError(ctx, fmt.Errorf("unable to destroy sessions of user '%s': %w", username, err))
+		return
+	}
+
+	ctx.Logger.Infof("Revoked all sessions of user '%s'", username)
+
+	adminAuditDetail(ctx, audit.DetailRevoked, strconv.Itoa(count))
+
+	if err = ctx.SetJSONBody(adminRevokedResponse{Revoked: int64(count)}); err != nil {
+		ctx.Logger.Errorf("Unable to set admin user sessions response in body: %s", err)
+	}
+}
+
+// AdminUserSessionDelete revokes one of the sessions of a user.
+func AdminUserSessionDelete(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	id, _ := ctx.UserValue("id").(string)
+
+	adminAuditDetail(ctx, audit.DetailSessionID, id)
+
+	if err := ctx.Providers.SessionProvider.RevokeSession(username, id); err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			ctx.SetJSONError(messageSessionNotFound)
+
+			return
+		}
+
+		adminError(ctx, fmt.Errorf("unable to revoke session '%s' of user '%s': %w", id, username, err))
+
+		return
+	}
+
+	ctx.Logger.Infof("Session '%s' of user '%s' was revoked", id, username)
+	ctx.ReplyOK()
+}
+
+// AdminUserLogsGet lists the authentication logs of a user, most recent first.
+func AdminUserLogsGet(ctx *middlewares.AutheliaCtx) {
+	username := adminUsername(ctx)
+
+	args := ctx.QueryArgs()
+
+	limit, page, since := adminLogsDefaultLimit, 0, time.Time{}
+
+	var err error
+
+	if args.Has("limit") {
+		if limit, err = args.GetUint("limit"); err != nil || limit < 1 || limit > adminLogsMaximumLimit {
+			adminReplyBadRequest(ctx, fmt.Errorf("limit must be between 1 and %d", adminLogsMaximumLimit))
+			return
+		}
+	}
+
+	if args.Has("page") {
+		if page, err = args.GetUint("page"); err != nil {
+			adminReplyBadRequest(ctx, fmt.Errorf("page must be 0 or greater: %w", err))
+			return
+		}
+	}
+
+	if args.Has("since") {
+		if since, err = time.Parse(time.RFC3339, string(args.Peek("since"))); err != nil {
+			adminReplyBadRequest(ctx, fmt.Errorf("since must be a RFC3339 time: %w", err))
+			return
+		}
+	}
+
+	attempts, err := ctx.Providers.StorageProvider.LoadAuthenticationLogsHistory(ctx, username, since, limit, page)
+	if err != nil && !errors.Is(err, storage.ErrNoAuthenticationLogs) {
+		adminError(ctx, fmt.Errorf("unable to load authentication logs of user '%s': %w", username, err))
+		return
+	}
+
+	body := make([]adminAuthenticationLog, len(attempts))
+
+	for i, attempt := range attempts {
+		body[i] = adminAuthenticationLog{
+			Time:          attempt.Time,
+			Successful:    attempt.Successful,
+			Banned:        attempt.Banned,
+			Type:          attempt.Type,
+			Country:       attempt.Country,
+			ASN:           attempt.ASN,
+			RequestURI:    attempt.RequestURI,
+			RequestMethod: attempt.RequestMethod,
+		}
+
+		if attempt.RemoteIP.IP != nil {
+			body[i].RemoteIP = attempt.RemoteIP.IP.String()
+		}
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Logger.Errorf("Unable to set admin user logs response in body: %s", err)
+	}
+}
+
+func adminUsername(ctx *middlewares.AutheliaCtx) (username string) {
+	username, _ = ctx.UserValue("username").(string)
+
+	return username
+}
+
+func adminError(ctx *middlewares.AutheliaCtx, err error) {
+	ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+	ctx.Error(err, messageOperationFailed)
+}
+
+func adminReplyBadRequest(ctx *middlewares.AutheliaCtx, err error) {
+	ctx.SetStatusCode(fasthttp.StatusBadRequest)
+	ctx.ReplyError(err, messageInvalidQueryParameter)
+}
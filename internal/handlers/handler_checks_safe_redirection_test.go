@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/session"
 )
@@ -63,3 +64,33 @@ func TestCheckSafeRedirection_SafeRedirection(t *testing.T) {
 		OK: true,
 	})
 }
+
+func TestCheckSafeRedirection_ShouldUseCookieDomainOfRequest(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtxWithUserSession(t, session.UserSession{
+		Username:            "john",
+		AuthenticationLevel: authentication.OneFactor,
+	})
+	defer mock.Close()
+	mock.Ctx.Configuration.Session.Domain = exampleDotComDomain
+	mock.Ctx.Configuration.Session.Cookies = []schema.SessionCookieConfiguration{{Domain: "example.org"}}
+
+	mock.Ctx.Request.Header.Set("X-Forwarded-Host", "auth.example.org")
+	mock.SetRequestBody(t, checkURIWithinDomainRequestBody{
+		URI: "https://myapp.example.org",
+	})
+
+	CheckSafeRedirection(mock.Ctx)
+	mock.Assert200OK(t, checkURIWithinDomainResponseBody{
+		OK: true,
+	})
+
+	mock.Ctx.Response.Reset()
+	mock.SetRequestBody(t, checkURIWithinDomainRequestBody{
+		URI: "https://myapp.example.com",
+	})
+
+	CheckSafeRedirection(mock.Ctx)
+	mock.Assert200OK(t, checkURIWithinDomainResponseBody{
+		OK: false,
+	})
+}
@@ -7,7 +7,9 @@ import (
 	"sync"
 	"time"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
 )
@@ -79,8 +81,19 @@ func FirstFactorPost(msInitialDelay time.Duration, delayEnabled bool) middleware
 			return
 		}
 
-		if bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, bodyJSON.Username); err != nil {
-			if errors.Is(err, regulation.ErrUserIsBanned) {
+		regulate := ctx.Providers.Regulator.Regulate
+
+		// The users who are already logged in aren't locked out by the global limit when they log in again.
+		if userSession := ctx.GetSession(); userSession.AuthenticationLevel >= authentication.OneFactor && userSession.Username == bodyJSON.Username {
+			regulate = ctx.Providers.Regulator.RegulateAuthenticated
+		}
+
+		if bannedUntil, err := regulate(ctx, bodyJSON.Username, ctx.RemoteIP()); err != nil {
+			if regulation.IsBanned(err) {
+				if !errors.Is(err, regulation.ErrUserIsBanned) {
+					ctx.Logger.Warnf("Rejected %s authentication attempt by user '%s' from %s: %v", regulation.AuthType1FA, bodyJSON.Username, ctx.RemoteIP(), err)
+				}
+
 				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, bodyJSON.Username, regulation.AuthType1FA, nil)
 
 				respondUnauthorized(ctx, messageAuthenticationFailed)
@@ -112,6 +125,8 @@ func FirstFactorPost(msInitialDelay time.Duration, delayEnabled bool) middleware
 			return
 		}
 
+		newLogin := isNewLogin(ctx, bodyJSON.Username)
+
 		if err = markAuthenticationAttempt(ctx, true, nil, bodyJSON.Username, regulation.AuthType1FA, nil); err != nil {
 			respondUnauthorized(ctx, messageAuthenticationFailed)
 
@@ -166,7 +181,17 @@ func FirstFactorPost(msInitialDelay time.Duration, delayEnabled bool) middleware
 
 		ctx.Logger.Tracef(logFmtTraceProfileDetails, bodyJSON.Username, userDetails.Groups, userDetails.Emails)
 
+		generation, err := ctx.Providers.StorageProvider.LoadSessionGeneration(ctx, userDetails.Username)
+		if err != nil {
+			ctx.Logger.Errorf(logFmtErrObtainSessionGeneration, regulation.AuthType1FA, bodyJSON.Username, err)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
 		userSession.SetOneFactor(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
+		userSession.Generation = generation
 
 		if refresh, refreshInterval := getProfileRefreshSettings(ctx.Configuration.AuthenticationBackend); refresh {
 			userSession.RefreshTTL = ctx.Clock.Now().Add(refreshInterval)
@@ -180,12 +205,22 @@ func FirstFactorPost(msInitialDelay time.Duration, delayEnabled bool) middleware
 			return
 		}
 
+		if err = ctx.Providers.SessionProvider.RecordSession(ctx.RequestCtx, userSession.Username, ctx.RemoteIP()); err != nil {
+			ctx.Logger.Errorf(logFmtErrSessionRecord, regulation.AuthType1FA, bodyJSON.Username, err)
+		}
+
 		successful = true
 
+		setDeviceCookie(ctx, userSession.Username)
+
+		if newLogin {
+			notifySecurityEvent(ctx, notification.SecurityEventNewLogin, userSession.Username, "")
+		}
+
 		if userSession.OIDCWorkflowSession != nil {
 			handleOIDCWorkflowResponse(ctx)
 		} else {
-			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups, userSession.Emails)
 		}
 	}
 }
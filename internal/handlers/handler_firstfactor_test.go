@@ -26,6 +26,7 @@ type FirstFactorSuite struct {
 
 func (s *FirstFactorSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.mock.StorageMock.EXPECT().LoadSessionGeneration(s.mock.Ctx, gomock.Any()).Return(0, nil).AnyTimes()
 }
 
 func (s *FirstFactorSuite) TearDownTest() {
@@ -261,6 +262,77 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
 	assert.Equal(s.T(), []string{"dev", "admins"}, session.Groups)
 }
 
+func (s *FirstFactorSuite) setGlobalBan() {
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(&schema.RegulationConfiguration{
+		Global: &schema.RegulationLimitConfiguration{MaxRetries: 100, FindTime: "1m", BanTime: "5m"},
+	}, s.mock.StorageMock, nil, &s.mock.Clock)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadRegulationBans(s.mock.Ctx, gomock.Eq(regulation.BanTypeGlobal), gomock.Eq(""), gomock.Any()).
+		Return([]models.RegulationBan{{
+			Time:    s.mock.Clock.Now().Add(-time.Minute),
+			Expires: s.mock.Clock.Now().Add(4 * time.Minute),
+			Type:    regulation.BanTypeGlobal,
+		}}, nil).
+		AnyTimes()
+}
+
+func (s *FirstFactorSuite) TestShouldRejectLoginWhenGlobalLimitIsReached() {
+	s.setGlobalBan()
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPost(0, false)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), "Authentication failed. Check your credentials.")
+}
+
+func (s *FirstFactorSuite) TestShouldNotApplyGlobalLimitToLoggedInUser() {
+	s.setGlobalBan()
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.Username = "test"
+	userSession.AuthenticationLevel = authentication.OneFactor
+	s.Require().NoError(s.mock.Ctx.SaveSession(userSession))
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username: "test",
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPost(0, false)(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+}
+
 func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSession() {
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -313,6 +385,7 @@ type FirstFactorRedirectionSuite struct {
 
 func (s *FirstFactorRedirectionSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.mock.StorageMock.EXPECT().LoadSessionGeneration(s.mock.Ctx, gomock.Any()).Return(0, nil).AnyTimes()
 	s.mock.Ctx.Configuration.DefaultRedirectionURL = "https://default.local"
 	s.mock.Ctx.Configuration.AccessControl.DefaultPolicy = "bypass"
 	s.mock.Ctx.Configuration.AccessControl.Rules = []schema.ACLRule{
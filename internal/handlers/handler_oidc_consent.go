@@ -3,7 +3,9 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 )
 
@@ -90,7 +92,17 @@ func oidcConsentPOST(ctx *middlewares.AutheliaCtx) {
 
 	var redirectionURL string
 
+	event := audit.Event{
+		Type:    audit.EventOpenIDConnectConsent,
+		Outcome: audit.OutcomeDenied,
+		Actor:   audit.Actor{ID: userSession.Username},
+		Target:  audit.Target{Type: audit.TargetClient, ID: body.ClientID},
+		Details: map[string]string{audit.DetailScopes: strings.Join(userSession.OIDCWorkflowSession.RequestedScopes, " ")},
+	}
+
 	if body.AcceptOrReject == accept {
+		event.Outcome = audit.OutcomeSuccess
+
 		redirectionURL = userSession.OIDCWorkflowSession.AuthURI
 		userSession.OIDCWorkflowSession.GrantedScopes = userSession.OIDCWorkflowSession.RequestedScopes
 		userSession.OIDCWorkflowSession.GrantedAudience = userSession.OIDCWorkflowSession.RequestedAudience
@@ -110,6 +122,8 @@ func oidcConsentPOST(ctx *middlewares.AutheliaCtx) {
 		}
 	}
 
+	ctx.Audit(event)
+
 	response := ConsentPostResponseBody{RedirectURI: redirectionURL}
 
 	if err := ctx.SetJSONBody(response); err != nil {
@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/ory/fosite"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 )
 
@@ -14,6 +16,7 @@ func oidcToken(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.R
 	accessRequest, err := ctx.Providers.OpenIDConnect.Fosite.NewAccessRequest(ctx, req, oidcSession)
 	if err != nil {
 		ctx.Logger.Errorf("Error occurred in NewAccessRequest: %+v", err)
+		ctx.Audit(newTokenAuditEvent(accessRequest, err))
 		ctx.Providers.OpenIDConnect.Fosite.WriteAccessError(rw, accessRequest, err)
 
 		return
@@ -29,6 +32,9 @@ func oidcToken(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.R
 	}
 
 	response, err := ctx.Providers.OpenIDConnect.Fosite.NewAccessResponse(ctx, accessRequest)
+
+	ctx.Audit(newTokenAuditEvent(accessRequest, err))
+
 	if err != nil {
 		ctx.Logger.Errorf("Error occurred in NewAccessResponse: %+v", err)
 		ctx.Providers.OpenIDConnect.Fosite.WriteAccessError(rw, accessRequest, err)
@@ -38,3 +44,40 @@ func oidcToken(ctx *middlewares.AutheliaCtx, rw http.ResponseWriter, req *http.R
 
 	ctx.Providers.OpenIDConnect.Fosite.WriteAccessResponse(rw, accessRequest, response)
 }
+
+// newTokenAuditEvent returns the audit event of the issuance of the tokens of an access request. The client is the
+// actor and the user the tokens are issued for is the target, the client is also the target when there's no user such
+// as with the client credentials grant.
+func newTokenAuditEvent(accessRequest fosite.AccessRequester, err error) (event audit.Event) {
+	event = audit.Event{
+		Type:    audit.EventOpenIDConnectToken,
+		Outcome: audit.OutcomeSuccess,
+		Actor:   audit.Actor{Type: audit.ActorClient},
+	}
+
+	if err != nil {
+		event.Outcome = audit.OutcomeFailure
+		event.Reason = fosite.ErrorToRFC6749Error(err).ErrorField
+	}
+
+	if accessRequest == nil {
+		return event
+	}
+
+	if client := accessRequest.GetClient(); client != nil {
+		event.Actor.ID = client.GetID()
+	}
+
+	event.Target = audit.Target{Type: audit.TargetClient, ID: event.Actor.ID}
+
+	if session := accessRequest.GetSession(); session != nil && session.GetSubject() != "" {
+		event.Target = audit.Target{Type: audit.TargetUser, ID: session.GetSubject()}
+	}
+
+	event.Details = map[string]string{
+		audit.DetailGrantType: strings.Join(accessRequest.GetGrantTypes(), " "),
+		audit.DetailScopes:    strings.Join(accessRequest.GetGrantedScopes(), " "),
+	}
+
+	return event
+}
@@ -5,9 +5,11 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/duo"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -97,6 +99,8 @@ func SecondFactorDuoDevicePost(ctx *middlewares.AutheliaCtx) {
 	ctx.Logger.Debugf("Save new preferred Duo device and method of user %s to %s using %s", userSession.Username, device.Device, device.Method)
 	err = ctx.Providers.StorageProvider.SavePreferredDuoDevice(ctx, models.DuoDevice{Username: userSession.Username, Device: device.Device, Method: device.Method})
 
+	auditUserEvent(ctx, audit.EventSecondFactorRegistered, userSession.Username, err, map[string]string{audit.DetailMethod: regulation.AuthTypeDuo})
+
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to save new preferred Duo device and method: %s", err), messageMFAValidationFailed)
 		return
@@ -111,6 +115,8 @@ func SecondFactorDuoDeviceDelete(ctx *middlewares.AutheliaCtx) {
 	ctx.Logger.Debugf("Deleting preferred Duo device and method of user %s", userSession.Username)
 	err := ctx.Providers.StorageProvider.DeletePreferredDuoDevice(ctx, userSession.Username)
 
+	auditUserEvent(ctx, audit.EventSecondFactorRemoved, userSession.Username, err, map[string]string{audit.DetailMethod: regulation.AuthTypeDuo})
+
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to delete preferred Duo device and method: %s", err), messageMFAValidationFailed)
 		return
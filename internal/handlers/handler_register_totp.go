@@ -3,8 +3,11 @@ package handlers
 import (
 	"fmt"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
@@ -17,8 +20,10 @@ func identityRetrieverFromSession(ctx *middlewares.AutheliaCtx) (*session.Identi
 	}
 
 	return &session.Identity{
-		Username: userSession.Username,
-		Email:    userSession.Emails[0],
+		Username:    userSession.Username,
+		DisplayName: userSession.DisplayName,
+		Email:       userSession.Emails[0],
+		Locale:      userSession.Locale,
 	}, nil
 }
 
@@ -46,6 +51,9 @@ func secondFactorTOTPIdentityFinish(ctx *middlewares.AutheliaCtx, username strin
 	}
 
 	err = ctx.Providers.StorageProvider.SaveTOTPConfiguration(ctx, *config)
+
+	auditUserEvent(ctx, audit.EventSecondFactorRegistered, username, err, map[string]string{audit.DetailMethod: regulation.AuthTypeTOTP})
+
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to save TOTP secret in DB: %s", err), messageUnableToRegisterOneTimePassword)
 		return
@@ -60,6 +68,8 @@ func secondFactorTOTPIdentityFinish(ctx *middlewares.AutheliaCtx, username strin
 	if err != nil {
 		ctx.Logger.Errorf("Unable to set TOTP key response in body: %s", err)
 	}
+
+	notifySecurityEvent(ctx, notification.SecurityEventDeviceRegistered, username, regulation.AuthTypeTOTP)
 }
 
 // SecondFactorTOTPIdentityFinish the handler for finishing the identity validation.
@@ -6,8 +6,11 @@ import (
 
 	"github.com/tstranex/u2f"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
 )
 
 // SecondFactorU2FRegister handler validating the client has successfully validated the challenge
@@ -54,10 +57,14 @@ func SecondFactorU2FRegister(ctx *middlewares.AutheliaCtx) {
 		PublicKey:   publicKey},
 	)
 
+	auditUserEvent(ctx, audit.EventSecondFactorRegistered, userSession.Username, err, map[string]string{audit.DetailMethod: regulation.AuthTypeU2F})
+
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to register U2F device for user %s: %v", userSession.Username, err), messageUnableToRegisterSecurityKey)
 		return
 	}
 
+	notifySecurityEvent(ctx, notification.SecurityEventDeviceRegistered, userSession.Username, regulation.AuthTypeU2F)
+
 	ctx.ReplyOK()
 }
@@ -4,20 +4,35 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
-func identityRetrieverFromStorage(ctx *middlewares.AutheliaCtx) (*session.Identity, error) {
+func identityRetrieverFromStorage(ctx *middlewares.AutheliaCtx) (identity *session.Identity, err error) {
 	var requestBody resetPasswordStep1RequestBody
-	err := json.Unmarshal(ctx.PostBody(), &requestBody)
+	err = json.Unmarshal(ctx.PostBody(), &requestBody)
 
 	if err != nil {
 		return nil, err
 	}
 
-	details, err := ctx.Providers.UserProvider.GetDetails(requestBody.Username)
+	// The password reset is requested by an anonymous user for the user of the request.
+	defer func() {
+		outcome := audit.OutcomeSuccess
+
+		if err != nil {
+			outcome = audit.OutcomeFailure
+		}
 
+		ctx.Audit(audit.Event{
+			Type:    audit.EventPasswordResetRequested,
+			Outcome: outcome,
+			Target:  audit.Target{Type: audit.TargetUser, ID: requestBody.Username},
+		})
+	}()
+
+	details, err := ctx.Providers.UserProvider.GetDetails(requestBody.Username)
 	if err != nil {
 		return nil, err
 	}
@@ -27,8 +42,10 @@ func identityRetrieverFromStorage(ctx *middlewares.AutheliaCtx) (*session.Identi
 	}
 
 	return &session.Identity{
-		Username: requestBody.Username,
-		Email:    details.Emails[0],
+		Username:    requestBody.Username,
+		DisplayName: details.DisplayName,
+		Email:       details.Emails[0],
+		Locale:      details.Locale,
 	}, nil
 }
 
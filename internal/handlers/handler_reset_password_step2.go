@@ -3,7 +3,9 @@ package handlers
 import (
 	"fmt"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -29,6 +31,8 @@ func ResetPasswordPost(ctx *middlewares.AutheliaCtx) {
 
 	err = ctx.Providers.UserProvider.UpdatePassword(*userSession.PasswordResetUsername, requestBody.Password)
 
+	auditUserEvent(ctx, audit.EventPasswordResetCompleted, *userSession.PasswordResetUsername, err, nil)
+
 	if err != nil {
 		switch {
 		case utils.IsStringInSliceContains(err.Error(), ldapPasswordComplexityCodes),
@@ -43,6 +47,14 @@ func ResetPasswordPost(ctx *middlewares.AutheliaCtx) {
 
 	ctx.Logger.Debugf("Password of user %s has been reset", *userSession.PasswordResetUsername)
 
+	// Invalidate all of the existing sessions of the user. The password has already been changed at this point, so a
+	// failure is only logged rather than reporting the reset as failed.
+	if err = ctx.Providers.StorageProvider.IncrementSessionGeneration(ctx, *userSession.PasswordResetUsername); err != nil {
+		ctx.Logger.Errorf("Unable to invalidate the sessions of user %s after the password was reset: %+v", *userSession.PasswordResetUsername, err)
+	}
+
+	notifySecurityEvent(ctx, notification.SecurityEventPasswordChanged, *userSession.PasswordResetUsername, "")
+
 	// Reset the request.
 	userSession.PasswordResetUsername = nil
 	err = ctx.SaveSession(userSession)
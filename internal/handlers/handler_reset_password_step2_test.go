@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/mocks"
+)
+
+func TestShouldResetPasswordWhenSessionsCannotBeInvalidated(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	defer mock.Close()
+
+	username := testUsername
+
+	userSession := mock.Ctx.GetSession()
+	userSession.PasswordResetUsername = &username
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	mock.Ctx.Request.SetBodyString(`{"password":"new-password"}`)
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().UpdatePassword(testUsername, "new-password").Return(nil),
+		mock.StorageMock.EXPECT().IncrementSessionGeneration(mock.Ctx, testUsername).Return(errors.New("failed")),
+	)
+
+	ResetPasswordPost(mock.Ctx)
+
+	mock.Assert200OK(t, nil)
+	assert.Nil(t, mock.Ctx.GetSession().PasswordResetUsername)
+
+	entry := mock.Hook.LastEntry()
+	require.NotNil(t, entry)
+	assert.Equal(t, logrus.ErrorLevel, entry.Level)
+	assert.Equal(t, "Unable to invalidate the sessions of user john after the password was reset: failed", entry.Message)
+}
@@ -19,6 +19,18 @@ func SecondFactorTOTPPost(ctx *middlewares.AutheliaCtx) {
 
 	userSession := ctx.GetSession()
 
+	if bannedUntil, err := ctx.Providers.Regulator.RegulateTOTP(ctx, userSession.Username); err != nil {
+		if regulation.IsBanned(err) {
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeTOTP, nil)
+		} else {
+			ctx.Logger.Errorf(logFmtErrRegulationFail, regulation.AuthTypeTOTP, userSession.Username, err)
+		}
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
 	config, err := ctx.Providers.StorageProvider.LoadTOTPConfiguration(ctx, userSession.Username)
 	if err != nil {
 		ctx.Logger.Errorf("Failed to load TOTP configuration: %+v", err)
@@ -4,12 +4,14 @@ import (
 	"encoding/json"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/tstranex/u2f"
 
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/models"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -36,6 +38,39 @@ func (s *HandlerSignTOTPSuite) TearDownTest() {
 	s.mock.Close()
 }
 
+func (s *HandlerSignTOTPSuite) TestShouldNotValidateTOTPWhenUserIsBannedFromTOTP() {
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(&schema.RegulationConfiguration{
+		TOTP: &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "2m", BanTime: "10m"},
+	}, s.mock.StorageMock, nil, &s.mock.Clock)
+
+	bannedUntil := s.mock.Clock.Now().Add(5 * time.Minute)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationBans(s.mock.Ctx, gomock.Eq(regulation.BanTypeTOTP), gomock.Eq("john"), gomock.Any()).
+			Return([]models.RegulationBan{{Time: s.mock.Clock.Now(), Expires: bannedUntil, Type: regulation.BanTypeTOTP, Value: "john"}}, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(models.AuthenticationAttempt{
+				Username:   "john",
+				Successful: false,
+				Banned:     true,
+				Time:       s.mock.Clock.Now(),
+				Type:       regulation.AuthTypeTOTP,
+				RemoteIP:   models.NewNullIPFromString("0.0.0.0"),
+			})),
+	)
+
+	bodyBytes, err := json.Marshal(signTOTPRequestBody{
+		Token: "abc",
+	})
+	s.Require().NoError(err)
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+
+	SecondFactorTOTPPost(s.mock.Ctx)
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+}
+
 func (s *HandlerSignTOTPSuite) TestShouldRedirectUserToDefaultURL() {
 	config := models.TOTPConfiguration{ID: 1, Username: "john", Digits: 6, Secret: []byte("secret"), Period: 30, Algorithm: "SHA1"}
 
@@ -1,7 +1,14 @@
 package handlers
 
 import (
+	"net/url"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
 )
 
 // StateGet is the handler serving the user state.
@@ -13,8 +20,51 @@ func StateGet(ctx *middlewares.AutheliaCtx) {
 		DefaultRedirectionURL: ctx.Configuration.DefaultRedirectionURL,
 	}
 
+	// The level is only lowered in the response so the portal prompts for the second factor again for this target,
+	// while the session remains valid for the other resources.
+	if isSecondFactorReauthenticationRequired(ctx, userSession) {
+		stateResponse.AuthenticationLevel = authentication.OneFactor
+	}
+
 	err := ctx.SetJSONBody(stateResponse)
 	if err != nil {
 		ctx.Logger.Errorf("Unable to set state response in body: %s", err)
 	}
 }
+
+// isSecondFactorReauthenticationRequired checks whether the second factor of the session was completed too long ago
+// for the rule of the URL the portal redirects the user to, which is passed with its method in the rd and rm query
+// arguments.
+func isSecondFactorReauthenticationRequired(ctx *middlewares.AutheliaCtx, userSession session.UserSession) bool {
+	redirectionURL := ctx.QueryArgs().Peek("rd")
+
+	if userSession.AuthenticationLevel < authentication.TwoFactor || len(redirectionURL) == 0 {
+		return false
+	}
+
+	targetURL, err := url.ParseRequestURI(string(redirectionURL))
+	if err != nil {
+		return false
+	}
+
+	method := ctx.QueryArgs().Peek("rm")
+	if len(method) == 0 {
+		method = []byte(fasthttp.MethodGet)
+	}
+
+	location := ctx.RemoteLocation()
+
+	subject := authorization.Subject{
+		Username: userSession.Username,
+		Groups:   userSession.Groups,
+		Emails:   userSession.Emails,
+		IP:       ctx.RemoteIP(),
+		Country:  location.Country,
+		ASN:      location.ASN,
+	}
+
+	_, matching := isTargetURLAuthorized(ctx.Providers.Authorizer, *targetURL, subject, method,
+		userSession.AuthenticationLevel, getSecondFactorAge(ctx, false))
+
+	return matching == ReauthenticationRequired
+}
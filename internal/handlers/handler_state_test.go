@@ -3,12 +3,15 @@ package handlers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
 )
 
@@ -90,3 +93,53 @@ func TestRunStateGetSuite(t *testing.T) {
 	s := new(StateGetSuite)
 	suite.Run(t, s)
 }
+
+func TestShouldReturnOneFactorStateWhenSecondFactorIsTooOldForTarget(t *testing.T) {
+	testCases := []struct {
+		name            string
+		redirectionURL  string
+		secondFactorAge time.Duration
+		expectedLevel   authentication.Level
+	}{
+		{"ShouldReturnSessionLevelWithoutTarget", "", 15 * time.Minute, authentication.TwoFactor},
+		{"ShouldReturnSessionLevelWhenSecondFactorIsRecent", "https://admin.example.com/", 5 * time.Minute, authentication.TwoFactor},
+		{"ShouldReturnOneFactorWhenSecondFactorIsTooOld", "https://admin.example.com/", 15 * time.Minute, authentication.OneFactor},
+		{"ShouldReturnSessionLevelForTargetWithoutMaxAge", "https://app.example.com/", 15 * time.Minute, authentication.TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.ACLRule{
+				{Domains: []string{"admin.example.com"}, Policy: "two_factor", MaxAuthenticationAge: "10m"},
+				{Domains: []string{"app.example.com"}, Policy: "two_factor"},
+			}
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration)
+
+			userSession := mock.Ctx.GetSession()
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-tc.secondFactorAge).Unix()
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			if tc.redirectionURL != "" {
+				mock.Ctx.QueryArgs().Add("rd", tc.redirectionURL)
+			}
+
+			StateGet(mock.Ctx)
+
+			actualBody := struct {
+				Status string
+				Data   StateResponse
+			}{}
+
+			require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), &actualBody))
+			assert.Equal(t, tc.expectedLevel, actualBody.Data.AuthenticationLevel)
+			assert.Equal(t, authentication.TwoFactor, mock.Ctx.GetSession().AuthenticationLevel)
+		})
+	}
+}
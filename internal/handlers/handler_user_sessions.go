@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// UserSessionsGet lists the active sessions of the user identified by the session.
+func UserSessionsGet(ctx *middlewares.AutheliaCtx) {
+	userSession := ctx.GetSession()
+
+	sessions, err := ctx.Providers.SessionProvider.ListSessions(userSession.Username)
+	if err != nil {
+		ctx.Error(fmt.Errorf("unable to list sessions of user '%s': %w", userSession.Username, err), messageOperationFailed)
+		return
+	}
+
+	body := make([]userSessionResponse, len(sessions))
+
+	for i, s := range sessions {
+		body[i] = newUserSessionResponse(s)
+		body[i].Current = ctx.Providers.SessionProvider.IsCurrentSession(ctx.RequestCtx, s)
+	}
+
+	if err = ctx.SetJSONBody(body); err != nil {
+		ctx.Logger.Errorf("Unable to set user sessions response in body: %s", err)
+	}
+}
+
+// UserSessionDelete revokes one of the sessions of the user identified by the session.
+func UserSessionDelete(ctx *middlewares.AutheliaCtx) {
+	userSession := ctx.GetSession()
+
+	id, _ := ctx.UserValue("id").(string)
+
+	sessions, err := ctx.Providers.SessionProvider.ListSessions(userSession.Username)
+	if err != nil {
+		ctx.Error(fmt.Errorf("unable to list sessions of user '%s': %w", userSession.Username, err), messageOperationFailed)
+		return
+	}
+
+	for _, s := range sessions {
+		if s.ID != id || !ctx.Providers.SessionProvider.IsCurrentSession(ctx.RequestCtx, s) {
+			continue
+		}
+
+		// Revoking the current session is the same as logging out.
+		if err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx); err != nil {
+			ctx.Error(fmt.Errorf("unable to revoke session '%s' of user '%s': %w", id, userSession.Username, err), messageOperationFailed)
+			return
+		}
+
+		ctx.Logger.Infof("Session '%s' of user '%s' was revoked", id, userSession.Username)
+		ctx.ReplyOK()
+
+		return
+	}
+
+	if err = ctx.Providers.SessionProvider.RevokeSession(userSession.Username, id); err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			ctx.SetJSONError(messageSessionNotFound)
+
+			return
+		}
+
+		ctx.Error(fmt.Errorf("unable to revoke session '%s' of user '%s': %w", id, userSession.Username, err), messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Infof("Session '%s' of user '%s' was revoked", id, userSession.Username)
+	ctx.ReplyOK()
+}
+
+func newUserSessionResponse(s session.ActiveSession) userSessionResponse {
+	return userSessionResponse{
+		ID:                s.ID,
+		RemoteIP:          s.RemoteIP,
+		UserAgent:         s.UserAgent,
+		Device:            s.Device,
+		Created:           s.Created,
+		LastActivity:      timeOrNil(s.LastActivity),
+		FirstFactorAuthn:  timeOrNil(s.FirstFactorAuthn),
+		SecondFactorAuthn: timeOrNil(s.SecondFactorAuthn),
+		KeepMeLoggedIn:    s.KeepMeLoggedIn,
+	}
+}
+
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
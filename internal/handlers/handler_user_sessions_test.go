@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+)
+
+type UserSessionsSuite struct {
+	suite.Suite
+
+	mock *mocks.MockAutheliaCtx
+}
+
+func (s *UserSessionsSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.mock.Ctx.Request.Header.SetUserAgent("Mozilla/5.0 (X11; Linux x86_64; rv:95.0) Gecko/20100101 Firefox/95.0")
+
+	userSession := s.mock.Ctx.GetSession()
+	userSession.SetOneFactor(s.mock.Clock.Now(), &authentication.UserDetails{Username: testUsername}, false)
+
+	require.NoError(s.T(), s.mock.Ctx.SaveSession(userSession))
+	require.NoError(s.T(), s.mock.Ctx.Providers.SessionProvider.RecordSession(s.mock.Ctx.RequestCtx, testUsername, net.ParseIP("192.168.0.10")))
+}
+
+func (s *UserSessionsSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *UserSessionsSuite) newOtherSession() *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+
+	provider := s.mock.Ctx.Providers.SessionProvider
+
+	userSession, err := provider.GetSession(ctx)
+	s.Require().NoError(err)
+
+	userSession.SetOneFactor(s.mock.Clock.Now(), &authentication.UserDetails{Username: testUsername}, false)
+
+	s.Require().NoError(provider.SaveSession(ctx, userSession))
+	s.Require().NoError(provider.RecordSession(ctx, testUsername, net.ParseIP("10.0.0.5")))
+
+	return ctx
+}
+
+func (s *UserSessionsSuite) TestShouldListSessions() {
+	s.newOtherSession()
+
+	UserSessionsGet(s.mock.Ctx)
+
+	var body []userSessionResponse
+
+	s.mock.GetResponseData(s.T(), &body)
+
+	s.Require().Len(body, 2)
+
+	s.Assert().Equal("192.168.0.10", body[0].RemoteIP)
+	s.Assert().Equal("Firefox on Linux", body[0].Device)
+	s.Assert().True(body[0].Current)
+	s.Assert().Nil(body[0].SecondFactorAuthn)
+	s.Require().NotNil(body[0].FirstFactorAuthn)
+	s.Assert().Equal(s.mock.Clock.Now().Unix(), body[0].FirstFactorAuthn.Unix())
+
+	s.Assert().Equal("10.0.0.5", body[1].RemoteIP)
+	s.Assert().False(body[1].Current)
+}
+
+func (s *UserSessionsSuite) TestShouldRevokeOtherSession() {
+	other := s.newOtherSession()
+
+	sessions, err := s.mock.Ctx.Providers.SessionProvider.ListSessions(testUsername)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 2)
+
+	s.mock.Ctx.SetUserValue("id", sessions[1].ID)
+
+	UserSessionDelete(s.mock.Ctx)
+
+	s.Assert().Equal(fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+
+	userSession, err := s.mock.Ctx.Providers.SessionProvider.GetSession(other)
+	s.Require().NoError(err)
+	s.Assert().Equal("", userSession.Username)
+
+	s.Assert().Equal(testUsername, s.mock.Ctx.GetSession().Username)
+}
+
+func (s *UserSessionsSuite) TestShouldRevokeCurrentSession() {
+	sessions, err := s.mock.Ctx.Providers.SessionProvider.ListSessions(testUsername)
+	s.Require().NoError(err)
+	s.Require().Len(sessions, 1)
+
+	s.mock.Ctx.SetUserValue("id", sessions[0].ID)
+
+	UserSessionDelete(s.mock.Ctx)
+
+	s.Assert().Equal(fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	s.Assert().Equal("", s.mock.Ctx.GetSession().Username)
+
+	sessions, err = s.mock.Ctx.Providers.SessionProvider.ListSessions(testUsername)
+	s.Require().NoError(err)
+	s.Assert().Len(sessions, 0)
+}
+
+func (s *UserSessionsSuite) TestShouldReturnNotFoundForUnknownSession() {
+	s.mock.Ctx.SetUserValue("id", "5f9c4b3e-2a1d-4c6e-8b7f-0e1d2c3b4a59")
+
+	UserSessionDelete(s.mock.Ctx)
+
+	s.Assert().Equal(fasthttp.StatusNotFound, s.mock.Ctx.Response.StatusCode())
+	s.Assert().Equal(messageSessionNotFound, s.mock.GetResponseError(s.T()).Message)
+}
+
+func TestRunUserSessionsSuite(t *testing.T) {
+	s := new(UserSessionsSuite)
+	suite.Run(t, s)
+}
+
+func TestShouldReturnNilForZeroTime(t *testing.T) {
+	assert.Nil(t, timeOrNil(time.Time{}))
+	assert.NotNil(t, timeOrNil(time.Unix(1625048140, 0)))
+}
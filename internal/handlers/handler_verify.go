@@ -4,16 +4,17 @@ import (
 	"bytes"
 	"encoding/base64"
 	"fmt"
-	"net"
 	"net/url"
 	"strings"
 	"time"
 
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -53,34 +54,32 @@ func parseBasicAuth(header []byte, auth string) (username, password string, err
 	return cs[:s], cs[s+1:], nil
 }
 
-// isTargetURLAuthorized check whether the given user is authorized to access the resource.
+// isTargetURLAuthorized check whether the given subject is authorized to access the resource and returns the level
+// which applies to it. The secondFactorAge is the time elapsed since the user last completed the second factor.
 func isTargetURLAuthorized(authorizer *authorization.Authorizer, targetURL url.URL,
-	username string, userGroups []string, clientIP net.IP, method []byte, authLevel authentication.Level) authorizationMatching {
-	level := authorizer.GetRequiredLevel(
-		authorization.Subject{
-			Username: username,
-			Groups:   userGroups,
-			IP:       clientIP,
-		},
-		authorization.NewObjectRaw(&targetURL, method))
+	subject authorization.Subject, method []byte, authLevel authentication.Level, secondFactorAge time.Duration) (authorization.Level, authorizationMatching) {
+	level, maxAuthenticationAge := authorizer.GetRequirements(subject, authorization.NewObjectRaw(&targetURL, method))
 
 	switch {
 	case level == authorization.Bypass:
-		return Authorized
-	case level == authorization.Denied && username != "":
+		return level, Authorized
+	case level == authorization.Denied && subject.Username != "":
 		// If the user is not anonymous, it means that we went through
 		// all the rules related to that user and knowing who he is we can
 		// deduce the access is forbidden
 		// For anonymous users though, we cannot be sure that she
 		// could not be granted the rights to access the resource. Consequently
 		// for anonymous users we send Unauthorized instead of Forbidden
-		return Forbidden
+		return level, Forbidden
+	case level == authorization.TwoFactor && authLevel >= authentication.TwoFactor &&
+		maxAuthenticationAge != 0 && secondFactorAge > maxAuthenticationAge:
+		return level, ReauthenticationRequired
 	case level == authorization.OneFactor && authLevel >= authentication.OneFactor,
 		level == authorization.TwoFactor && authLevel >= authentication.TwoFactor:
-		return Authorized
+		return level, Authorized
 	}
 
-	return NotAuthorized
+	return level, NotAuthorized
 }
 
 // verifyBasicAuth verify that the provided username and password are correct and
@@ -93,7 +92,6 @@ func verifyBasicAuth(ctx *middlewares.AutheliaCtx, header, auth []byte) (usernam
 	}
 
 	authenticated, err := ctx.Providers.UserProvider.CheckUserPassword(username, password)
-
 	if err != nil {
 		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to check credentials extracted from %s header: %w", header, err)
 	}
@@ -105,7 +103,6 @@ func verifyBasicAuth(ctx *middlewares.AutheliaCtx, header, auth []byte) (usernam
 	}
 
 	details, err := ctx.Providers.UserProvider.GetDetails(username)
-
 	if err != nil {
 		return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to retrieve details of user %s: %s", username, err)
 	}
@@ -175,6 +172,23 @@ func verifySessionCookie(ctx *middlewares.AutheliaCtx, targetURL *url.URL, userS
 		}
 	}
 
+	// The session generation is checked on every request so the invalidated sessions are rejected immediately.
+	if !isUserAnonymous {
+		generation, err := ctx.Providers.StorageProvider.LoadSessionGeneration(ctx, userSession.Username)
+		if err != nil {
+			return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to check the session generation of user %s: %w", userSession.Username, err)
+		}
+
+		if userSession.Generation < generation {
+			// Destroy the session a new one will be regenerated on next request.
+			if err = ctx.Providers.SessionProvider.DestroySession(ctx.RequestCtx); err != nil {
+				return "", "", nil, nil, authentication.NotAuthenticated, fmt.Errorf("unable to destroy user session after it was invalidated: %s", err)
+			}
+
+			return userSession.Username, userSession.DisplayName, userSession.Groups, userSession.Emails, authentication.NotAuthenticated, fmt.Errorf("User %s has a session which has been invalidated", userSession.Username)
+		}
+	}
+
 	err = verifySessionHasUpToDateProfile(ctx, targetURL, userSession, refreshProfile, refreshProfileInterval)
 	if err != nil {
 		if err == authentication.ErrUserNotFound {
@@ -219,8 +233,12 @@ func handleUnauthorized(ctx *middlewares.AutheliaCtx, targetURL fmt.Stringer, is
 
 	// Kubernetes ingress controller and Traefik use the rd parameter of the verify
 	// endpoint to provide the URL of the login portal. The target URL of the user
-	// is computed from X-Forwarded-* headers or X-Original-URL.
+	// is computed from X-Forwarded-* headers or X-Original-URL. When the parameter
+	// is absent the portal URL of the session cookie of the target is used.
 	rd := string(ctx.QueryArgs().Peek("rd"))
+	if rd == "" {
+		rd = ctx.GetSessionCookie().AutheliaURL
+	}
 	rm := string(method)
 
 	switch rm {
@@ -277,6 +295,17 @@ func updateActivityTimestamp(ctx *middlewares.AutheliaCtx, isBasicAuth bool, use
 	return ctx.SaveSession(userSession)
 }
 
+// getSecondFactorAge returns the time elapsed since the user of the session last completed the second factor.
+func getSecondFactorAge(ctx *middlewares.AutheliaCtx, isBasicAuth bool) time.Duration {
+	if isBasicAuth {
+		return 0
+	}
+
+	userSession := ctx.GetSession()
+
+	return ctx.Clock.Now().Sub(time.Unix(userSession.SecondFactorAuthnTimestamp, 0))
+}
+
 // generateVerifySessionHasUpToDateProfileTraceLogs is used to generate trace logs only when trace logging is enabled.
 // The information calculated in this function is completely useless other than trace for now.
 func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaCtx, userSession *session.UserSession,
@@ -349,8 +378,9 @@ func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *ur
 	emailsDiff := utils.IsStringSlicesDifferent(userSession.Emails, details.Emails)
 	groupsDiff := utils.IsStringSlicesDifferent(userSession.Groups, details.Groups)
 	nameDiff := userSession.DisplayName != details.DisplayName
+	localeDiff := userSession.Locale != details.Locale
 
-	if !groupsDiff && !emailsDiff && !nameDiff {
+	if !groupsDiff && !emailsDiff && !nameDiff && !localeDiff {
 		ctx.Logger.Tracef("Updated profile not detected for %s.", userSession.Username)
 		// Only update TTL if the user has an interval set.
 		// We get to this check when there were no changes.
@@ -369,6 +399,7 @@ func verifySessionHasUpToDateProfile(ctx *middlewares.AutheliaCtx, targetURL *ur
 		userSession.Emails = details.Emails
 		userSession.Groups = details.Groups
 		userSession.DisplayName = details.DisplayName
+		userSession.Locale = details.Locale
 
 		// Only update TTL if the user has a interval set.
 		if refreshProfileInterval != schema.RefreshIntervalAlways {
@@ -463,9 +494,13 @@ func VerifyGet(cfg schema.AuthenticationBackendConfiguration) middlewares.Reques
 			return
 		}
 
-		if !isURLUnderProtectedDomain(targetURL, ctx.Configuration.Session.Domain) {
+		// The session cookie is the cookie of the domain of the target URL, as the proxies which send the X-Original-URL
+		// header don't send the X-Forwarded-Host header.
+		session.SetRequestHost(ctx.RequestCtx, targetURL.Hostname())
+
+		if domain := ctx.GetSessionCookie().Domain; !isURLUnderProtectedDomain(targetURL, domain) {
 			ctx.Logger.Errorf("Target URL %s is not under the protected domain %s",
-				targetURL.String(), ctx.Configuration.Session.Domain)
+				targetURL.String(), domain)
 			ctx.ReplyUnauthorized()
 
 			return
@@ -487,16 +522,49 @@ func VerifyGet(cfg schema.AuthenticationBackendConfiguration) middlewares.Reques
 			return
 		}
 
-		authorized := isTargetURLAuthorized(ctx.Providers.Authorizer, *targetURL, username,
-			groups, ctx.RemoteIP(), method, authLevel)
+		location := ctx.RemoteLocation()
+
+		subject := authorization.Subject{
+			Username: username,
+			Groups:   groups,
+			Emails:   emails,
+			IP:       ctx.RemoteIP(),
+			Country:  location.Country,
+			ASN:      location.ASN,
+		}
+
+		level, authorized := isTargetURLAuthorized(ctx.Providers.Authorizer, *targetURL, subject, method, authLevel, getSecondFactorAge(ctx, isBasicAuth))
+
+		policy := authorization.LevelToPolicy(level)
 
 		switch authorized {
 		case Forbidden:
+			metrics.RecordVerify(policy, metrics.VerifyOutcomeForbidden)
+
 			ctx.Logger.Infof("Access to %s is forbidden to user %s", targetURL.String(), username)
+			ctx.Audit(audit.Event{
+				Type:    audit.EventAuthorizationDenied,
+				Outcome: audit.OutcomeDenied,
+				Actor:   audit.Actor{ID: username},
+				Target:  audit.Target{Type: audit.TargetResource, ID: targetURL.String()},
+				Details: map[string]string{audit.DetailPolicy: policy, audit.DetailHTTPMethod: string(method)},
+			})
 			ctx.ReplyForbidden()
 		case NotAuthorized:
+			metrics.RecordVerify(policy, metrics.VerifyOutcomeUnauthorized)
+
+			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method)
+		case ReauthenticationRequired:
+			metrics.RecordVerify(policy, metrics.VerifyOutcomeReauthenticationRequired)
+
+			// The authentication level of the session is left untouched so the other resources remain accessible, the
+			// portal prompts for the second factor again as it reports the level required by this resource.
+			ctx.Logger.Infof("Access to %s requires user %s to perform the second factor again as it was completed too long ago", targetURL.String(), username)
+
 			handleUnauthorized(ctx, targetURL, isBasicAuth, username, method)
 		case Authorized:
+			metrics.RecordVerify(policy, metrics.VerifyOutcomeAuthorized)
+
 			setForwardedHeaders(&ctx.Response.Header, username, name, groups, emails)
 		}
 
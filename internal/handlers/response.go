@@ -7,8 +7,11 @@ import (
 
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/audit"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -50,7 +53,7 @@ func handleOIDCWorkflowResponse(ctx *middlewares.AutheliaCtx) {
 }
 
 // Handle1FAResponse handle the redirection upon 1FA authentication.
-func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, username string, groups []string) {
+func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod string, username string, groups, emails []string) {
 	if targetURI == "" {
 		if !ctx.Providers.Authorizer.IsSecondFactorEnabled() && ctx.Configuration.DefaultRedirectionURL != "" {
 			err := ctx.SetJSONBody(redirectResponse{Redirect: ctx.Configuration.DefaultRedirectionURL})
@@ -70,11 +73,16 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 		return
 	}
 
+	location := ctx.RemoteLocation()
+
 	requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
 			Username: username,
 			Groups:   groups,
+			Emails:   emails,
 			IP:       ctx.RemoteIP(),
+			Country:  location.Country,
+			ASN:      location.ASN,
 		},
 		authorization.NewObject(targetURL, requestMethod))
 
@@ -87,7 +95,7 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 		return
 	}
 
-	safeRedirection := utils.IsRedirectionSafe(*targetURL, ctx.Configuration.Session.Domain)
+	safeRedirection := utils.IsRedirectionSafe(*targetURL, ctx.GetSessionCookie().Domain)
 
 	if !safeRedirection {
 		ctx.Logger.Debugf("Redirection URL %s is not safe", targetURI)
@@ -127,7 +135,7 @@ func Handle2FAResponse(ctx *middlewares.AutheliaCtx, targetURI string) {
 		return
 	}
 
-	safe, err := utils.IsRedirectionURISafe(targetURI, ctx.Configuration.Session.Domain)
+	safe, err := utils.IsRedirectionURISafe(targetURI, ctx.GetSessionCookie().Domain)
 
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to check target URL: %s", err), messageMFAValidationFailed)
@@ -163,6 +171,14 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 		}
 	}
 
+	if authType == regulation.AuthType1FA {
+		metrics.RecordAuthenticationFirstFactor(successful, bannedUntil != nil)
+	} else {
+		metrics.RecordAuthenticationSecondFactor(authType, successful, bannedUntil != nil)
+	}
+
+	ctx.Audit(newAuthenticationAuditEvent(successful, bannedUntil, username, authType))
+
 	if err = ctx.Providers.Regulator.Mark(ctx, successful, bannedUntil != nil, username, requestURI, requestMethod, authType, ctx.RemoteIP()); err != nil {
 		ctx.Logger.Errorf("Unable to mark %s authentication attempt by user '%s': %+v", authType, username, err)
 
@@ -185,6 +201,30 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 	return nil
 }
 
+// newAuthenticationAuditEvent returns the audit event of an authentication attempt.
+func newAuthenticationAuditEvent(successful bool, bannedUntil *time.Time, username string, authType string) (event audit.Event) {
+	event = audit.Event{
+		Type:    audit.EventAuthenticationFirstFactor,
+		Outcome: audit.OutcomeFailure,
+		Actor:   audit.Actor{ID: username},
+		Target:  audit.Target{Type: audit.TargetUser, ID: username},
+	}
+
+	if authType != regulation.AuthType1FA {
+		event.Type = audit.EventAuthenticationSecondFactor
+		event.Details = map[string]string{audit.DetailMethod: authType}
+	}
+
+	switch {
+	case successful:
+		event.Outcome = audit.OutcomeSuccess
+	case bannedUntil != nil:
+		event.Reason = "banned"
+	}
+
+	return event
+}
+
 func respondUnauthorized(ctx *middlewares.AutheliaCtx, message string) {
 	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
 	ctx.SetJSONError(message)
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// notifySecurityEvent notifies the user of a security event caused by the request. The notification is sent in the
+// background and failing to notify the user is only logged as it must not affect the outcome of the request.
+func notifySecurityEvent(ctx *middlewares.AutheliaCtx, eventType, username, device string) {
+	if ctx.Providers.SecurityEvents == nil {
+		return
+	}
+
+	ctx.Providers.SecurityEvents.NotifyAsync(notification.SecurityEvent{
+		Type:        eventType,
+		Username:    username,
+		Device:      device,
+		RemoteIP:    ctx.RemoteIP(),
+		UserAgent:   string(ctx.UserAgent()),
+		Time:        ctx.Clock.Now(),
+		Preferences: []string{string(ctx.Request.Header.Peek(fasthttp.HeaderAcceptLanguage))},
+	})
+}
+
+// isNewLogin returns true if the user has not successfully logged in from the remote IP within the new login lookback
+// or if the request doesn't have a valid device cookie for the user. It must be called before the current attempt is
+// marked as successful.
+func isNewLogin(ctx *middlewares.AutheliaCtx, username string) bool {
+	if ctx.Providers.SecurityEvents == nil || !ctx.Providers.SecurityEvents.Enabled(notification.SecurityEventNewLogin) {
+		return false
+	}
+
+	if _, ok := deviceCookieID(ctx, username); !ok {
+		return true
+	}
+
+	fromDate := ctx.Clock.Now().Add(-ctx.Providers.SecurityEvents.NewLoginLookback())
+
+	count, err := ctx.Providers.StorageProvider.CountSuccessfulAuthenticationLogsByRemoteIP(ctx, username, regulation.AuthType1FA, ctx.RemoteIP(), fromDate)
+	if err != nil {
+		ctx.Logger.Errorf("Unable to determine if user '%s' has logged in from %s before: %+v", username, ctx.RemoteIP(), err)
+
+		return false
+	}
+
+	return count == 0
+}
+
+// setDeviceCookie sets the cookie which identifies the device as one the user has logged in from.
+func setDeviceCookie(ctx *middlewares.AutheliaCtx, username string) {
+	if ctx.Providers.SecurityEvents == nil || !ctx.Providers.SecurityEvents.Enabled(notification.SecurityEventNewLogin) {
+		return
+	}
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	// The device keeps its ID when the user logs in again, and gets a new one otherwise.
+	deviceID, ok := deviceCookieID(ctx, username)
+	if !ok {
+		deviceID = utils.RandomString(deviceIDLength, utils.AlphaNumericCharacters, true)
+	}
+
+	cookie.SetKey(deviceCookieName)
+	cookie.SetValue(deviceCookieValue(ctx, username, deviceID))
+	cookie.SetDomain(ctx.GetSessionCookie().Domain)
+	cookie.SetPath("/")
+	cookie.SetExpire(ctx.Clock.Now().Add(deviceCookieExpiration))
+	cookie.SetHTTPOnly(true)
+	cookie.SetSecure(true)
+	cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+
+	ctx.Response.Header.SetCookie(cookie)
+}
+
+// deviceCookieValue returns the value of the device cookie which identifies the device of the user with the given ID.
+// The ID is signed along with the username so the cookie can't be forged without the JWT secret, and the cookie of a
+// device doesn't identify the other devices of the user.
+func deviceCookieValue(ctx *middlewares.AutheliaCtx, username, deviceID string) string {
+	return deviceID + "." + base64.RawURLEncoding.EncodeToString(deviceCookieMAC(ctx, username, deviceID))
+}
+
+// deviceCookieID returns the device ID of the device cookie of the request and true if the cookie is a valid device
+// cookie of the user.
+func deviceCookieID(ctx *middlewares.AutheliaCtx, username string) (deviceID string, ok bool) {
+	value := string(ctx.Request.Header.Cookie(deviceCookieName))
+
+	i := strings.LastIndexByte(value, '.')
+	if i <= 0 {
+		return "", false
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(value[i+1:])
+	if err != nil {
+		return "", false
+	}
+
+	deviceID = value[:i]
+
+	return deviceID, hmac.Equal(mac, deviceCookieMAC(ctx, username, deviceID))
+}
+
+func deviceCookieMAC(ctx *middlewares.AutheliaCtx, username, deviceID string) []byte {
+	mac := hmac.New(sha256.New, []byte(ctx.Configuration.JWTSecret))
+	mac.Write([]byte(deviceCookieName + "." + username + "." + deviceID))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+func newSecurityEventsMockAutheliaCtx(t *testing.T, configuration schema.SecurityEventsNotifierConfiguration) *mocks.MockAutheliaCtx {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	if configuration.NewLoginLookback == "" {
+		configuration.NewLoginLookback = "90d"
+	}
+
+	mock.Ctx.Clock = &mock.Clock
+	mock.Ctx.Configuration.JWTSecret = "abc"
+	mock.Ctx.Providers.SecurityEvents = notification.NewSecurityEventNotifier(
+		&schema.NotifierConfiguration{SecurityEvents: configuration},
+		mock.NotifierMock, mock.Ctx.Providers.Templates, mock.UserProviderMock)
+
+	return mock
+}
+
+func TestShouldNotifyUserOfSecurityEvent(t *testing.T) {
+	mock := newSecurityEventsMockAutheliaCtx(t, schema.SecurityEventsNotifierConfiguration{})
+	defer mock.Close()
+
+	mock.Ctx.Request.Header.Set(fasthttp.HeaderUserAgent, "Mozilla/5.0")
+
+	gomock.InOrder(
+		mock.UserProviderMock.EXPECT().
+			GetDetails(gomock.Eq("john")).
+			Return(&authentication.UserDetails{Username: "john", Emails: []string{"john@example.com"}}, nil),
+		mock.NotifierMock.EXPECT().
+			Send(gomock.Eq("john@example.com"), gomock.Eq("Your password has been changed"), gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+
+	notifySecurityEvent(mock.Ctx, notification.SecurityEventPasswordChanged, "john", "")
+	mock.Ctx.Providers.SecurityEvents.Wait()
+}
+
+func TestShouldOnlyLogSecurityEventNotificationErrors(t *testing.T) {
+	mock := newSecurityEventsMockAutheliaCtx(t, schema.SecurityEventsNotifierConfiguration{})
+	defer mock.Close()
+
+	mock.UserProviderMock.EXPECT().
+		GetDetails(gomock.Eq("john")).
+		Return(&authentication.UserDetails{Username: "john"}, nil)
+
+	// The notification is sent in the background so the error is logged by the notifier.
+	hook := test.NewLocal(logging.Logger())
+
+	notifySecurityEvent(mock.Ctx, notification.SecurityEventDeviceRegistered, "john", regulation.AuthTypeTOTP)
+	mock.Ctx.Providers.SecurityEvents.Wait()
+
+	require.NotNil(t, hook.LastEntry())
+	assert.Equal(t, "Unable to notify user 'john' of the device_registered security event: user 'john' has no email address configured", hook.LastEntry().Message)
+}
+
+func TestShouldDetectNewLoginFromDeviceCookieAndRemoteIP(t *testing.T) {
+	mock := newSecurityEventsMockAutheliaCtx(t, schema.SecurityEventsNotifierConfiguration{})
+	defer mock.Close()
+
+	// The device is new as it doesn't have a device cookie yet.
+	assert.True(t, isNewLogin(mock.Ctx, "john"))
+
+	setDeviceCookie(mock.Ctx, "john")
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(deviceCookieName)
+	require.True(t, mock.Ctx.Response.Header.Cookie(cookie))
+
+	assert.True(t, cookie.HTTPOnly())
+	assert.True(t, cookie.Secure())
+	assert.Equal(t, mock.Clock.Now().Add(deviceCookieExpiration).Unix(), cookie.Expire().Unix())
+
+	mock.Ctx.Request.Header.SetCookieBytesKV([]byte(deviceCookieName), cookie.Value())
+
+	fromDate := mock.Clock.Now().Add(-90 * 24 * time.Hour)
+
+	gomock.InOrder(
+		mock.StorageMock.EXPECT().
+			CountSuccessfulAuthenticationLogsByRemoteIP(mock.Ctx, gomock.Eq("john"), gomock.Eq(regulation.AuthType1FA), gomock.Eq(net.ParseIP("0.0.0.0")), gomock.Eq(fromDate)).
+			Return(2, nil),
+		mock.StorageMock.EXPECT().
+			CountSuccessfulAuthenticationLogsByRemoteIP(mock.Ctx, gomock.Eq("john"), gomock.Eq(regulation.AuthType1FA), gomock.Eq(net.ParseIP("0.0.0.0")), gomock.Eq(fromDate)).
+			Return(0, nil),
+	)
+
+	assert.False(t, isNewLogin(mock.Ctx, "john"))
+	assert.True(t, isNewLogin(mock.Ctx, "john"))
+
+	// The device cookie of one user doesn't identify a known device of another user.
+	assert.True(t, isNewLogin(mock.Ctx, "harry"))
+
+	// The device keeps its ID when the user logs in again from it.
+	deviceID, ok := deviceCookieID(mock.Ctx, "john")
+	require.True(t, ok)
+
+	mock.Ctx.Response.Header.DelAllCookies()
+	setDeviceCookie(mock.Ctx, "john")
+
+	require.True(t, mock.Ctx.Response.Header.Cookie(cookie))
+	assert.Equal(t, deviceCookieValue(mock.Ctx, "john", deviceID), string(cookie.Value()))
+}
+
+func TestShouldGiveEachDeviceItsOwnDeviceCookie(t *testing.T) {
+	mock := newSecurityEventsMockAutheliaCtx(t, schema.SecurityEventsNotifierConfiguration{})
+	defer mock.Close()
+
+	values := make([]string, 2)
+
+	for i := range values {
+		mock.Ctx.Response.Header.DelAllCookies()
+		setDeviceCookie(mock.Ctx, "john")
+
+		values[i] = string(mock.Ctx.Response.Header.PeekCookie(deviceCookieName))
+	}
+
+	assert.NotEqual(t, values[0], values[1])
+
+	// A device cookie with an ID which wasn't signed for the user isn't valid.
+	deviceID := strings.SplitN(deviceCookieValue(mock.Ctx, "john", "device"), ".", 2)
+
+	mock.Ctx.Request.Header.SetCookie(deviceCookieName, "other."+deviceID[1])
+	assert.True(t, isNewLogin(mock.Ctx, "john"))
+
+	mock.Ctx.Request.Header.SetCookie(deviceCookieName, "invalid")
+	assert.True(t, isNewLogin(mock.Ctx, "john"))
+}
+
+func TestShouldNotDetectNewLoginWhenDisabled(t *testing.T) {
+	mock := newSecurityEventsMockAutheliaCtx(t, schema.SecurityEventsNotifierConfiguration{DisableNewLogin: true})
+	defer mock.Close()
+
+	assert.False(t, isNewLogin(mock.Ctx, "john"))
+
+	setDeviceCookie(mock.Ctx, "john")
+
+	assert.Len(t, mock.Ctx.Response.Header.PeekCookie(deviceCookieName), 0)
+}
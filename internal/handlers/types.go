@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/tstranex/u2f"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
@@ -34,6 +36,62 @@ type signDuoRequestBody struct {
 	Passcode  string `json:"passcode"`
 }
 
+// userSessionResponse represents one of the active sessions of a user.
+type userSessionResponse struct {
+	ID                string     `json:"id"`
+	RemoteIP          string     `json:"remote_ip"`
+	UserAgent         string     `json:"user_agent"`
+	Device            string     `json:"device"`
+	Created           time.Time  `json:"created"`
+	LastActivity      *time.Time `json:"last_activity,omitempty"`
+	FirstFactorAuthn  *time.Time `json:"first_factor_authn,omitempty"`
+	SecondFactorAuthn *time.Time `json:"second_factor_authn,omitempty"`
+	KeepMeLoggedIn    bool       `json:"keep_me_logged_in"`
+	Current           bool       `json:"current"`
+}
+
+// adminUserDevicesResponse represents the second factor devices of a user. A device is null if the user has not
+// registered it.
+type adminUserDevicesResponse struct {
+	TOTP *adminTOTPDevice `json:"totp"`
+	U2F  *adminU2FDevice  `json:"u2f"`
+	Duo  *adminDuoDevice  `json:"duo"`
+}
+
+type adminTOTPDevice struct {
+	Issuer    string `json:"issuer"`
+	Algorithm string `json:"algorithm"`
+	Digits    uint   `json:"digits"`
+	Period    uint   `json:"period"`
+}
+
+type adminU2FDevice struct {
+	Description string `json:"description"`
+}
+
+type adminDuoDevice struct {
+	Device string `json:"device"`
+	Method string `json:"method"`
+}
+
+// adminRevokedResponse represents the number of bans or sessions revoked by the administrative API.
+type adminRevokedResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// adminAuthenticationLog represents an authentication attempt of a user.
+type adminAuthenticationLog struct {
+	Time          time.Time `json:"time"`
+	Successful    bool      `json:"successful"`
+	Banned        bool      `json:"banned"`
+	Type          string    `json:"type"`
+	RemoteIP      string    `json:"remote_ip"`
+	Country       string    `json:"country,omitempty"`
+	ASN           uint      `json:"asn,omitempty"`
+	RequestURI    string    `json:"request_uri"`
+	RequestMethod string    `json:"request_method"`
+}
+
 // preferred2FAMethodBody the selected 2FA method.
 type preferred2FAMethodBody struct {
 	Method string `json:"method" valid:"required"`
@@ -1,3 +1,40 @@
 package logging
 
+import (
+	"time"
+)
+
 const logFormatJSON = "json"
+
+const (
+	logFileMegabyte            = 1024 * 1024
+	logFileRotationTimeFormat  = "20060102T150405.000"
+	logFileCompressedExtension = ".gz"
+)
+
+// Syslog severities.
+const (
+	SyslogSeverityCritical = 2
+	SyslogSeverityError    = 3
+	SyslogSeverityWarning  = 4
+	SyslogSeverityNotice   = 5
+	SyslogSeverityInfo     = 6
+	SyslogSeverityDebug    = 7
+)
+
+// JournaldSocketPath is the path of the socket journald receives the entries of the native journal protocol on.
+const JournaldSocketPath = "/run/systemd/journal/socket"
+
+const journaldIdentifier = "authelia"
+
+const (
+	syslogNilValue        = "-"
+	syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+	syslogDialTimeout  = 5 * time.Second
+	syslogWriteTimeout = 5 * time.Second
+
+	// syslogHookQueueSize is the number of log entries which can wait to be sent to the syslog server before the entries
+	// are dropped.
+	syslogHookQueueSize = 1024
+)
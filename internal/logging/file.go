@@ -0,0 +1,245 @@
+package logging
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// OpenFile opens the log file at the path for appending. The file is rotated according to the rotation configuration
+// when it isn't nil.
+func OpenFile(path string, rotation *schema.LogRotationConfiguration) (file *File, err error) {
+	file = &File{path: path, rotation: rotation}
+
+	if err = file.open(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// File is a log file which can be rotated when it grows too large or gets too old, and which can be reopened after it
+// was moved by an external tool such as logrotate.
+type File struct {
+	path     string
+	rotation *schema.LogRotationConfiguration
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+	next  time.Time
+
+	// cleanup serializes the compression and the removal of the rotated files.
+	cleanup sync.Mutex
+}
+
+// Write implements io.Writer, rotating the file before writing when the data would exceed the maximum size or the
+// rotation interval has elapsed.
+func (f *File) Write(data []byte) (n int, err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if f.shouldRotate(int64(len(data))) {
+		if err = f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = f.file.Write(data)
+	f.size += int64(n)
+
+	return n, err
+}
+
+// Reopen closes the file and opens the path again, so the writes go to a new file once the current one was moved.
+func (f *File) Reopen() (err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+
+	return f.open()
+}
+
+// Close closes the file.
+func (f *File) Close() (err error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err = f.file.Close()
+	f.file = nil
+
+	return err
+}
+
+func (f *File) open() (err error) {
+	if f.file, err = os.OpenFile(f.path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600); err != nil {
+		return err
+	}
+
+	info, err := f.file.Stat()
+	if err != nil {
+		_ = f.file.Close()
+		f.file = nil
+
+		return err
+	}
+
+	f.size = info.Size()
+
+	if f.rotation != nil && f.rotation.Interval > 0 {
+		// The rotations are aligned on the interval, so a file rotated daily contains the messages of a single UTC day.
+		f.next = time.Now().Truncate(f.rotation.Interval).Add(f.rotation.Interval)
+	}
+
+	return nil
+}
+
+func (f *File) shouldRotate(length int64) bool {
+	if f.rotation == nil {
+		return false
+	}
+
+	if f.rotation.MaxSize > 0 && f.size > 0 && f.size+length > int64(f.rotation.MaxSize)*logFileMegabyte {
+		return true
+	}
+
+	return f.rotation.Interval > 0 && !time.Now().Before(f.next)
+}
+
+func (f *File) rotate() (err error) {
+	if err = f.file.Close(); err != nil {
+		return err
+	}
+
+	f.file = nil
+
+	backup := fmt.Sprintf("%s.%s", f.path, time.Now().UTC().Format(logFileRotationTimeFormat))
+
+	renameErr := os.Rename(f.path, backup)
+
+	if err = f.open(); err != nil {
+		return err
+	}
+
+	if renameErr != nil {
+		// The messages are still written to the current file rather than being lost.
+		fmt.Fprintf(os.Stderr, "Unable to rotate the log file: %v\n", renameErr)
+
+		return nil
+	}
+
+	go f.process(backup)
+
+	return nil
+}
+
+// process compresses the rotated file and removes the rotated files exceeding the maximum number of backups.
+func (f *File) process(backup string) {
+	f.cleanup.Lock()
+	defer f.cleanup.Unlock()
+
+	if f.rotation.Compress {
+		if err := compressFile(backup); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to compress the rotated log file %s: %v\n", backup, err)
+		}
+	}
+
+	if f.rotation.MaxBackups == 0 {
+		return
+	}
+
+	backups, err := f.backups()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to list the rotated log files: %v\n", err)
+
+		return
+	}
+
+	for i := 0; i < len(backups)-f.rotation.MaxBackups; i++ {
+		if err = os.Remove(backups[i]); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to remove the rotated log file %s: %v\n", backups[i], err)
+		}
+	}
+}
+
+// backups returns the rotated files from the oldest to the most recent.
+func (f *File) backups() (backups []string, err error) {
+	entries, err := os.ReadDir(filepath.Dir(f.path))
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(f.path) + "."
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), logFileCompressedExtension)
+
+		if _, err = time.Parse(logFileRotationTimeFormat, timestamp); err != nil {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(filepath.Dir(f.path), name))
+	}
+
+	// The timestamps sort lexically in chronological order.
+	sort.Strings(backups)
+
+	return backups, nil
+}
+
+func compressFile(path string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+logFileCompressedExtension, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		_ = os.Remove(path + logFileCompressedExtension)
+
+		return err
+	}
+
+	return os.Remove(path)
+}
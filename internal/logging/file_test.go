@@ -0,0 +1,174 @@
+package logging
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldRotateLogFileWhenMaxSizeIsExceeded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authelia.log")
+
+	file, err := OpenFile(path, &schema.LogRotationConfiguration{MaxSize: 1})
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	line := []byte(strings.Repeat("a", 1023) + "\n")
+
+	for i := 0; i < 1024; i++ {
+		_, err = file.Write(line)
+		require.NoError(t, err)
+	}
+
+	_, err = file.Write([]byte("rotated\n"))
+	require.NoError(t, err)
+
+	backups, err := file.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	info, err := os.Stat(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(logFileMegabyte), info.Size())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "rotated\n", string(data))
+}
+
+func TestShouldRotateLogFileWhenIntervalHasElapsed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authelia.log")
+
+	file, err := OpenFile(path, &schema.LogRotationConfiguration{Interval: time.Hour})
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	file.next = time.Now().Add(-time.Second)
+
+	_, err = file.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	assert.True(t, file.next.After(time.Now()))
+
+	backups, err := file.backups()
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+
+	data, err := os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(data))
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(data))
+}
+
+func TestShouldCompressAndRemoveRotatedLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authelia.log")
+
+	// The rotated files which aren't named like the backups of the log file are kept.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "authelia.log.old"), []byte("old"), 0600))
+
+	file, err := OpenFile(path, &schema.LogRotationConfiguration{Interval: time.Hour, MaxBackups: 2, Compress: true})
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	for _, message := range []string{"first", "second", "third", "fourth"} {
+		file.next = time.Now().Add(-time.Second)
+
+		_, err = file.Write([]byte(message + "\n"))
+		require.NoError(t, err)
+
+		// Ensures the rotated files have distinct names.
+		time.Sleep(time.Millisecond * 2)
+	}
+
+	var backups []string
+
+	require.Eventually(t, func() bool {
+		file.cleanup.Lock()
+		defer file.cleanup.Unlock()
+
+		backups, err = file.backups()
+
+		return err == nil && len(backups) == 2 && strings.HasSuffix(backups[1], logFileCompressedExtension)
+	}, time.Second*5, time.Millisecond*10)
+
+	for i, expected := range []string{"second\n", "third\n"} {
+		assert.True(t, strings.HasSuffix(backups[i], logFileCompressedExtension))
+
+		f, err := os.Open(backups[i])
+		require.NoError(t, err)
+
+		reader, err := gzip.NewReader(f)
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(data))
+
+		f.Close()
+	}
+
+	assert.FileExists(t, filepath.Join(dir, "authelia.log.old"))
+}
+
+func TestShouldReopenLogFileAfterItWasMoved(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authelia.log")
+
+	file, err := OpenFile(path, nil)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	_, err = file.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, os.Rename(path, path+".1"))
+
+	_, err = file.Write([]byte("second\n"))
+	require.NoError(t, err)
+
+	require.NoError(t, file.Reopen())
+
+	_, err = file.Write([]byte("third\n"))
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data))
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(data))
+}
+
+func TestShouldNotWriteToClosedLogFile(t *testing.T) {
+	file, err := OpenFile(filepath.Join(t.TempDir(), "authelia.log"), nil)
+	require.NoError(t, err)
+
+	require.NoError(t, file.Close())
+	require.NoError(t, file.Close())
+
+	_, err = file.Write([]byte("message\n"))
+	assert.ErrorIs(t, err, os.ErrClosed)
+}
@@ -0,0 +1,136 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewSyslogHook creates a logrus hook which sends the log entries formatted with the formatter to a syslog server.
+func NewSyslogHook(config schema.SyslogConfiguration, formatter logrus.Formatter) *SyslogHook {
+	return newSyslogHook(NewSyslogWriter(config), formatter, syslogHookQueueSize)
+}
+
+func newSyslogHook(writer *SyslogWriter, formatter logrus.Formatter, size int) *SyslogHook {
+	hook := &SyslogHook{
+		writer:    writer,
+		formatter: formatter,
+		entries:   make(chan syslogEntry, size),
+		done:      make(chan struct{}),
+	}
+
+	go hook.run()
+
+	return hook
+}
+
+// SyslogHook is a logrus hook which sends the log entries to a syslog server. The entries are sent in the background
+// from a bounded queue so a slow or unreachable server never blocks the goroutines which log. The entries which don't
+// fit in the queue are dropped, and the number of dropped entries is sent to the server along with the next entry.
+type SyslogHook struct {
+	writer    *SyslogWriter
+	formatter logrus.Formatter
+
+	mutex   sync.RWMutex
+	closed  bool
+	entries chan syslogEntry
+	done    chan struct{}
+
+	dropped uint64
+}
+
+// syslogEntry is a formatted log entry waiting to be sent to the syslog server.
+type syslogEntry struct {
+	severity int
+	data     []byte
+}
+
+// Levels implements logrus.Hook.
+func (h *SyslogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *SyslogHook) Fire(entry *logrus.Entry) (err error) {
+	data, err := h.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.closed {
+		return nil
+	}
+
+	select {
+	case h.entries <- syslogEntry{severity: syslogSeverity(entry.Level), data: append([]byte(nil), bytes.TrimRight(data, "\n")...)}:
+	default:
+		atomic.AddUint64(&h.dropped, 1)
+	}
+
+	return nil
+}
+
+// run sends the queued entries to the syslog server until the queue is closed. The entries which can't be sent are
+// reported on the standard error like logrus reports the errors of the hooks.
+func (h *SyslogHook) run() {
+	defer close(h.done)
+
+	for entry := range h.entries {
+		if dropped := atomic.SwapUint64(&h.dropped, 0); dropped != 0 {
+			h.write(SyslogSeverityWarning, []byte(fmt.Sprintf("%d log entries were dropped because the syslog server did not receive them fast enough", dropped)))
+		}
+
+		h.write(entry.severity, entry.data)
+	}
+}
+
+func (h *SyslogHook) write(severity int, data []byte) {
+	if err := h.writer.WriteMessage(severity, "", data); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to send the log entry to the syslog server: %v\n", err)
+	}
+}
+
+// Close sends the entries which are still queued and closes the connection to the syslog server.
+func (h *SyslogHook) Close() error {
+	h.mutex.Lock()
+
+	if h.closed {
+		h.mutex.Unlock()
+
+		return nil
+	}
+
+	h.closed = true
+
+	close(h.entries)
+
+	h.mutex.Unlock()
+
+	<-h.done
+
+	return h.writer.Close()
+}
+
+func syslogSeverity(level logrus.Level) int {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel:
+		return SyslogSeverityCritical
+	case logrus.ErrorLevel:
+		return SyslogSeverityError
+	case logrus.WarnLevel:
+		return SyslogSeverityWarning
+	case logrus.InfoLevel:
+		return SyslogSeverityInfo
+	default:
+		return SyslogSeverityDebug
+	}
+}
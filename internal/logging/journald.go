@@ -0,0 +1,125 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewJournaldHook creates a logrus hook which sends the log entries to the journald socket at the path using the
+// native journal protocol.
+func NewJournaldHook(path, identifier string) *JournaldHook {
+	return &JournaldHook{path: path, identifier: identifier}
+}
+
+// JournaldHook is a logrus hook which sends the log entries to journald. The message, the priority and each field of
+// the entry are sent as separate journal fields, so the fields can be used to filter the entries with journalctl.
+type JournaldHook struct {
+	path, identifier string
+
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+// Levels implements logrus.Hook.
+func (h *JournaldHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook.
+func (h *JournaldHook) Fire(entry *logrus.Entry) (err error) {
+	buf := &bytes.Buffer{}
+
+	writeJournaldField(buf, "MESSAGE", entry.Message)
+	writeJournaldField(buf, "PRIORITY", strconv.Itoa(syslogSeverity(entry.Level)))
+	writeJournaldField(buf, "SYSLOG_IDENTIFIER", h.identifier)
+
+	for key, value := range entry.Data {
+		if name := journaldFieldName(key); name != "" {
+			writeJournaldField(buf, name, fmt.Sprint(value))
+		}
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	// The journald socket is a datagram socket, so a write only fails when journald isn't running. The connection is
+	// established again on the next entry in case journald restarted.
+	if h.conn == nil {
+		if h.conn, err = net.Dial("unixgram", h.path); err != nil {
+			h.conn = nil
+
+			return fmt.Errorf("unable to connect to journald at %s: %w", h.path, err)
+		}
+	}
+
+	if _, err = h.conn.Write(buf.Bytes()); err != nil {
+		_ = h.conn.Close()
+		h.conn = nil
+
+		return fmt.Errorf("unable to write to journald: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the connection to journald.
+func (h *JournaldHook) Close() (err error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if h.conn == nil {
+		return nil
+	}
+
+	err = h.conn.Close()
+	h.conn = nil
+
+	return err
+}
+
+// writeJournaldField writes a field in the format of the native journal protocol. The values containing a new line
+// are written with their length as they can't be delimited by the new line.
+func writeJournaldField(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+
+	if !strings.Contains(value, "\n") {
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+
+		return
+	}
+
+	buf.WriteByte('\n')
+	_ = binary.Write(buf, binary.LittleEndian, uint64(len(value)))
+	buf.WriteString(value)
+	buf.WriteByte('\n')
+}
+
+// journaldFieldName converts the key of a logrus field to a journal field name, which may only contain uppercase
+// letters, digits and underscores, and can't start with an underscore as those fields are trusted fields of journald.
+func journaldFieldName(key string) string {
+	name := strings.TrimLeft(strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, key), "_")
+
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		return ""
+	}
+
+	return name
+}
@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSendEntriesToJournald(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	hook := NewJournaldHook(path, "authelia")
+	defer hook.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(hook)
+
+	logger.WithFields(logrus.Fields{"remote_ip": "127.0.0.1", "_PID": 1}).Warn("Unsuccessful 1FA authentication attempt")
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	fields := parseJournaldFields(t, buf[:n])
+
+	assert.Equal(t, map[string]string{
+		"MESSAGE":           "Unsuccessful 1FA authentication attempt",
+		"PRIORITY":          "4",
+		"SYSLOG_IDENTIFIER": "authelia",
+		"REMOTE_IP":         "127.0.0.1",
+		"PID":               "1",
+	}, fields)
+
+	logger.Error("first line\nsecond line")
+
+	n, err = conn.Read(buf)
+	require.NoError(t, err)
+
+	fields = parseJournaldFields(t, buf[:n])
+
+	assert.Equal(t, "first line\nsecond line", fields["MESSAGE"])
+	assert.Equal(t, "3", fields["PRIORITY"])
+}
+
+func TestShouldReturnErrorWhenJournaldIsNotRunning(t *testing.T) {
+	hook := NewJournaldHook(filepath.Join(t.TempDir(), "journal.sock"), "authelia")
+
+	err := hook.Fire(&logrus.Entry{Level: logrus.InfoLevel, Message: "message"})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to connect to journald at ")
+}
+
+func TestShouldConvertFieldNamesToJournaldFieldNames(t *testing.T) {
+	assert.Equal(t, "TRACE_ID", journaldFieldName("trace_id"))
+	assert.Equal(t, "HTTP_METHOD", journaldFieldName("http.method"))
+	assert.Equal(t, "PID", journaldFieldName("__pid"))
+	assert.Equal(t, "", journaldFieldName("1fa"))
+	assert.Equal(t, "", journaldFieldName("_"))
+}
+
+func parseJournaldFields(t *testing.T, data []byte) map[string]string {
+	fields := map[string]string{}
+
+	for len(data) != 0 {
+		end := bytes.IndexByte(data, '\n')
+		require.NotEqual(t, -1, end)
+
+		if i := bytes.IndexByte(data[:end], '='); i != -1 {
+			fields[string(data[:i])] = string(data[i+1 : end])
+			data = data[end+1:]
+
+			continue
+		}
+
+		name := string(data[:end])
+		data = data[end+1:]
+
+		require.GreaterOrEqual(t, len(data), 8)
+
+		length := binary.LittleEndian.Uint64(data[:8])
+		fields[name] = string(data[8 : 8+length])
+		data = data[8+length+1:]
+	}
+
+	return fields
+}
@@ -15,10 +15,19 @@ func Logger() *logrus.Logger {
 	return logrus.StandardLogger()
 }
 
+var (
+	logFile  *File
+	logHooks []io.Closer
+)
+
 // InitializeLogger configures the default loggers stack levels, formatting, and the output destinations.
 func InitializeLogger(config schema.LogConfiguration, log bool) error {
 	setLevelStr(config.Level, log)
 
+	closeOutputs()
+	logrus.SetOutput(os.Stderr)
+	logrus.StandardLogger().ReplaceHooks(make(logrus.LevelHooks))
+
 	callerLevels := []logrus.Level{}
 	stackLevels := []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
 	logrus.AddHook(logrus_stack.NewHook(callerLevels, stackLevels))
@@ -29,13 +38,31 @@ func InitializeLogger(config schema.LogConfiguration, log bool) error {
 		logrus.SetFormatter(&logrus.TextFormatter{})
 	}
 
+	if config.Syslog != nil {
+		hook := NewSyslogHook(*config.Syslog, newHookFormatter(config.Format))
+
+		logrus.AddHook(hook)
+
+		logHooks = append(logHooks, hook)
+	}
+
+	if config.Journald {
+		hook := NewJournaldHook(JournaldSocketPath, journaldIdentifier)
+
+		logrus.AddHook(hook)
+
+		logHooks = append(logHooks, hook)
+	}
+
 	if config.FilePath != "" {
-		f, err := os.OpenFile(config.FilePath, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600)
+		f, err := OpenFile(config.FilePath, config.Rotation)
 
 		if err != nil {
 			return err
 		}
 
+		logFile = f
+
 		if config.Format != logFormatJSON {
 			logrus.SetFormatter(&logrus.TextFormatter{
 				DisableColors: true,
@@ -49,11 +76,53 @@ func InitializeLogger(config schema.LogConfiguration, log bool) error {
 		} else {
 			logrus.SetOutput(f)
 		}
+	} else if len(logHooks) != 0 {
+		if config.KeepStdout {
+			logrus.SetOutput(os.Stdout)
+		} else {
+			logrus.SetOutput(io.Discard)
+		}
 	}
 
 	return nil
 }
 
+// Reopen closes the log file and opens it again, so an external tool such as logrotate can rotate it.
+func Reopen() error {
+	if logFile == nil {
+		return nil
+	}
+
+	return logFile.Reopen()
+}
+
+func closeOutputs() {
+	if logFile != nil {
+		_ = logFile.Close()
+
+		logFile = nil
+	}
+
+	for _, hook := range logHooks {
+		_ = hook.Close()
+	}
+
+	logHooks = nil
+}
+
+// newHookFormatter returns the formatter of the messages sent by the hooks, which omits the timestamp and the colors of
+// the text format as the messages are timestamped by the receiver.
+func newHookFormatter(format string) logrus.Formatter {
+	if format == logFormatJSON {
+		return &logrus.JSONFormatter{}
+	}
+
+	return &logrus.TextFormatter{
+		DisableColors:    true,
+		DisableTimestamp: true,
+	}
+}
+
 func setLevelStr(level string, log bool) {
 	switch level {
 	case "error":
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"os"
+	"path/filepath"
+	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -81,3 +84,52 @@ func TestShouldFormatLogsAsJSON(t *testing.T) {
 
 	assert.Contains(t, string(b), "{\"level\":\"info\",\"msg\":\"This is a test\",")
 }
+
+func TestShouldSendLogsToSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	err = InitializeLogger(schema.LogConfiguration{Format: "text", Syslog: &schema.SyslogConfiguration{
+		Network: "udp", Address: conn.LocalAddr().String(), AppName: "authelia", Facility: "daemon",
+	}}, false)
+	require.NoError(t, err)
+
+	defer closeOutputs()
+
+	Logger().WithField("remote_ip", "127.0.0.1").Warn("This is a test")
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	// The priority of the warning severity of the daemon facility is 3*8+4.
+	assert.Regexp(t, regexp.MustCompile(`^<28>1 \S+ \S+ authelia \d+ - - level=warning msg="This is a test" remote_ip=127.0.0.1$`), string(buf[:n]))
+}
+
+func TestShouldReopenLogFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "authelia.log")
+
+	err := InitializeLogger(schema.LogConfiguration{Format: "text", FilePath: path}, false)
+	require.NoError(t, err)
+
+	defer closeOutputs()
+
+	Logger().Info("Before the rotation")
+
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, Reopen())
+
+	Logger().Info("After the rotation")
+
+	b, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "msg=\"Before the rotation\"")
+	assert.NotContains(t, string(b), "msg=\"After the rotation\"")
+
+	b, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "msg=\"After the rotation\"")
+}
@@ -0,0 +1,132 @@
+package logging
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewSyslogWriter creates a SyslogWriter which sends the messages to the syslog server of the configuration. The
+// connection is established when the first message is written.
+func NewSyslogWriter(config schema.SyslogConfiguration) *SyslogWriter {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = syslogNilValue
+	}
+
+	return &SyslogWriter{
+		network:  config.Network,
+		address:  config.Address,
+		appName:  config.AppName,
+		facility: schema.SyslogFacilities[config.Facility],
+		hostname: hostname,
+		pid:      os.Getpid(),
+
+		dialTimeout:  syslogDialTimeout,
+		writeTimeout: syslogWriteTimeout,
+	}
+}
+
+// SyslogWriter writes RFC 5424 syslog messages to a syslog server over UDP, TCP or a unix socket. The messages sent
+// over a stream are framed with their length as per RFC 6587. Connecting to the server and writing a message time out
+// so an unresponsive server only blocks the writer for a bounded time.
+type SyslogWriter struct {
+	network, address, appName, hostname string
+	facility, pid                       int
+
+	dialTimeout, writeTimeout time.Duration
+
+	mutex  sync.Mutex
+	conn   net.Conn
+	stream bool
+}
+
+// WriteMessage writes a message with the severity and the message ID. The connection is established again and the
+// message is written once more when writing to the current connection fails, as the server may have restarted.
+func (w *SyslogWriter) WriteMessage(severity int, msgID string, message []byte) (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if msgID == "" {
+		msgID = syslogNilValue
+	}
+
+	data := []byte(fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s", w.facility*8+severity,
+		time.Now().Format(syslogTimestampFormat), w.hostname, w.appName, w.pid, msgID, syslogNilValue, message))
+
+	for attempt := 0; attempt < 2; attempt++ {
+		if w.conn == nil {
+			if err = w.connect(); err != nil {
+				return err
+			}
+		}
+
+		if err = w.write(data); err == nil {
+			return nil
+		}
+
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+
+	return err
+}
+
+// Close closes the connection to the syslog server.
+func (w *SyslogWriter) Close() (err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn == nil {
+		return nil
+	}
+
+	err = w.conn.Close()
+	w.conn = nil
+
+	return err
+}
+
+func (w *SyslogWriter) connect() (err error) {
+	switch w.network {
+	case schema.SyslogNetworkUnix:
+		// The local syslog daemons usually listen on a datagram socket, but some listen on a stream socket.
+		if w.conn, err = net.DialTimeout("unixgram", w.address, w.dialTimeout); err == nil {
+			w.stream = false
+
+			return nil
+		}
+
+		w.conn, err = net.DialTimeout("unix", w.address, w.dialTimeout)
+		w.stream = true
+	default:
+		w.conn, err = net.DialTimeout(w.network, w.address, w.dialTimeout)
+		w.stream = w.network == schema.SyslogNetworkTCP
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to connect to the syslog server %s://%s: %w", w.network, w.address, err)
+	}
+
+	return nil
+}
+
+func (w *SyslogWriter) write(data []byte) (err error) {
+	if err = w.conn.SetWriteDeadline(time.Now().Add(w.writeTimeout)); err != nil {
+		return err
+	}
+
+	if w.stream {
+		_, err = fmt.Fprintf(w.conn, "%d %s", len(data), data)
+
+		return err
+	}
+
+	_, err = w.conn.Write(data)
+
+	return err
+}
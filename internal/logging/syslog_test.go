@@ -0,0 +1,167 @@
+package logging
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldWriteSyslogMessageOverUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "udp", Address: conn.LocalAddr().String(), AppName: "authelia", Facility: "authpriv"})
+	defer writer.Close()
+
+	require.NoError(t, writer.WriteMessage(SyslogSeverityNotice, "authentication.first_factor", []byte(`{"type":"authentication.first_factor"}`)))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	// The priority of the notice severity of the authpriv facility is 10*8+5.
+	assert.Regexp(t, regexp.MustCompile(`^<85>1 \d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}\.\d{6}(Z|[+-]\d{2}:\d{2}) \S+ authelia `+
+		strconv.Itoa(os.Getpid())+` authentication.first_factor - \{"type":"authentication.first_factor"\}$`), string(buf[:n]))
+}
+
+func TestShouldWriteSyslogMessagesOverTCPWithOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "tcp", Address: listener.Addr().String(), AppName: "authelia", Facility: "daemon"})
+	defer writer.Close()
+
+	require.NoError(t, writer.WriteMessage(SyslogSeverityError, "", []byte("first")))
+	require.NoError(t, writer.WriteMessage(SyslogSeverityInfo, "", []byte("second")))
+
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+
+	for _, expected := range []string{"<27>1 ", "<30>1 "} {
+		length, err := reader.ReadString(' ')
+		require.NoError(t, err)
+
+		n, err := strconv.Atoi(strings.TrimSpace(length))
+		require.NoError(t, err)
+
+		message := make([]byte, n)
+		_, err = reader.Read(message)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(string(message), expected))
+		assert.Contains(t, string(message), " - - ")
+	}
+}
+
+func TestShouldWriteSyslogMessageToUnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log")
+
+	conn, err := net.ListenPacket("unixgram", path)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "unix", Address: path, AppName: "authelia", Facility: "local0"})
+	defer writer.Close()
+
+	require.NoError(t, writer.WriteMessage(SyslogSeverityWarning, "", []byte("message")))
+
+	buf := make([]byte, 1024)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "<132>1 "))
+	assert.True(t, strings.HasSuffix(string(buf[:n]), " - - message"))
+}
+
+func TestShouldFailToConnectToSyslogServer(t *testing.T) {
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "unix", Address: filepath.Join(t.TempDir(), "missing"), AppName: "authelia"})
+
+	err := writer.WriteMessage(SyslogSeverityInfo, "", []byte("message"))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to connect to the syslog server unix://")
+}
+
+func TestShouldTimeOutWritingToUnresponsiveSyslogServer(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer listener.Close()
+
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "tcp", Address: listener.Addr().String(), AppName: "authelia"})
+	writer.writeTimeout = 100 * time.Millisecond
+
+	defer writer.Close()
+
+	// The server accepts the connections but never reads from them, so the message doesn't fit in the buffers.
+	err = writer.WriteMessage(SyslogSeverityInfo, "", make([]byte, 32*1024*1024))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "i/o timeout")
+}
+
+func TestShouldDropSyslogHookEntriesWhenTheQueueIsFull(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	writer := NewSyslogWriter(schema.SyslogConfiguration{Network: "udp", Address: conn.LocalAddr().String(), AppName: "authelia", Facility: "daemon"})
+
+	// The worker isn't running yet so the second entry doesn't fit in the queue.
+	hook := &SyslogHook{
+		writer:    writer,
+		formatter: newHookFormatter("text"),
+		entries:   make(chan syslogEntry, 1),
+		done:      make(chan struct{}),
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(hook)
+
+	logger.Info("first")
+	logger.Info("second")
+
+	go hook.run()
+
+	buf := make([]byte, 1024)
+
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(string(buf[:n]), "<28>1 "))
+	assert.True(t, strings.HasSuffix(string(buf[:n]), " - - 1 log entries were dropped because the syslog server did not receive them fast enough"))
+
+	n, _, err = conn.ReadFrom(buf)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasSuffix(string(buf[:n]), ` - - level=info msg=first`))
+
+	require.NoError(t, hook.Close())
+	require.NoError(t, hook.Close())
+
+	logger.Info("third")
+}
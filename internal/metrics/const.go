@@ -0,0 +1,37 @@
+package metrics
+
+const namespace = "authelia"
+
+// Backends of which the latencies and errors of the operations are observed.
+const (
+	// BackendStorage is the backend label of the storage provider operations.
+	BackendStorage = "storage"
+
+	// BackendLDAP is the backend label of the LDAP user provider operations.
+	BackendLDAP = "ldap"
+
+	// BackendSMTP is the backend label of the SMTP notifier operations.
+	BackendSMTP = "smtp"
+)
+
+// Outcomes of the requests to the verify endpoint.
+const (
+	// VerifyOutcomeAuthorized is the outcome of a request which is allowed to go through.
+	VerifyOutcomeAuthorized = "authorized"
+
+	// VerifyOutcomeUnauthorized is the outcome of a request which requires the user to authenticate.
+	VerifyOutcomeUnauthorized = "unauthorized"
+
+	// VerifyOutcomeForbidden is the outcome of a request which is forbidden to the user.
+	VerifyOutcomeForbidden = "forbidden"
+
+	// VerifyOutcomeReauthenticationRequired is the outcome of a request which requires the user to perform the second
+	// factor again.
+	VerifyOutcomeReauthenticationRequired = "reauthentication_required"
+)
+
+// routeNotFound is the route label of the requests which didn't match any route.
+const routeNotFound = "not_found"
+
+// methodOther is the method label of the requests with a method which isn't a standard method.
+const methodOther = "OTHER"
@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttpadaptor"
+)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "The number of HTTP requests by route, method and status code.",
+	}, []string{"route", "method", "code"})
+
+	requestsDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "The latency of the HTTP requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+
+	verifyTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "verify_requests_total",
+		Help:      "The number of requests to the verify endpoint by the policy which applies to them and their outcome.",
+	}, []string{"policy", "outcome"})
+
+	firstFactorTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authentication_first_factor_total",
+		Help:      "The number of first factor authentication attempts by success and whether the user was banned.",
+	}, []string{"success", "banned"})
+
+	secondFactorTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "authentication_second_factor_total",
+		Help:      "The number of second factor authentication attempts by method, success and whether the user was banned.",
+	}, []string{"type", "success", "banned"})
+
+	regulationBansTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "regulation_bans_total",
+		Help:      "The number of regulation bans by ban type.",
+	}, []string{"type"})
+
+	backendDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "backend_operation_duration_seconds",
+		Help:      "The latency of the storage, LDAP and SMTP operations by backend and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "operation"})
+
+	backendErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "backend_operation_errors_total",
+		Help:      "The number of failed storage, LDAP and SMTP operations by backend and operation.",
+	}, []string{"backend", "operation"})
+
+	auditEventsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "audit_events_dropped_total",
+		Help:      "The number of audit events which were dropped because the queue of the audit log was full.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal,
+		requestsDuration,
+		verifyTotal,
+		firstFactorTotal,
+		secondFactorTotal,
+		regulationBansTotal,
+		backendDuration,
+		backendErrorsTotal,
+		auditEventsDroppedTotal,
+	)
+}
+
+// Handler returns the fasthttp.RequestHandler which exposes the metrics in the Prometheus exposition format.
+func Handler() fasthttp.RequestHandler {
+	return fasthttpadaptor.NewFastHTTPHandler(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+}
+
+// RecordRequest records a HTTP request to a route. An empty route is the route of the requests which didn't match any
+// route, and the methods which aren't standard methods are recorded as OTHER, this prevents unknown paths and made up
+// methods from creating a series each.
+func RecordRequest(route, method string, statusCode int, elapsed time.Duration) {
+	if route == "" {
+		route = routeNotFound
+	}
+
+	switch method {
+	case fasthttp.MethodGet, fasthttp.MethodHead, fasthttp.MethodPost, fasthttp.MethodPut, fasthttp.MethodPatch,
+		fasthttp.MethodDelete, fasthttp.MethodOptions:
+	default:
+		method = methodOther
+	}
+
+	code := strconv.Itoa(statusCode)
+
+	requestsTotal.WithLabelValues(route, method, code).Inc()
+	requestsDuration.WithLabelValues(route, method, code).Observe(elapsed.Seconds())
+}
+
+// RecordVerify records the outcome of a request to the verify endpoint and the policy which applies to it.
+func RecordVerify(policy, outcome string) {
+	verifyTotal.WithLabelValues(policy, outcome).Inc()
+}
+
+// RecordAuthenticationFirstFactor records a first factor authentication attempt.
+func RecordAuthenticationFirstFactor(successful, banned bool) {
+	firstFactorTotal.WithLabelValues(strconv.FormatBool(successful), strconv.FormatBool(banned)).Inc()
+}
+
+// RecordAuthenticationSecondFactor records a second factor authentication attempt with the method.
+func RecordAuthenticationSecondFactor(method string, successful, banned bool) {
+	secondFactorTotal.WithLabelValues(method, strconv.FormatBool(successful), strconv.FormatBool(banned)).Inc()
+}
+
+// RecordRegulationBan records a ban of the regulator.
+func RecordRegulationBan(banType string) {
+	regulationBansTotal.WithLabelValues(banType).Inc()
+}
+
+// ObserveBackend records the latency of an operation of a backend and whether it failed.
+func ObserveBackend(backend, operation string, elapsed time.Duration, err error) {
+	backendDuration.WithLabelValues(backend, operation).Observe(elapsed.Seconds())
+
+	if err != nil {
+		backendErrorsTotal.WithLabelValues(backend, operation).Inc()
+	}
+}
+
+// RecordAuditEventDropped records an audit event which was dropped.
+func RecordAuditEventDropped() {
+	auditEventsDroppedTotal.Inc()
+}
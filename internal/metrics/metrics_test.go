@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestShouldRecordRequests(t *testing.T) {
+	RecordRequest("/api/state", fasthttp.MethodGet, fasthttp.StatusOK, time.Millisecond)
+	RecordRequest("/api/state", fasthttp.MethodGet, fasthttp.StatusOK, time.Millisecond)
+	RecordRequest("", fasthttp.MethodGet, fasthttp.StatusNotFound, time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/state", fasthttp.MethodGet, "200")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues(routeNotFound, fasthttp.MethodGet, "404")))
+}
+
+func TestShouldRecordNonStandardMethodsAsOther(t *testing.T) {
+	RecordRequest("/api/health", "MADEUP", fasthttp.StatusMethodNotAllowed, time.Millisecond)
+	RecordRequest("/api/health", "get", fasthttp.StatusMethodNotAllowed, time.Millisecond)
+	RecordRequest("/api/health", fasthttp.MethodOptions, fasthttp.StatusOK, time.Millisecond)
+
+	assert.Equal(t, float64(2), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/health", methodOther, "405")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/health", "MADEUP", "405")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(requestsTotal.WithLabelValues("/api/health", fasthttp.MethodOptions, "200")))
+}
+
+func TestShouldRecordAuthentication(t *testing.T) {
+	RecordAuthenticationFirstFactor(false, false)
+	RecordAuthenticationFirstFactor(false, true)
+	RecordAuthenticationSecondFactor("TOTP", true, false)
+	RecordVerify("two_factor", VerifyOutcomeUnauthorized)
+	RecordRegulationBan("user")
+	RecordAuditEventDropped()
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(firstFactorTotal.WithLabelValues("false", "false")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(firstFactorTotal.WithLabelValues("false", "true")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(secondFactorTotal.WithLabelValues("TOTP", "true", "false")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(verifyTotal.WithLabelValues("two_factor", VerifyOutcomeUnauthorized)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(regulationBansTotal.WithLabelValues("user")))
+	assert.Equal(t, float64(1), testutil.ToFloat64(auditEventsDroppedTotal))
+}
+
+func TestShouldObserveBackendErrors(t *testing.T) {
+	ObserveBackend(BackendSMTP, "Send", time.Second, nil)
+	ObserveBackend(BackendSMTP, "Send", time.Second, errors.New("failed"))
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(backendErrorsTotal.WithLabelValues(BackendSMTP, "Send")))
+	assert.Equal(t, 1, testutil.CollectAndCount(backendDuration))
+}
+
+func TestShouldServeMetrics(t *testing.T) {
+	RecordRegulationBan("ip")
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/metrics")
+
+	Handler()(ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, ctx.Response.StatusCode())
+	assert.Contains(t, string(ctx.Response.Body()), `authelia_regulation_bans_total{type="ip"} 1`)
+	assert.Contains(t, string(ctx.Response.Body()), "go_goroutines")
+}
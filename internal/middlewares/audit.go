@@ -0,0 +1,32 @@
+package middlewares
+
+import (
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/authelia/authelia/v4/internal/audit"
+)
+
+// Audit writes the audit event caused by the request when the audit log is enabled. The remote IP and the user agent of
+// the request are those of the actor, and the user is the actor when no actor is set. The event is written in the
+// background, and failing to queue it is only logged as it must not affect the outcome of the request.
+func (c *AutheliaCtx) Audit(event audit.Event) {
+	if c.Providers.Audit == nil {
+		return
+	}
+
+	if event.Actor.Type == "" && event.Actor.ID != "" {
+		event.Actor.Type = audit.ActorUser
+	}
+
+	event.Time = c.Clock.Now()
+	event.Actor.IP = c.RemoteIP().String()
+	event.Actor.UserAgent = string(c.UserAgent())
+
+	if spanContext := trace.SpanContextFromContext(c); spanContext.IsValid() {
+		event.TraceID = spanContext.TraceID().String()
+	}
+
+	if err := c.Providers.Audit.Log(event); err != nil {
+		c.Logger.Errorf("Unable to queue the %s audit event: %+v", event.Type, err)
+	}
+}
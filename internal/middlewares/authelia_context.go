@@ -9,40 +9,77 @@ import (
 	"strings"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/fasthttp/router"
 	"github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/tracing"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
-// NewRequestLogger create a new request logger for the given request.
+// NewRequestLogger create a new request logger for the given request. The IDs of the trace and span of the request are
+// logged when it's traced.
 func NewRequestLogger(ctx *AutheliaCtx) *logrus.Entry {
-	return logrus.WithFields(logrus.Fields{
+	fields := logrus.Fields{
 		"method":    string(ctx.Method()),
 		"path":      string(ctx.Path()),
 		"remote_ip": ctx.RemoteIP().String(),
-	})
+	}
+
+	if spanContext := trace.SpanContextFromContext(tracing.RequestContext(ctx.RequestCtx)); spanContext.IsValid() {
+		fields["trace_id"] = spanContext.TraceID().String()
+		fields["span_id"] = spanContext.SpanID().String()
+	}
+
+	return logrus.WithFields(fields)
 }
 
 // NewAutheliaCtx instantiate an AutheliaCtx out of a RequestCtx.
 func NewAutheliaCtx(ctx *fasthttp.RequestCtx, configuration schema.Configuration, providers Providers) (*AutheliaCtx, error) {
+	return newAutheliaCtx(ctx, configuration, providers, parseTrustedProxies(configuration.Server.TrustedProxies))
+}
+
+func newAutheliaCtx(ctx *fasthttp.RequestCtx, configuration schema.Configuration, providers Providers, trustedProxies []*net.IPNet) (*AutheliaCtx, error) {
 	autheliaCtx := new(AutheliaCtx)
 	autheliaCtx.RequestCtx = ctx
 	autheliaCtx.Providers = providers
 	autheliaCtx.Configuration = configuration
+	autheliaCtx.trustedProxies = trustedProxies
 	autheliaCtx.Logger = NewRequestLogger(autheliaCtx)
 	autheliaCtx.Clock = utils.RealClock{}
 
+	// The calls to the user provider and the notifier are traced as children of the span of the request.
+	if configuration.Tracing.Enabled {
+		if providers.UserProvider != nil {
+			autheliaCtx.Providers.UserProvider = tracing.NewUserProvider(autheliaCtx, providers.UserProvider)
+		}
+
+		if providers.Notifier != nil {
+			autheliaCtx.Providers.Notifier = tracing.NewNotifier(autheliaCtx, providers.Notifier)
+		}
+	}
+
 	return autheliaCtx, nil
 }
 
 // AutheliaMiddleware is wrapping the RequestCtx into an AutheliaCtx providing Authelia related objects.
 func AutheliaMiddleware(configuration schema.Configuration, providers Providers) RequestHandlerBridge {
+	trustedProxies := parseTrustedProxies(configuration.Server.TrustedProxies)
+
 	return func(next RequestHandler) fasthttp.RequestHandler {
 		return func(ctx *fasthttp.RequestCtx) {
-			autheliaCtx, err := NewAutheliaCtx(ctx, configuration, providers)
+			if configuration.Tracing.Enabled {
+				route, _ := ctx.UserValue(router.MatchedRoutePathParam).(string)
+
+				span := tracing.StartRequest(ctx, route)
+				defer tracing.EndRequest(ctx, span)
+			}
+
+			autheliaCtx, err := newAutheliaCtx(ctx, configuration, providers, trustedProxies)
 			if err != nil {
 				autheliaCtx.Error(err, messageOperationFailed)
 				return
@@ -53,6 +90,16 @@ func AutheliaMiddleware(configuration schema.Configuration, providers Providers)
 	}
 }
 
+// Value returns the value of the key from the context of the span of the request, or the user value of the request.
+// This makes the spans started with the AutheliaCtx children of the span of the request.
+func (c *AutheliaCtx) Value(key interface{}) interface{} {
+	if value := tracing.RequestContext(c.RequestCtx).Value(key); value != nil {
+		return value
+	}
+
+	return c.RequestCtx.Value(key)
+}
+
 // Error reply with an error and display the stack trace in the logs.
 func (c *AutheliaCtx) Error(err error, message string) {
 	c.SetJSONError(message)
@@ -162,6 +209,12 @@ func (c *AutheliaCtx) XOriginalURL() []byte {
 	return c.RequestCtx.Request.Header.PeekBytes(headerXOriginalURL)
 }
 
+// GetSessionCookie returns the configuration of the session cookie used for the request, which is selected using the
+// host of the protected resource on the verify endpoint and the X-Forwarded-Host header otherwise.
+func (c *AutheliaCtx) GetSessionCookie() schema.SessionCookieConfiguration {
+	return session.GetCookieConfiguration(c.Configuration.Session, c.RequestCtx)
+}
+
 // GetSession return the user session. Any update will be saved in cache.
 func (c *AutheliaCtx) GetSession() session.UserSession {
 	userSession, err := c.Providers.SessionProvider.GetSession(c.RequestCtx)
@@ -218,18 +271,75 @@ func (c *AutheliaCtx) SetJSONBody(value interface{}) error {
 	return nil
 }
 
-// RemoteIP return the remote IP taking X-Forwarded-For header into account if provided.
+// RemoteIP return the remote IP taking X-Forwarded-For header into account if provided. When trusted proxies are
+// configured the forwarded addresses are only taken into account if the peer is a trusted proxy, and they're read from
+// right to left stopping at the first address which is not a trusted proxy.
 func (c *AutheliaCtx) RemoteIP() net.IP {
-	XForwardedFor := c.Request.Header.PeekBytes(headerXForwardedFor)
-	if XForwardedFor != nil {
-		ips := strings.Split(string(XForwardedFor), ",")
+	if len(c.trustedProxies) == 0 {
+		XForwardedFor := c.Request.Header.PeekBytes(headerXForwardedFor)
+		if XForwardedFor != nil {
+			ips := strings.Split(string(XForwardedFor), ",")
+
+			if len(ips) > 0 {
+				return net.ParseIP(strings.Trim(ips[0], " "))
+			}
+		}
+
+		return c.RequestCtx.RemoteIP()
+	}
+
+	remoteIP := c.RequestCtx.RemoteIP()
+
+	if !utils.IsIPInNetworks(remoteIP, c.trustedProxies) {
+		return remoteIP
+	}
+
+	forwarded := c.forwardedAddresses()
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		ip := net.ParseIP(forwarded[i])
+		if ip == nil {
+			// The address is obfuscated or invalid, the last trusted proxy is the closest known hop.
+			return remoteIP
+		}
+
+		remoteIP = ip
+
+		if !utils.IsIPInNetworks(remoteIP, c.trustedProxies) {
+			return remoteIP
+		}
+	}
+
+	return remoteIP
+}
+
+// RemoteLocation returns the location of the remote IP when a GeoIP provider is configured.
+func (c *AutheliaCtx) RemoteLocation() (location geoip.Location) {
+	if c.Providers.GeoIP == nil {
+		return location
+	}
+
+	return c.Providers.GeoIP.Lookup(c.RemoteIP())
+}
 
-		if len(ips) > 0 {
-			return net.ParseIP(strings.Trim(ips[0], " "))
+// forwardedAddresses returns the addresses of the hops the request was forwarded for in the order they were added.
+func (c *AutheliaCtx) forwardedAddresses() (addresses []string) {
+	if c.Configuration.Server.EnableForwardedHeader {
+		if forwarded := c.Request.Header.PeekBytes(headerForwarded); forwarded != nil {
+			return parseForwardedFor(string(forwarded))
 		}
 	}
 
-	return c.RequestCtx.RemoteIP()
+	XForwardedFor := c.Request.Header.PeekBytes(headerXForwardedFor)
+	if XForwardedFor == nil {
+		return nil
+	}
+
+	for _, address := range strings.Split(string(XForwardedFor), ",") {
+		addresses = append(addresses, strings.Trim(address, " "))
+	}
+
+	return addresses
 }
 
 // GetOriginalURL extract the URL from the request headers (X-Original-URI or X-Forwarded-* headers).
@@ -1,12 +1,18 @@
 package middlewares_test
 
 import (
+	"net"
 	"net/url"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -19,7 +25,7 @@ func TestShouldCallNextWithAutheliaCtx(t *testing.T) {
 	ctx := &fasthttp.RequestCtx{}
 	configuration := schema.Configuration{}
 	userProvider := mocks.NewMockUserProvider(ctrl)
-	sessionProvider := session.NewProvider(configuration.Session, nil)
+	sessionProvider := session.NewProvider(configuration.Session, nil, nil)
 	providers := middlewares.Providers{
 		UserProvider:    userProvider,
 		SessionProvider: sessionProvider,
@@ -35,6 +41,52 @@ func TestShouldCallNextWithAutheliaCtx(t *testing.T) {
 	assert.True(t, nextCalled)
 }
 
+func TestShouldTraceRequestWhenTracingIsEnabled(t *testing.T) {
+	otel.SetTracerProvider(sdktrace.NewTracerProvider())
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	configuration := schema.Configuration{}
+	configuration.Tracing.Enabled = true
+
+	providers := middlewares.Providers{
+		SessionProvider: session.NewProvider(configuration.Session, nil, nil),
+	}
+
+	nextCalled := false
+
+	middlewares.AutheliaMiddleware(configuration, providers)(func(actx *middlewares.AutheliaCtx) {
+		spanContext := trace.SpanContextFromContext(actx)
+
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+		assert.NotEqual(t, "00f067aa0ba902b7", spanContext.SpanID().String())
+
+		assert.Equal(t, spanContext.TraceID().String(), actx.Logger.Data["trace_id"])
+		assert.Equal(t, spanContext.SpanID().String(), actx.Logger.Data["span_id"])
+
+		nextCalled = true
+	})(ctx)
+
+	assert.True(t, nextCalled)
+}
+
+func TestShouldNotLogTraceWhenTracingIsDisabled(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	configuration := schema.Configuration{}
+	providers := middlewares.Providers{
+		SessionProvider: session.NewProvider(configuration.Session, nil, nil),
+	}
+
+	middlewares.AutheliaMiddleware(configuration, providers)(func(actx *middlewares.AutheliaCtx) {
+		assert.False(t, trace.SpanContextFromContext(actx).IsValid())
+		assert.NotContains(t, actx.Logger.Data, "trace_id")
+	})(ctx)
+}
+
 // Test getOriginalURL.
 func TestShouldGetOriginalURLFromOriginalURLHeader(t *testing.T) {
 	mock := mocks.NewMockAutheliaCtx(t)
@@ -70,3 +122,72 @@ func TestShouldGetOriginalURLFromForwardedHeadersWithURI(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "Unable to parse URL extracted from X-Original-URL header: parse \"htt-ps//home?-.example.com\": invalid URI for request", err.Error())
 }
+
+func newRemoteIPTestCtx(t *testing.T, server schema.ServerConfiguration, peer string, headers map[string]string) *middlewares.AutheliaCtx {
+	request := &fasthttp.Request{}
+
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Init(request, &net.TCPAddr{IP: net.ParseIP(peer), Port: 4711}, nil)
+
+	actx, err := middlewares.NewAutheliaCtx(ctx, schema.Configuration{Server: server}, middlewares.Providers{})
+	require.NoError(t, err)
+
+	return actx
+}
+
+func TestShouldReturnFirstXForwardedForWithoutTrustedProxies(t *testing.T) {
+	ctx := newRemoteIPTestCtx(t, schema.ServerConfiguration{}, "10.0.0.2",
+		map[string]string{"X-Forwarded-For": "10.1.1.1, 192.0.2.10"})
+
+	assert.Equal(t, "10.1.1.1", ctx.RemoteIP().String())
+}
+
+func TestShouldReturnPeerWithoutXForwardedForOrTrustedProxies(t *testing.T) {
+	ctx := newRemoteIPTestCtx(t, schema.ServerConfiguration{}, "10.0.0.2", nil)
+
+	assert.Equal(t, "10.0.0.2", ctx.RemoteIP().String())
+}
+
+func TestShouldIgnoreXForwardedForFromUntrustedPeer(t *testing.T) {
+	ctx := newRemoteIPTestCtx(t, schema.ServerConfiguration{TrustedProxies: []string{"10.0.0.0/24"}}, "192.0.2.50",
+		map[string]string{"X-Forwarded-For": "10.1.1.1"})
+
+	assert.Equal(t, "192.0.2.50", ctx.RemoteIP().String())
+}
+
+func TestShouldReturnFirstUntrustedHopOfXForwardedFor(t *testing.T) {
+	testCases := []struct {
+		name, header, expected string
+	}{
+		{"ShouldStopAtFirstUntrustedHop", "10.1.1.1, 192.0.2.10, 10.0.0.3", "192.0.2.10"},
+		{"ShouldReturnLeftmostWhenAllTrusted", "10.0.0.4, 10.0.0.3", "10.0.0.4"},
+		{"ShouldReturnLastTrustedHopWhenInvalid", "10.1.1.1, not-an-ip, 10.0.0.3", "10.0.0.3"},
+		{"ShouldReturnPeerWhenEmpty", "", "10.0.0.2"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := newRemoteIPTestCtx(t, schema.ServerConfiguration{TrustedProxies: []string{"10.0.0.0/24"}}, "10.0.0.2",
+				map[string]string{"X-Forwarded-For": tc.header})
+
+			assert.Equal(t, tc.expected, ctx.RemoteIP().String())
+		})
+	}
+}
+
+func TestShouldUseForwardedHeaderWhenEnabled(t *testing.T) {
+	headers := map[string]string{
+		"Forwarded":       `for=192.0.2.60;proto=https, For="[2001:db8:cafe::17]:4711", for=10.0.0.3:80`,
+		"X-Forwarded-For": "192.0.2.99",
+	}
+
+	ctx := newRemoteIPTestCtx(t, schema.ServerConfiguration{TrustedProxies: []string{"10.0.0.0/24"}, EnableForwardedHeader: true}, "10.0.0.2", headers)
+	assert.Equal(t, "2001:db8:cafe::17", ctx.RemoteIP().String())
+
+	ctx = newRemoteIPTestCtx(t, schema.ServerConfiguration{TrustedProxies: []string{"10.0.0.0/24"}}, "10.0.0.2", headers)
+	assert.Equal(t, "192.0.2.99", ctx.RemoteIP().String())
+}
@@ -8,6 +8,7 @@ var (
 	headerXForwardedProto = []byte(fasthttp.HeaderXForwardedProto)
 	headerXForwardedHost  = []byte(fasthttp.HeaderXForwardedHost)
 	headerXForwardedFor   = []byte(fasthttp.HeaderXForwardedFor)
+	headerForwarded       = []byte(fasthttp.HeaderForwarded)
 	headerXRequestedWith  = []byte(fasthttp.HeaderXRequestedWith)
 	headerAccept          = []byte(fasthttp.HeaderAccept)
 
@@ -1,14 +1,16 @@
 package middlewares
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/templates"
 )
 
@@ -28,6 +30,15 @@ func IdentityVerificationStart(args IdentityVerificationStartArgs) RequestHandle
 			return
 		}
 
+		if bannedUntil, err := ctx.Providers.Regulator.RegulateIdentityVerification(ctx, identity.Username, ctx.RemoteIP()); err != nil {
+			// In that case we also reply ok to avoid user enumeration.
+			ctx.Logger.Warnf("Identity verification for user '%s' from %s was rejected until %s: %v",
+				identity.Username, ctx.RemoteIP(), bannedUntil.Format(time.RFC3339), err)
+			ctx.ReplyOK()
+
+			return
+		}
+
 		var jti uuid.UUID
 
 		if jti, err = uuid.NewUUID(); err != nil {
@@ -62,35 +73,17 @@ func IdentityVerificationStart(args IdentityVerificationStartArgs) RequestHandle
 
 		link := fmt.Sprintf("%s%s?token=%s", uri, args.TargetEndpoint, ss)
 
-		bufHTML := new(bytes.Buffer)
-
-		disableHTML := false
-		if ctx.Configuration.Notifier != nil && ctx.Configuration.Notifier.SMTP != nil {
-			disableHTML = ctx.Configuration.Notifier.SMTP.DisableHTMLEmails
-		}
-
-		if !disableHTML {
-			htmlParams := map[string]interface{}{
-				"title":  args.MailTitle,
-				"url":    link,
-				"button": args.MailButtonContent,
-			}
-
-			err = templates.HTMLEmailTemplate.Execute(bufHTML, htmlParams)
-
-			if err != nil {
-				ctx.Error(err, messageOperationFailed)
-				return
-			}
+		values := templates.EmailIdentityVerificationValues{
+			Title:       args.MailTitle,
+			DisplayName: identity.DisplayName,
+			Username:    identity.Username,
+			LinkURL:     link,
+			LinkText:    args.MailButtonContent,
+			RemoteIP:    ctx.RemoteIP().String(),
 		}
 
-		bufText := new(bytes.Buffer)
-		textParams := map[string]interface{}{
-			"url": link,
-		}
-
-		err = templates.PlainTextEmailTemplate.Execute(bufText, textParams)
-
+		email, err := ctx.Providers.Templates.ExecuteIdentityVerification(values, !notification.HTMLEmailsDisabled(ctx.Configuration.Notifier),
+			identity.Locale, string(ctx.Request.Header.Peek(fasthttp.HeaderAcceptLanguage)))
 		if err != nil {
 			ctx.Error(err, messageOperationFailed)
 			return
@@ -99,8 +92,7 @@ func IdentityVerificationStart(args IdentityVerificationStartArgs) RequestHandle
 		ctx.Logger.Debugf("Sending an email to user %s (%s) to confirm identity for registering a device.",
 			identity.Username, identity.Email)
 
-		err = ctx.Providers.Notifier.Send(identity.Email, args.MailTitle, bufText.String(), bufHTML.String())
-
+		err = ctx.Providers.Notifier.Send(identity.Email, email.Title, email.Text, email.HTML)
 		if err != nil {
 			ctx.Error(err, messageOperationFailed)
 			return
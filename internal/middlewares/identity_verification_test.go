@@ -2,19 +2,26 @@ package middlewares_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 const testJWTSecret = "abc"
@@ -149,6 +156,83 @@ func TestShouldSucceedIdentityVerificationStartProcess(t *testing.T) {
 	defer mock.Close()
 }
 
+func TestShouldSendLocalizedEmailUsingAcceptLanguage(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "de"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "de", "IdentityVerification.txt"),
+		[]byte(`{{ define "title" }}Titel{{ end }}Hallo {{ .Username }} von {{ .RemoteIP }}`), 0600))
+
+	provider, err := templates.NewProvider(dir, "en")
+	require.NoError(t, err)
+
+	mock.Ctx.Providers.Templates = provider
+
+	mock.Ctx.Configuration.JWTSecret = testJWTSecret
+	mock.Ctx.Request.Header.Add("X-Forwarded-Proto", "http")
+	mock.Ctx.Request.Header.Add("X-Forwarded-Host", "host")
+	mock.Ctx.Request.Header.Add("X-Forwarded-For", "192.168.0.10")
+	mock.Ctx.Request.Header.Add("Accept-Language", "de-CH, de;q=0.9, en;q=0.8")
+
+	mock.StorageMock.EXPECT().
+		SaveIdentityVerification(mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	mock.NotifierMock.EXPECT().
+		Send(gomock.Eq("john@example.com"), gomock.Eq("Titel"), gomock.Eq("Hallo john von 192.168.0.10"), gomock.Any()).
+		Return(nil)
+
+	middlewares.IdentityVerificationStart(newArgs(defaultRetriever))(mock.Ctx)
+
+	assert.Equal(t, 200, mock.Ctx.Response.StatusCode())
+}
+
+func TestShouldNotSendEmailWhenIdentityVerificationIsRegulated(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Configuration.JWTSecret = testJWTSecret
+	mock.Ctx.Request.Header.Add("X-Forwarded-Proto", "http")
+	mock.Ctx.Request.Header.Add("X-Forwarded-Host", "host")
+
+	mock.Ctx.Providers.Regulator = regulation.NewRegulator(&schema.RegulationConfiguration{
+		IdentityVerification: &schema.RegulationIdentityVerificationConfiguration{
+			User: &schema.RegulationLimitConfiguration{MaxRetries: 2, FindTime: "10m", BanTime: "1h"},
+		},
+	}, mock.StorageMock, nil, &mock.Clock)
+
+	latest := mock.Clock.Now().Add(-time.Minute)
+
+	gomock.InOrder(
+		mock.StorageMock.EXPECT().
+			LoadRegulationBans(mock.Ctx, gomock.Eq(regulation.BanTypeIdentityVerificationUser), gomock.Eq("john"), gomock.Any()).
+			Return(nil, nil),
+		mock.StorageMock.EXPECT().
+			LoadIdentityVerificationTimesByUsername(mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(2)).
+			Return([]time.Time{latest, mock.Clock.Now().Add(-2 * time.Minute)}, nil),
+		mock.StorageMock.EXPECT().
+			SaveRegulationBan(mock.Ctx, gomock.Eq(models.RegulationBan{
+				Time:    mock.Clock.Now(),
+				Expires: latest.Add(time.Hour),
+				Type:    regulation.BanTypeIdentityVerificationUser,
+				Value:   "john",
+			})).
+			Return(nil),
+	)
+
+	args := newArgs(defaultRetriever)
+	middlewares.IdentityVerificationStart(args)(mock.Ctx)
+
+	assert.Equal(t, 200, mock.Ctx.Response.StatusCode())
+	assert.Contains(t, mock.Hook.LastEntry().Message, "Identity verification for user 'john' from 0.0.0.0 was rejected until")
+	assert.Equal(t, logrus.WarnLevel, mock.Hook.LastEntry().Level)
+}
+
 // Test Finish process.
 type IdentityVerificationFinishProcess struct {
 	suite.Suite
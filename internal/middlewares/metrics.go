@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"time"
+
+	"github.com/fasthttp/router"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/metrics"
+)
+
+// MetricsRequestMiddleware records the requests to the routes of the router. The router must save the path of the
+// matched route so the requests are recorded by their route rather than their path.
+func MetricsRequestMiddleware(next fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return func(ctx *fasthttp.RequestCtx) {
+		started := time.Now()
+
+		next(ctx)
+
+		route, _ := ctx.UserValue(router.MatchedRoutePathParam).(string)
+
+		metrics.RecordRequest(route, string(ctx.Method()), ctx.Response.StatusCode(), time.Since(started))
+	}
+}
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"testing"
+
+	"github.com/fasthttp/router"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func TestShouldRecordRequestOfMatchedRoute(t *testing.T) {
+	r := router.New()
+	r.SaveMatchedRoutePath = true
+
+	var route string
+
+	r.GET("/api/user/sessions/{id}", func(ctx *fasthttp.RequestCtx) {
+		ctx.SetStatusCode(fasthttp.StatusNoContent)
+	})
+
+	handler := MetricsRequestMiddleware(func(ctx *fasthttp.RequestCtx) {
+		r.Handler(ctx)
+
+		route, _ = ctx.UserValue(router.MatchedRoutePathParam).(string)
+	})
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/user/sessions/abc")
+
+	handler(ctx)
+
+	assert.Equal(t, fasthttp.StatusNoContent, ctx.Response.StatusCode())
+	assert.Equal(t, "/api/user/sessions/{id}", route)
+}
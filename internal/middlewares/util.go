@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"net"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// parseTrustedProxies parses the trusted proxies which have already been validated by the configuration validator.
+func parseTrustedProxies(trustedProxies []string) (networks []*net.IPNet) {
+	for _, proxy := range trustedProxies {
+		network, err := utils.ParseHostCIDR(proxy)
+		if err != nil {
+			continue
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// parseForwardedFor returns the values of the for parameter of each element of a Forwarded header as per RFC7239,
+// stripped of their quotes, brackets and ports. Elements without a for parameter produce an empty value.
+func parseForwardedFor(header string) (addresses []string) {
+	for _, element := range strings.Split(header, ",") {
+		address := ""
+
+		for _, pair := range strings.Split(element, ";") {
+			kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+			if len(kv) != 2 || !strings.EqualFold(kv[0], "for") {
+				continue
+			}
+
+			address = parseForwardedNode(strings.Trim(kv[1], "\""))
+		}
+
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+// parseForwardedNode strips the port from a Forwarded header node, i.e. "[2001:db8::1]:4711" and "192.0.2.1:4711"
+// respectively become "2001:db8::1" and "192.0.2.1".
+func parseForwardedNode(node string) string {
+	if strings.HasPrefix(node, "[") {
+		if end := strings.Index(node, "]"); end != -1 {
+			return node[1:end]
+		}
+
+		return node
+	}
+
+	if strings.Count(node, ":") == 1 {
+		return node[:strings.Index(node, ":")]
+	}
+
+	return node
+}
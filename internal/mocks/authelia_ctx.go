@@ -18,6 +18,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 // MockAutheliaCtx a mock of AutheliaCtx.
@@ -108,13 +109,15 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 		&configuration)
 
 	providers.SessionProvider = session.NewProvider(
-		configuration.Session, nil)
+		configuration.Session, nil, providers.StorageProvider)
 
-	providers.Regulator = regulation.NewRegulator(configuration.Regulation, providers.StorageProvider, &mockAuthelia.Clock)
+	providers.Regulator = regulation.NewRegulator(configuration.Regulation, providers.StorageProvider, nil, &mockAuthelia.Clock)
 
 	mockAuthelia.TOTPMock = NewMockTOTP(mockAuthelia.Ctrl)
 	providers.TOTP = mockAuthelia.TOTPMock
 
+	providers.Templates, _ = templates.NewProvider("", "en")
+
 	request := &fasthttp.RequestCtx{}
 	// Set a cookie to identify this client throughout the test.
 	// request.Request.Header.SetCookie("authelia_session", "client_cookie")
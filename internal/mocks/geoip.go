@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/geoip (interfaces: Provider)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	net "net"
+	reflect "reflect"
+
+	geoip "github.com/authelia/authelia/v4/internal/geoip"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGeoIP is a mock of Provider interface.
+type MockGeoIP struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoIPMockRecorder
+}
+
+// MockGeoIPMockRecorder is the mock recorder for MockGeoIP.
+type MockGeoIPMockRecorder struct {
+	mock *MockGeoIP
+}
+
+// NewMockGeoIP creates a new mock instance.
+func NewMockGeoIP(ctrl *gomock.Controller) *MockGeoIP {
+	mock := &MockGeoIP{ctrl: ctrl}
+	mock.recorder = &MockGeoIPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoIP) EXPECT() *MockGeoIPMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockGeoIP) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockGeoIPMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockGeoIP)(nil).Close))
+}
+
+// Lookup mocks base method.
+func (m *MockGeoIP) Lookup(arg0 net.IP) geoip.Location {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", arg0)
+	ret0, _ := ret[0].(geoip.Location)
+	return ret0
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockGeoIPMockRecorder) Lookup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockGeoIP)(nil).Lookup), arg0)
+}
@@ -6,6 +6,8 @@ package mocks
 
 import (
 	context "context"
+	io "io"
+	net "net"
 	reflect "reflect"
 	time "time"
 
@@ -79,6 +81,51 @@ func (mr *MockStorageMockRecorder) ConsumeIdentityVerification(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeIdentityVerification", reflect.TypeOf((*MockStorage)(nil).ConsumeIdentityVerification), arg0, arg1, arg2)
 }
 
+// CountSessionData mocks base method.
+func (m *MockStorage) CountSessionData(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSessionData", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSessionData indicates an expected call of CountSessionData.
+func (mr *MockStorageMockRecorder) CountSessionData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSessionData", reflect.TypeOf((*MockStorage)(nil).CountSessionData), arg0)
+}
+
+// CountSuccessfulAuthenticationLogsByRemoteIP mocks base method.
+func (m *MockStorage) CountSuccessfulAuthenticationLogsByRemoteIP(arg0 context.Context, arg1, arg2 string, arg3 net.IP, arg4 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSuccessfulAuthenticationLogsByRemoteIP", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSuccessfulAuthenticationLogsByRemoteIP indicates an expected call of CountSuccessfulAuthenticationLogsByRemoteIP.
+func (mr *MockStorageMockRecorder) CountSuccessfulAuthenticationLogsByRemoteIP(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSuccessfulAuthenticationLogsByRemoteIP", reflect.TypeOf((*MockStorage)(nil).CountSuccessfulAuthenticationLogsByRemoteIP), arg0, arg1, arg2, arg3, arg4)
+}
+
+// DeleteExpiredSessionData mocks base method.
+func (m *MockStorage) DeleteExpiredSessionData(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredSessionData", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredSessionData indicates an expected call of DeleteExpiredSessionData.
+func (mr *MockStorageMockRecorder) DeleteExpiredSessionData(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessionData", reflect.TypeOf((*MockStorage)(nil).DeleteExpiredSessionData), arg0)
+}
+
 // DeletePreferredDuoDevice mocks base method.
 func (m *MockStorage) DeletePreferredDuoDevice(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -93,6 +140,34 @@ func (mr *MockStorageMockRecorder) DeletePreferredDuoDevice(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).DeletePreferredDuoDevice), arg0, arg1)
 }
 
+// DeleteSessionData mocks base method.
+func (m *MockStorage) DeleteSessionData(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionData indicates an expected call of DeleteSessionData.
+func (mr *MockStorageMockRecorder) DeleteSessionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionData", reflect.TypeOf((*MockStorage)(nil).DeleteSessionData), arg0, arg1)
+}
+
+// DeleteSessionInventoryRecord mocks base method.
+func (m *MockStorage) DeleteSessionInventoryRecord(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionInventoryRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionInventoryRecord indicates an expected call of DeleteSessionInventoryRecord.
+func (mr *MockStorageMockRecorder) DeleteSessionInventoryRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionInventoryRecord", reflect.TypeOf((*MockStorage)(nil).DeleteSessionInventoryRecord), arg0, arg1, arg2)
+}
+
 // DeleteTOTPConfiguration mocks base method.
 func (m *MockStorage) DeleteTOTPConfiguration(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -107,6 +182,35 @@ func (mr *MockStorageMockRecorder) DeleteTOTPConfiguration(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPConfiguration", reflect.TypeOf((*MockStorage)(nil).DeleteTOTPConfiguration), arg0, arg1)
 }
 
+// DeleteU2FDevice mocks base method.
+func (m *MockStorage) DeleteU2FDevice(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteU2FDevice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteU2FDevice indicates an expected call of DeleteU2FDevice.
+func (mr *MockStorageMockRecorder) DeleteU2FDevice(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteU2FDevice", reflect.TypeOf((*MockStorage)(nil).DeleteU2FDevice), arg0, arg1)
+}
+
+// ExportData mocks base method.
+func (m *MockStorage) ExportData(arg0 context.Context, arg1 io.Writer) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportData", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportData indicates an expected call of ExportData.
+func (mr *MockStorageMockRecorder) ExportData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportData", reflect.TypeOf((*MockStorage)(nil).ExportData), arg0, arg1)
+}
+
 // FindIdentityVerification mocks base method.
 func (m *MockStorage) FindIdentityVerification(arg0 context.Context, arg1 string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -122,6 +226,50 @@ func (mr *MockStorageMockRecorder) FindIdentityVerification(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindIdentityVerification", reflect.TypeOf((*MockStorage)(nil).FindIdentityVerification), arg0, arg1)
 }
 
+// ImportData mocks base method.
+func (m *MockStorage) ImportData(arg0 context.Context, arg1 io.Reader) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportData", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportData indicates an expected call of ImportData.
+func (mr *MockStorageMockRecorder) ImportData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportData", reflect.TypeOf((*MockStorage)(nil).ImportData), arg0, arg1)
+}
+
+// IncrementSessionGeneration mocks base method.
+func (m *MockStorage) IncrementSessionGeneration(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementSessionGeneration", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrementSessionGeneration indicates an expected call of IncrementSessionGeneration.
+func (mr *MockStorageMockRecorder) IncrementSessionGeneration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementSessionGeneration", reflect.TypeOf((*MockStorage)(nil).IncrementSessionGeneration), arg0, arg1)
+}
+
+// LoadActiveRegulationBans mocks base method.
+func (m *MockStorage) LoadActiveRegulationBans(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]models.RegulationBan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadActiveRegulationBans", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.RegulationBan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadActiveRegulationBans indicates an expected call of LoadActiveRegulationBans.
+func (mr *MockStorageMockRecorder) LoadActiveRegulationBans(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadActiveRegulationBans", reflect.TypeOf((*MockStorage)(nil).LoadActiveRegulationBans), arg0, arg1, arg2, arg3)
+}
+
 // LoadAuthenticationLogs mocks base method.
 func (m *MockStorage) LoadAuthenticationLogs(arg0 context.Context, arg1 string, arg2 time.Time, arg3, arg4 int) ([]models.AuthenticationAttempt, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +285,111 @@ func (mr *MockStorageMockRecorder) LoadAuthenticationLogs(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// LoadAuthenticationLogsByType mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsByType(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4, arg5 int) ([]models.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsByType", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]models.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsByType indicates an expected call of LoadAuthenticationLogsByType.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsByType(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsByType", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsByType), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// LoadAuthenticationLogsHistory mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsHistory(arg0 context.Context, arg1 string, arg2 time.Time, arg3, arg4 int) ([]models.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsHistory", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]models.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsHistory indicates an expected call of LoadAuthenticationLogsHistory.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsHistory(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsHistory", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsHistory), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadFailedAuthenticationLogs mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogs(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]models.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogs indicates an expected call of LoadFailedAuthenticationLogs.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogs), arg0, arg1, arg2, arg3)
+}
+
+// LoadFailedAuthenticationLogsByRemoteIP mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsByRemoteIP(arg0 context.Context, arg1 net.IP, arg2 time.Time, arg3, arg4 int) ([]models.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsByRemoteIP", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]models.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsByRemoteIP indicates an expected call of LoadFailedAuthenticationLogsByRemoteIP.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsByRemoteIP(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsByRemoteIP", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsByRemoteIP), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadFailedAuthenticationLogsBySubnet mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsBySubnet(arg0 context.Context, arg1 *net.IPNet, arg2 time.Time, arg3, arg4 int) ([]models.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsBySubnet", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]models.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsBySubnet indicates an expected call of LoadFailedAuthenticationLogsBySubnet.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsBySubnet(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsBySubnet", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsBySubnet), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadIdentityVerificationTimesByIssuedIP mocks base method.
+func (m *MockStorage) LoadIdentityVerificationTimesByIssuedIP(arg0 context.Context, arg1 net.IP, arg2 time.Time, arg3 int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadIdentityVerificationTimesByIssuedIP", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadIdentityVerificationTimesByIssuedIP indicates an expected call of LoadIdentityVerificationTimesByIssuedIP.
+func (mr *MockStorageMockRecorder) LoadIdentityVerificationTimesByIssuedIP(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadIdentityVerificationTimesByIssuedIP", reflect.TypeOf((*MockStorage)(nil).LoadIdentityVerificationTimesByIssuedIP), arg0, arg1, arg2, arg3)
+}
+
+// LoadIdentityVerificationTimesByUsername mocks base method.
+func (m *MockStorage) LoadIdentityVerificationTimesByUsername(arg0 context.Context, arg1 string, arg2 time.Time, arg3 int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadIdentityVerificationTimesByUsername", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadIdentityVerificationTimesByUsername indicates an expected call of LoadIdentityVerificationTimesByUsername.
+func (mr *MockStorageMockRecorder) LoadIdentityVerificationTimesByUsername(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadIdentityVerificationTimesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadIdentityVerificationTimesByUsername), arg0, arg1, arg2, arg3)
+}
+
 // LoadPreferred2FAMethod mocks base method.
 func (m *MockStorage) LoadPreferred2FAMethod(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -167,6 +420,66 @@ func (mr *MockStorageMockRecorder) LoadPreferredDuoDevice(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).LoadPreferredDuoDevice), arg0, arg1)
 }
 
+// LoadRegulationBans mocks base method.
+func (m *MockStorage) LoadRegulationBans(arg0 context.Context, arg1, arg2 string, arg3 time.Time) ([]models.RegulationBan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationBans", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]models.RegulationBan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationBans indicates an expected call of LoadRegulationBans.
+func (mr *MockStorageMockRecorder) LoadRegulationBans(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationBans", reflect.TypeOf((*MockStorage)(nil).LoadRegulationBans), arg0, arg1, arg2, arg3)
+}
+
+// LoadSessionData mocks base method.
+func (m *MockStorage) LoadSessionData(arg0 context.Context, arg1 string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSessionData", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSessionData indicates an expected call of LoadSessionData.
+func (mr *MockStorageMockRecorder) LoadSessionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSessionData", reflect.TypeOf((*MockStorage)(nil).LoadSessionData), arg0, arg1)
+}
+
+// LoadSessionGeneration mocks base method.
+func (m *MockStorage) LoadSessionGeneration(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSessionGeneration", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSessionGeneration indicates an expected call of LoadSessionGeneration.
+func (mr *MockStorageMockRecorder) LoadSessionGeneration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSessionGeneration", reflect.TypeOf((*MockStorage)(nil).LoadSessionGeneration), arg0, arg1)
+}
+
+// LoadSessionInventoryRecords mocks base method.
+func (m *MockStorage) LoadSessionInventoryRecords(arg0 context.Context, arg1 string) ([]models.SessionInventoryRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSessionInventoryRecords", arg0, arg1)
+	ret0, _ := ret[0].([]models.SessionInventoryRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSessionInventoryRecords indicates an expected call of LoadSessionInventoryRecords.
+func (mr *MockStorageMockRecorder) LoadSessionInventoryRecords(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSessionInventoryRecords", reflect.TypeOf((*MockStorage)(nil).LoadSessionInventoryRecords), arg0, arg1)
+}
+
 // LoadTOTPConfiguration mocks base method.
 func (m *MockStorage) LoadTOTPConfiguration(arg0 context.Context, arg1 string) (*models.TOTPConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -242,6 +555,50 @@ func (mr *MockStorageMockRecorder) LoadUserInfo(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserInfo", reflect.TypeOf((*MockStorage)(nil).LoadUserInfo), arg0, arg1)
 }
 
+// PruneData mocks base method.
+func (m *MockStorage) PruneData(arg0 context.Context, arg1 models.PruneOptions) (models.PruneResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneData", arg0, arg1)
+	ret0, _ := ret[0].(models.PruneResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneData indicates an expected call of PruneData.
+func (mr *MockStorageMockRecorder) PruneData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneData", reflect.TypeOf((*MockStorage)(nil).PruneData), arg0, arg1)
+}
+
+// RenameSessionData mocks base method.
+func (m *MockStorage) RenameSessionData(arg0 context.Context, arg1, arg2 string, arg3 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameSessionData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameSessionData indicates an expected call of RenameSessionData.
+func (mr *MockStorageMockRecorder) RenameSessionData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameSessionData", reflect.TypeOf((*MockStorage)(nil).RenameSessionData), arg0, arg1, arg2, arg3)
+}
+
+// RevokeRegulationBans mocks base method.
+func (m *MockStorage) RevokeRegulationBans(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRegulationBans", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeRegulationBans indicates an expected call of RevokeRegulationBans.
+func (mr *MockStorageMockRecorder) RevokeRegulationBans(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRegulationBans", reflect.TypeOf((*MockStorage)(nil).RevokeRegulationBans), arg0, arg1, arg2, arg3)
+}
+
 // SaveIdentityVerification mocks base method.
 func (m *MockStorage) SaveIdentityVerification(arg0 context.Context, arg1 models.IdentityVerification) error {
 	m.ctrl.T.Helper()
@@ -284,6 +641,48 @@ func (mr *MockStorageMockRecorder) SavePreferredDuoDevice(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).SavePreferredDuoDevice), arg0, arg1)
 }
 
+// SaveRegulationBan mocks base method.
+func (m *MockStorage) SaveRegulationBan(arg0 context.Context, arg1 models.RegulationBan) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRegulationBan", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRegulationBan indicates an expected call of SaveRegulationBan.
+func (mr *MockStorageMockRecorder) SaveRegulationBan(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRegulationBan", reflect.TypeOf((*MockStorage)(nil).SaveRegulationBan), arg0, arg1)
+}
+
+// SaveSessionData mocks base method.
+func (m *MockStorage) SaveSessionData(arg0 context.Context, arg1 string, arg2 []byte, arg3 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSessionData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSessionData indicates an expected call of SaveSessionData.
+func (mr *MockStorageMockRecorder) SaveSessionData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionData", reflect.TypeOf((*MockStorage)(nil).SaveSessionData), arg0, arg1, arg2, arg3)
+}
+
+// SaveSessionInventoryRecord mocks base method.
+func (m *MockStorage) SaveSessionInventoryRecord(arg0 context.Context, arg1 models.SessionInventoryRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSessionInventoryRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSessionInventoryRecord indicates an expected call of SaveSessionInventoryRecord.
+func (mr *MockStorageMockRecorder) SaveSessionInventoryRecord(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionInventoryRecord", reflect.TypeOf((*MockStorage)(nil).SaveSessionInventoryRecord), arg0, arg1)
+}
+
 // SaveTOTPConfiguration mocks base method.
 func (m *MockStorage) SaveTOTPConfiguration(arg0 context.Context, arg1 models.TOTPConfiguration) error {
 	m.ctrl.T.Helper()
@@ -340,6 +739,21 @@ func (mr *MockStorageMockRecorder) SchemaEncryptionCheckKey(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaEncryptionCheckKey", reflect.TypeOf((*MockStorage)(nil).SchemaEncryptionCheckKey), arg0, arg1)
 }
 
+// SchemaEncryptionReencrypt mocks base method.
+func (m *MockStorage) SchemaEncryptionReencrypt(arg0 context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaEncryptionReencrypt", arg0)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaEncryptionReencrypt indicates an expected call of SchemaEncryptionReencrypt.
+func (mr *MockStorageMockRecorder) SchemaEncryptionReencrypt(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaEncryptionReencrypt", reflect.TypeOf((*MockStorage)(nil).SchemaEncryptionReencrypt), arg0)
+}
+
 // SchemaLatestVersion mocks base method.
 func (m *MockStorage) SchemaLatestVersion() (int, error) {
 	m.ctrl.T.Helper()
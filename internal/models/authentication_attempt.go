@@ -13,6 +13,8 @@ type AuthenticationAttempt struct {
 	Username      string    `db:"username"`
 	Type          string    `db:"auth_type"`
 	RemoteIP      NullIP    `db:"remote_ip"`
+	Country       string    `db:"country"`
+	ASN           uint      `db:"asn"`
 	RequestURI    string    `db:"request_uri"`
 	RequestMethod string    `db:"request_method"`
 }
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+)
+
+// PruneOptions represents the rows to prune from the storage. The rows of a table which are older than its time are
+// pruned, the rows of a table with a zero time are all kept.
+type PruneOptions struct {
+	AuthenticationLogs   time.Time
+	IdentityVerification time.Time
+	RegulationBans       time.Time
+
+	BatchSize int
+	DryRun    bool
+}
+
+// PruneResult represents the number of rows pruned from each table, or which would be pruned for a dry run.
+type PruneResult struct {
+	AuthenticationLogs   int64
+	IdentityVerification int64
+	RegulationBans       int64
+}
+
+// Total returns the number of rows pruned from every table.
+func (r PruneResult) Total() int64 {
+	return r.AuthenticationLogs + r.IdentityVerification + r.RegulationBans
+}
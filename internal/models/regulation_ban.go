@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// RegulationBan represents a regulation ban row in the database.
+type RegulationBan struct {
+	ID        int        `db:"id"`
+	Time      time.Time  `db:"time"`
+	Expires   time.Time  `db:"expires"`
+	Revoked   bool       `db:"revoked"`
+	RevokedAt *time.Time `db:"revoked_at"`
+	Type      string     `db:"ban_type"`
+	Value     string     `db:"ban_value"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+)
+
+// SessionInventoryRecord represents a session inventory row in the database.
+type SessionInventoryRecord struct {
+	ID        int       `db:"id"`
+	RecordID  string    `db:"record_id"`
+	SessionID string    `db:"session_id"`
+	Username  string    `db:"username"`
+	RemoteIP  string    `db:"remote_ip"`
+	UserAgent string    `db:"user_agent"`
+	CreatedAt time.Time `db:"created_at"`
+}
@@ -7,3 +7,23 @@ const (
 const (
 	rfc5322DateTimeLayout = "Mon, 2 Jan 2006 15:04:05 -0700"
 )
+
+const (
+	// SecurityEventPasswordChanged is the security event of the password of a user being changed.
+	SecurityEventPasswordChanged = "password_changed"
+
+	// SecurityEventDeviceRegistered is the security event of a second factor device being registered by a user.
+	SecurityEventDeviceRegistered = "device_registered"
+
+	// SecurityEventDeviceRemoved is the security event of a second factor device of a user being removed.
+	SecurityEventDeviceRemoved = "device_removed"
+
+	// SecurityEventNewLogin is the security event of a user logging in from a new IP address or device.
+	SecurityEventNewLogin = "new_login"
+
+	// SecurityEventAccountBanned is the security event of a user being temporarily banned by the regulator.
+	SecurityEventAccountBanned = "account_banned"
+)
+
+// securityEventsMaxSending is the maximum number of security event notifications sent in the background at once.
+const securityEventsMaxSending = 32
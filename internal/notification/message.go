@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// composeMessage builds a multipart MIME email with the plain text body and, if provided, the HTML body.
+func composeMessage(sender, recipient, subject, body, htmlBody string) string {
+	boundary := utils.RandomString(30, utils.AlphaNumericCharacters, true)
+
+	now := time.Now()
+
+	msg := "Date:" + now.Format(rfc5322DateTimeLayout) + "\n" +
+		"From: " + sender + "\n" +
+		"To: " + recipient + "\n" +
+		"Subject: " + subject + "\n" +
+		"MIME-version: 1.0\n" +
+		"Content-Type: multipart/alternative; boundary=" + boundary + "\n\n" +
+		"--" + boundary + "\n" +
+		"Content-Type: text/plain; charset=\"UTF-8\"\n" +
+		"Content-Transfer-Encoding: quoted-printable\n" +
+		"Content-Disposition: inline\n\n" +
+		body + "\n"
+
+	if htmlBody != "" {
+		msg += "--" + boundary + "\n" +
+			"Content-Type: text/html; charset=\"UTF-8\"\n\n" +
+			htmlBody + "\n"
+	}
+
+	msg += "--" + boundary + "--"
+
+	return msg
+}
@@ -0,0 +1,258 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewSecurityEventNotifier creates a SecurityEventNotifier which renders the notifications with the templates and
+// sends them to the email address of the user retrieved from the user provider using the notifier.
+func NewSecurityEventNotifier(configuration *schema.NotifierConfiguration, notifier Notifier, templates *templates.Provider, users authentication.UserProvider) *SecurityEventNotifier {
+	lookback, _ := utils.ParseDurationString(configuration.SecurityEvents.NewLoginLookback)
+
+	return &SecurityEventNotifier{
+		configuration: configuration.SecurityEvents,
+		lookback:      lookback,
+		html:          !HTMLEmailsDisabled(configuration),
+		notifier:      notifier,
+		templates:     templates,
+		users:         users,
+		log:           logging.Logger(),
+		sending:       make(chan struct{}, securityEventsMaxSending),
+		bans:          map[string]time.Time{},
+	}
+}
+
+// HTMLEmailsDisabled returns true if the configured notifier only sends plain text emails.
+func HTMLEmailsDisabled(configuration *schema.NotifierConfiguration) bool {
+	if configuration == nil {
+		return false
+	}
+
+	switch {
+	case configuration.SMTP != nil:
+		return configuration.SMTP.DisableHTMLEmails
+	case configuration.Sendmail != nil:
+		return configuration.Sendmail.DisableHTMLEmails
+	default:
+		return false
+	}
+}
+
+// Enabled returns true if the notifications of the event type are enabled.
+func (n *SecurityEventNotifier) Enabled(eventType string) bool {
+	if n.configuration.Disable {
+		return false
+	}
+
+	switch eventType {
+	case SecurityEventPasswordChanged:
+		return !n.configuration.DisablePasswordChanged
+	case SecurityEventDeviceRegistered:
+		return !n.configuration.DisableDeviceRegistered
+	case SecurityEventDeviceRemoved:
+		return !n.configuration.DisableDeviceRemoved
+	case SecurityEventNewLogin:
+		return !n.configuration.DisableNewLogin
+	case SecurityEventAccountBanned:
+		return !n.configuration.DisableAccountBanned
+	default:
+		return false
+	}
+}
+
+// NewLoginLookback returns the duration a login from a remote IP is remembered for, a login from a remote IP which the
+// user has not logged in from within this duration is considered a new login.
+func (n *SecurityEventNotifier) NewLoginLookback() time.Duration {
+	return n.lookback
+}
+
+// Notify sends the notification of the event to the user if the notifications of the event type are enabled.
+func (n *SecurityEventNotifier) Notify(event SecurityEvent) (err error) {
+	if !n.Enabled(event.Type) {
+		return nil
+	}
+
+	details, err := n.users.GetDetails(event.Username)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve the details of user '%s': %w", event.Username, err)
+	}
+
+	if len(details.Emails) == 0 {
+		return fmt.Errorf("user '%s' has no email address configured", event.Username)
+	}
+
+	values := templates.EmailSecurityEventValues{
+		Event:       event.Type,
+		DisplayName: details.DisplayName,
+		Username:    details.Username,
+		Device:      event.Device,
+		UserAgent:   event.UserAgent,
+		Time:        event.Time,
+		BannedUntil: event.BannedUntil,
+	}
+
+	if event.RemoteIP != nil {
+		values.RemoteIP = event.RemoteIP.String()
+	}
+
+	values.Title, values.Description = securityEventText(event)
+
+	email, err := n.templates.ExecuteSecurityEvent(values, n.html, append([]string{details.Locale}, event.Preferences...)...)
+	if err != nil {
+		return err
+	}
+
+	n.log.Debugf("Sending an email to user %s (%s) to notify them of the %s security event", event.Username, details.Emails[0], event.Type)
+
+	return n.notifier.Send(details.Emails[0], email.Title, email.Text, email.HTML)
+}
+
+// NotifyAsync sends the notification of the event in the background so sending it doesn't delay the request which
+// caused the event. Failing to send the notification is logged, and the notification is dropped when too many
+// notifications are already being sent.
+func (n *SecurityEventNotifier) NotifyAsync(event SecurityEvent) {
+	if !n.Enabled(event.Type) {
+		return
+	}
+
+	select {
+	case n.sending <- struct{}{}:
+	default:
+		n.log.Errorf("Unable to notify user '%s' of the %s security event: too many notifications are already being sent", event.Username, event.Type)
+
+		return
+	}
+
+	n.wg.Add(1)
+
+	go func() {
+		defer func() {
+			<-n.sending
+			n.wg.Done()
+		}()
+
+		if err := n.Notify(event); err != nil {
+			n.log.Errorf("Unable to notify user '%s' of the %s security event: %+v", event.Username, event.Type, err)
+		}
+	}()
+}
+
+// Wait waits until the notifications sent in the background have been sent.
+func (n *SecurityEventNotifier) Wait() {
+	n.wg.Wait()
+}
+
+// NotifyUserBan notifies the user they have been banned until the given time in the background, it implements the
+// regulation.UserBanHandler. The user is only notified once while they're banned even if they're banned several times,
+// for instance when concurrent attempts reach the limit at once or both the user and TOTP limits are reached.
+func (n *SecurityEventNotifier) NotifyUserBan(_ context.Context, username string, until time.Time) {
+	now := time.Now()
+
+	n.bansMutex.Lock()
+
+	for banned, bannedUntil := range n.bans {
+		if !bannedUntil.After(now) {
+			delete(n.bans, banned)
+		}
+	}
+
+	_, notified := n.bans[username]
+	if !notified {
+		n.bans[username] = until
+	}
+
+	n.bansMutex.Unlock()
+
+	if notified {
+		n.log.Debugf("Not notifying user '%s' of the %s security event as they have already been notified of their ban", username, SecurityEventAccountBanned)
+
+		return
+	}
+
+	n.NotifyAsync(SecurityEvent{
+		Type:        SecurityEventAccountBanned,
+		Username:    username,
+		Time:        now,
+		BannedUntil: until,
+	})
+}
+
+func securityEventText(event SecurityEvent) (title, description string) {
+	switch event.Type {
+	case SecurityEventPasswordChanged:
+		return "Your password has been changed", "The password of your account was changed."
+	case SecurityEventDeviceRegistered:
+		return fmt.Sprintf("A new %s device has been registered", event.Device),
+			fmt.Sprintf("A new %s second factor device was registered to your account.", event.Device)
+	case SecurityEventDeviceRemoved:
+		return fmt.Sprintf("Your %s device has been removed", event.Device),
+			fmt.Sprintf("The %s second factor device of your account was removed.", event.Device)
+	case SecurityEventNewLogin:
+		return "New sign in to your account", "Your account was signed in to from a new IP address or device."
+	case SecurityEventAccountBanned:
+		return "Your account has been temporarily locked",
+			fmt.Sprintf("Your account was temporarily locked until %s due to too many failed authentication attempts.",
+				event.BannedUntil.UTC().Format("2006-01-02 15:04:05 MST"))
+	default:
+		return event.Type, event.Type
+	}
+}
+
+// SecurityEventNotifier sends notifications to users about security relevant changes to their accounts.
+type SecurityEventNotifier struct {
+	configuration schema.SecurityEventsNotifierConfiguration
+	lookback      time.Duration
+	html          bool
+
+	notifier  Notifier
+	templates *templates.Provider
+	users     authentication.UserProvider
+
+	// sending limits the number of notifications sent in the background at once.
+	sending chan struct{}
+	wg      sync.WaitGroup
+
+	// bans are the users who have been notified of their ban and when the ban ends.
+	bans      map[string]time.Time
+	bansMutex sync.Mutex
+
+	log *logrus.Logger
+}
+
+// SecurityEvent is a security relevant change to the account of a user.
+type SecurityEvent struct {
+	// Type is the type of the event, one of the SecurityEvent constants.
+	Type string
+
+	// Username is the username of the user the event relates to.
+	Username string
+
+	// Device is the type of the second factor device for the device events.
+	Device string
+
+	// RemoteIP and UserAgent identify the client which caused the event if any.
+	RemoteIP  net.IP
+	UserAgent string
+
+	// Time is when the event happened.
+	Time time.Time
+
+	// BannedUntil is when the ban ends for the account banned event.
+	BannedUntil time.Time
+
+	// Preferences are the locale preferences of the client which caused the event such as the Accept-Language header,
+	// the locale of the user takes precedence over them.
+	Preferences []string
+}
@@ -0,0 +1,156 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+type testSentEmail struct {
+	recipient, subject, body, htmlBody string
+}
+
+type testNotifier struct {
+	sent []testSentEmail
+}
+
+func (n *testNotifier) StartupCheck() error {
+	return nil
+}
+
+func (n *testNotifier) Send(recipient, subject, body, htmlBody string) error {
+	n.sent = append(n.sent, testSentEmail{recipient, subject, body, htmlBody})
+
+	return nil
+}
+
+type testUserProvider struct {
+	authentication.UserProvider
+
+	users map[string]*authentication.UserDetails
+}
+
+func (p *testUserProvider) GetDetails(username string) (*authentication.UserDetails, error) {
+	if details, ok := p.users[username]; ok {
+		return details, nil
+	}
+
+	return nil, errors.New("user not found")
+}
+
+func newTestSecurityEventNotifier(t *testing.T, configuration *schema.NotifierConfiguration) (*SecurityEventNotifier, *testNotifier) {
+	provider, err := templates.NewProvider("", "en")
+	require.NoError(t, err)
+
+	notifier := &testNotifier{}
+
+	users := &testUserProvider{users: map[string]*authentication.UserDetails{
+		"john": {Username: "john", DisplayName: "John Doe", Emails: []string{"john@example.com", "jd@example.com"}},
+		"bob":  {Username: "bob", DisplayName: "Bob Dylan"},
+	}}
+
+	return NewSecurityEventNotifier(configuration, notifier, provider, users), notifier
+}
+
+func TestShouldSendSecurityEventNotifications(t *testing.T) {
+	configuration := &schema.NotifierConfiguration{
+		SecurityEvents: schema.SecurityEventsNotifierConfiguration{NewLoginLookback: "30d"},
+	}
+
+	securityEvents, notifier := newTestSecurityEventNotifier(t, configuration)
+
+	assert.Equal(t, 30*24*time.Hour, securityEvents.NewLoginLookback())
+
+	require.NoError(t, securityEvents.Notify(SecurityEvent{
+		Type:      SecurityEventDeviceRegistered,
+		Username:  "john",
+		Device:    "TOTP",
+		RemoteIP:  net.ParseIP("192.168.1.10"),
+		UserAgent: "Mozilla/5.0",
+		Time:      time.Date(2021, 12, 1, 10, 30, 0, 0, time.UTC),
+	}))
+
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "john@example.com", notifier.sent[0].recipient)
+	assert.Equal(t, "A new TOTP device has been registered", notifier.sent[0].subject)
+	assert.Contains(t, notifier.sent[0].body, "Hi John Doe,")
+	assert.Contains(t, notifier.sent[0].body, "A new TOTP second factor device was registered to your account.")
+	assert.Contains(t, notifier.sent[0].body, "IP Address: 192.168.1.10\nBrowser: Mozilla/5.0")
+	assert.Contains(t, notifier.sent[0].htmlBody, "<h1>A new TOTP device has been registered</h1>")
+
+	securityEvents.NotifyUserBan(context.Background(), "john", time.Date(2021, 12, 1, 10, 35, 0, 0, time.UTC))
+	securityEvents.Wait()
+
+	require.Len(t, notifier.sent, 2)
+	assert.Equal(t, "Your account has been temporarily locked", notifier.sent[1].subject)
+	assert.Contains(t, notifier.sent[1].body, "locked until 2021-12-01 10:35:00 UTC")
+	assert.NotContains(t, notifier.sent[1].body, "IP Address:")
+}
+
+func TestShouldOnlyNotifyUserOnceWhileBanned(t *testing.T) {
+	securityEvents, notifier := newTestSecurityEventNotifier(t, &schema.NotifierConfiguration{})
+
+	until := time.Now().Add(time.Hour)
+
+	securityEvents.NotifyUserBan(context.Background(), "john", until)
+	securityEvents.NotifyUserBan(context.Background(), "john", until.Add(time.Minute))
+	securityEvents.Wait()
+
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "Your account has been temporarily locked", notifier.sent[0].subject)
+
+	// The user is notified again of a ban once the previous ban has ended.
+	securityEvents.bans["john"] = time.Now().Add(-time.Second)
+
+	securityEvents.NotifyUserBan(context.Background(), "john", until)
+	securityEvents.Wait()
+
+	assert.Len(t, notifier.sent, 2)
+}
+
+func TestShouldNotSendDisabledSecurityEventNotifications(t *testing.T) {
+	configuration := &schema.NotifierConfiguration{
+		SMTP:           &schema.SMTPNotifierConfiguration{DisableHTMLEmails: true, Sender: mail.Address{Address: "admin@example.com"}},
+		SecurityEvents: schema.SecurityEventsNotifierConfiguration{DisableNewLogin: true},
+	}
+
+	securityEvents, notifier := newTestSecurityEventNotifier(t, configuration)
+
+	assert.False(t, securityEvents.Enabled(SecurityEventNewLogin))
+	assert.True(t, securityEvents.Enabled(SecurityEventPasswordChanged))
+	assert.False(t, securityEvents.Enabled("unknown"))
+
+	require.NoError(t, securityEvents.Notify(SecurityEvent{Type: SecurityEventNewLogin, Username: "john"}))
+	assert.Len(t, notifier.sent, 0)
+
+	require.NoError(t, securityEvents.Notify(SecurityEvent{Type: SecurityEventPasswordChanged, Username: "john"}))
+	require.Len(t, notifier.sent, 1)
+	assert.Equal(t, "", notifier.sent[0].htmlBody)
+
+	configuration.SecurityEvents.Disable = true
+
+	securityEvents, _ = newTestSecurityEventNotifier(t, configuration)
+
+	assert.False(t, securityEvents.Enabled(SecurityEventPasswordChanged))
+}
+
+func TestShouldFailToSendSecurityEventNotificationWithoutEmail(t *testing.T) {
+	securityEvents, notifier := newTestSecurityEventNotifier(t, &schema.NotifierConfiguration{})
+
+	assert.EqualError(t, securityEvents.Notify(SecurityEvent{Type: SecurityEventPasswordChanged, Username: "bob"}),
+		"user 'bob' has no email address configured")
+	assert.EqualError(t, securityEvents.Notify(SecurityEvent{Type: SecurityEventPasswordChanged, Username: "alice"}),
+		"unable to retrieve the details of user 'alice': user not found")
+
+	assert.Len(t, notifier.sent, 0)
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// SendmailNotifier a notifier to send emails using a sendmail compatible local command.
+type SendmailNotifier struct {
+	configuration *schema.SendmailNotifierConfiguration
+	log           *logrus.Logger
+}
+
+// NewSendmailNotifier creates a SendmailNotifier using the notifier configuration.
+func NewSendmailNotifier(configuration *schema.SendmailNotifierConfiguration) *SendmailNotifier {
+	return &SendmailNotifier{
+		configuration: configuration,
+		log:           logging.Logger(),
+	}
+}
+
+// StartupCheck implements the startup check provider interface.
+func (n *SendmailNotifier) StartupCheck() (err error) {
+	path, err := exec.LookPath(n.configuration.Path)
+	if err != nil {
+		return fmt.Errorf("notifier sendmail command '%s' could not be found: %w", n.configuration.Path, err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	if info.IsDir() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("notifier sendmail command '%s' is not executable", path)
+	}
+
+	return nil
+}
+
+// Send is used to send an email to a recipient.
+func (n *SendmailNotifier) Send(recipient, title, body, htmlBody string) error {
+	subject := strings.ReplaceAll(n.configuration.Subject, "{title}", title)
+
+	msg := composeMessage(n.configuration.Sender.String(), recipient, subject, body, htmlBody)
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.configuration.Timeout)
+	defer cancel()
+
+	//nolint:gosec // The command and its arguments are only sourced from the administrator controlled configuration.
+	cmd := exec.CommandContext(ctx, n.configuration.Path, n.configuration.Args...)
+
+	stderr := new(bytes.Buffer)
+
+	cmd.Stdin = strings.NewReader(msg)
+	cmd.Stderr = stderr
+
+	n.log.Debugf("Notifier sendmail attempting to send email to %s using command %s", recipient, n.configuration.Path)
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("notifier sendmail command timed out after %s", n.configuration.Timeout)
+		}
+
+		return fmt.Errorf("notifier sendmail command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	n.log.Debug("Notifier sendmail successfully sent email")
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestShouldSendEmailWithSendmailCommand(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "mail.txt")
+
+	notifier := NewSendmailNotifier(&schema.SendmailNotifierConfiguration{
+		Path:    "/bin/sh",
+		Args:    []string{"-c", "cat > " + output},
+		Timeout: time.Second * 5,
+		Sender:  mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+		Subject: "[Authelia] {title}",
+	})
+
+	require.NoError(t, notifier.Send("john@example.com", "Reset your password", "text body", "<p>html body</p>"))
+
+	content, err := os.ReadFile(output)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(content), "From: \"Authelia\" <authelia@example.com>\n")
+	assert.Contains(t, string(content), "To: john@example.com\n")
+	assert.Contains(t, string(content), "Subject: [Authelia] Reset your password\n")
+	assert.Contains(t, string(content), "text body\n")
+	assert.Contains(t, string(content), "<p>html body</p>\n")
+}
+
+func TestShouldReturnSendmailCommandError(t *testing.T) {
+	notifier := NewSendmailNotifier(&schema.SendmailNotifierConfiguration{
+		Path:    "/bin/sh",
+		Args:    []string{"-c", "echo 'No recipients' >&2; exit 75"},
+		Timeout: time.Second * 5,
+	})
+
+	assert.EqualError(t, notifier.Send("john@example.com", "title", "text", ""), "notifier sendmail command failed: exit status 75: No recipients")
+}
+
+func TestShouldTimeoutSendmailCommand(t *testing.T) {
+	notifier := NewSendmailNotifier(&schema.SendmailNotifierConfiguration{
+		Path:    "/bin/sh",
+		Args:    []string{"-c", "exec sleep 5"},
+		Timeout: time.Millisecond * 50,
+	})
+
+	assert.EqualError(t, notifier.Send("john@example.com", "title", "text", ""), "notifier sendmail command timed out after 50ms")
+}
+
+func TestShouldRunSendmailStartupCheck(t *testing.T) {
+	notifier := NewSendmailNotifier(&schema.SendmailNotifierConfiguration{Path: "/bin/sh"})
+
+	assert.NoError(t, notifier.StartupCheck())
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "sendmail")
+
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"), 0600))
+
+	notifier = NewSendmailNotifier(&schema.SendmailNotifierConfiguration{Path: path})
+	assert.EqualError(t, notifier.StartupCheck(), "notifier sendmail command '"+path+"' could not be found: exec: \""+path+"\": permission denied")
+
+	notifier = NewSendmailNotifier(&schema.SendmailNotifierConfiguration{Path: filepath.Join(dir, "missing")})
+	assert.Error(t, notifier.StartupCheck())
+}
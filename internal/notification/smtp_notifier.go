@@ -14,6 +14,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -132,29 +133,7 @@ func (n *SMTPNotifier) compose(recipient, subject, body, htmlBody string) error
 		return err
 	}
 
-	boundary := utils.RandomString(30, utils.AlphaNumericCharacters, true)
-
-	now := time.Now()
-
-	msg := "Date:" + now.Format(rfc5322DateTimeLayout) + "\n" +
-		"From: " + n.configuration.Sender.String() + "\n" +
-		"To: " + recipient + "\n" +
-		"Subject: " + subject + "\n" +
-		"MIME-version: 1.0\n" +
-		"Content-Type: multipart/alternative; boundary=" + boundary + "\n\n" +
-		"--" + boundary + "\n" +
-		"Content-Type: text/plain; charset=\"UTF-8\"\n" +
-		"Content-Transfer-Encoding: quoted-printable\n" +
-		"Content-Disposition: inline\n\n" +
-		body + "\n"
-
-	if htmlBody != "" {
-		msg += "--" + boundary + "\n" +
-			"Content-Type: text/html; charset=\"UTF-8\"\n\n" +
-			htmlBody + "\n"
-	}
-
-	msg += "--" + boundary + "--"
+	msg := composeMessage(n.configuration.Sender.String(), recipient, subject, body, htmlBody)
 
 	_, err = fmt.Fprint(wc, msg)
 	if err != nil {
@@ -251,7 +230,13 @@ func (n *SMTPNotifier) StartupCheck() (err error) {
 }
 
 // Send is used to send an email to a recipient.
-func (n *SMTPNotifier) Send(recipient, title, body, htmlBody string) error {
+func (n *SMTPNotifier) Send(recipient, title, body, htmlBody string) (err error) {
+	started := time.Now()
+
+	defer func() {
+		metrics.ObserveBackend(metrics.BackendSMTP, "Send", time.Since(started), err)
+	}()
+
 	subject := strings.ReplaceAll(n.configuration.Subject, "{title}", title)
 
 	if err := n.dial(); err != nil {
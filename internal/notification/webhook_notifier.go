@@ -0,0 +1,169 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// WebhookNotifier a notifier to send notifications as JSON to a HTTP endpoint.
+type WebhookNotifier struct {
+	configuration *schema.WebhookNotifierConfiguration
+	client        *http.Client
+	fields        []*template.Template
+	err           error
+	log           *logrus.Logger
+}
+
+// NewWebhookNotifier creates a WebhookNotifier using the notifier configuration.
+func NewWebhookNotifier(configuration *schema.WebhookNotifierConfiguration, certPool *x509.CertPool) *WebhookNotifier {
+	notifier := &WebhookNotifier{
+		configuration: configuration,
+		client: &http.Client{
+			Timeout: configuration.Timeout,
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: utils.NewTLSConfig(configuration.TLS, tls.VersionTLS12, certPool),
+			},
+		},
+		log: logging.Logger(),
+	}
+
+	for _, field := range configuration.Fields {
+		tmpl, err := template.New(field.Name).Parse(field.Template)
+		if err != nil {
+			notifier.err = fmt.Errorf("notifier webhook field '%s' has an invalid template: %w", field.Name, err)
+
+			break
+		}
+
+		notifier.fields = append(notifier.fields, tmpl)
+	}
+
+	return notifier
+}
+
+// StartupCheck implements the startup check provider interface.
+func (n *WebhookNotifier) StartupCheck() (err error) {
+	if n.err != nil {
+		return n.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.configuration.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, n.configuration.URL, nil)
+	if err != nil {
+		return err
+	}
+
+	n.setHeaders(req)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	// The endpoint is only expected to accept the configured method, so any response other than a server error shows
+	// it's reachable.
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("notifier webhook endpoint responded to the startup check with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Send is used to send a notification to a recipient.
+func (n *WebhookNotifier) Send(recipient, title, body, htmlBody string) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	payload, err := n.payload(webhookTemplateData{
+		Recipient: recipient,
+		Title:     title,
+		Body:      body,
+		HTMLBody:  htmlBody,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), n.configuration.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, n.configuration.Method, n.configuration.URL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	n.setHeaders(req)
+
+	n.log.Debugf("Notifier webhook client attempting to send notification to %s", recipient)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		content, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("notifier webhook endpoint responded with status code %d: %s", resp.StatusCode, strings.TrimSpace(string(content)))
+	}
+
+	n.log.Debug("Notifier webhook client successfully sent notification")
+
+	return nil
+}
+
+func (n *WebhookNotifier) setHeaders(req *http.Request) {
+	for _, header := range n.configuration.Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+}
+
+func (n *WebhookNotifier) payload(data webhookTemplateData) (payload []byte, err error) {
+	fields := make(map[string]string, len(n.fields))
+
+	buf := new(bytes.Buffer)
+
+	for _, tmpl := range n.fields {
+		buf.Reset()
+
+		if err = tmpl.Execute(buf, data); err != nil {
+			return nil, fmt.Errorf("notifier webhook failed to execute the template for field '%s': %w", tmpl.Name(), err)
+		}
+
+		fields[tmpl.Name()] = buf.String()
+	}
+
+	return json.Marshal(fields)
+}
+
+// webhookTemplateData is the data available to the webhook field templates.
+type webhookTemplateData struct {
+	Recipient string
+	Title     string
+	Body      string
+	HTMLBody  string
+}
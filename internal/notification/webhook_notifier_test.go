@@ -0,0 +1,120 @@
+package notification
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newTestWebhookConfiguration(url string) *schema.WebhookNotifierConfiguration {
+	return &schema.WebhookNotifierConfiguration{
+		URL:     url,
+		Method:  http.MethodPost,
+		Timeout: time.Second,
+		Headers: []schema.WebhookHeaderConfiguration{
+			{Name: "Authorization", Value: "Bearer abc123"},
+		},
+		Fields: schema.DefaultWebhookNotifierConfiguration.Fields,
+		TLS:    schema.DefaultWebhookNotifierConfiguration.TLS,
+	}
+}
+
+func TestShouldSendWebhookNotification(t *testing.T) {
+	var (
+		method, contentType, authorization string
+		payload                            map[string]string
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, contentType, authorization = r.Method, r.Header.Get("Content-Type"), r.Header.Get("Authorization")
+
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &payload)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfiguration(server.URL), nil)
+
+	require.NoError(t, notifier.Send("john@example.com", "Reset your password", "text", "<p>html</p>"))
+
+	assert.Equal(t, http.MethodPost, method)
+	assert.Equal(t, "application/json", contentType)
+	assert.Equal(t, "Bearer abc123", authorization)
+	assert.Equal(t, map[string]string{
+		"recipient": "john@example.com",
+		"subject":   "[Authelia] Reset your password",
+		"body":      "text",
+		"html_body": "<p>html</p>",
+	}, payload)
+}
+
+func TestShouldReturnErrorOnWebhookFailureStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte("invalid recipient"))
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfiguration(server.URL), nil)
+
+	assert.EqualError(t, notifier.Send("john@example.com", "title", "text", ""), "notifier webhook endpoint responded with status code 400: invalid recipient")
+}
+
+func TestShouldTimeoutWebhookNotification(t *testing.T) {
+	done := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	config := newTestWebhookConfiguration(server.URL)
+	config.Timeout = time.Millisecond * 50
+
+	notifier := NewWebhookNotifier(config, nil)
+
+	assert.Error(t, notifier.Send("john@example.com", "title", "text", ""))
+}
+
+func TestShouldRunWebhookStartupCheck(t *testing.T) {
+	status := http.StatusMethodNotAllowed
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodHead, r.Method)
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfiguration(server.URL), nil)
+
+	assert.NoError(t, notifier.StartupCheck())
+
+	status = http.StatusBadGateway
+
+	assert.EqualError(t, notifier.StartupCheck(), "notifier webhook endpoint responded to the startup check with status code 502")
+}
+
+func TestShouldFailWebhookStartupCheckOnInvalidTemplate(t *testing.T) {
+	config := newTestWebhookConfiguration("http://127.0.0.1:9")
+	config.Fields = []schema.WebhookFieldConfiguration{{Name: "subject", Template: "{{ .Title "}}
+
+	notifier := NewWebhookNotifier(config, nil)
+
+	err := notifier.StartupCheck()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notifier webhook field 'subject' has an invalid template: ")
+
+	assert.Equal(t, err, notifier.Send("john@example.com", "title", "text", ""))
+}
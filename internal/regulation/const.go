@@ -2,8 +2,42 @@ package regulation
 
 import "fmt"
 
-// ErrUserIsBanned user is banned error message.
-var ErrUserIsBanned = fmt.Errorf("user is banned")
+var (
+	// ErrUserIsBanned user is banned error message.
+	ErrUserIsBanned = fmt.Errorf("user is banned")
+
+	// ErrRemoteIPIsBanned remote IP is banned error message.
+	ErrRemoteIPIsBanned = fmt.Errorf("remote ip is banned")
+
+	// ErrSubnetIsBanned subnet is banned error message.
+	ErrSubnetIsBanned = fmt.Errorf("subnet is banned")
+
+	// ErrGlobalLimitReached global limit reached error message.
+	ErrGlobalLimitReached = fmt.Errorf("global limit of failed authentication attempts reached")
+)
+
+const (
+	// BanTypeUser is the type of the bans of a user.
+	BanTypeUser = "user"
+
+	// BanTypeIP is the type of the bans of a remote IP.
+	BanTypeIP = "ip"
+
+	// BanTypeSubnet is the type of the bans of a subnet.
+	BanTypeSubnet = "subnet"
+
+	// BanTypeGlobal is the type of the bans of everyone when the global limit is reached.
+	BanTypeGlobal = "global"
+
+	// BanTypeTOTP is the type of the bans of a user from TOTP authentication.
+	BanTypeTOTP = "totp"
+
+	// BanTypeIdentityVerificationUser is the type of the bans of a user from starting identity verifications.
+	BanTypeIdentityVerificationUser = "iv_user"
+
+	// BanTypeIdentityVerificationIP is the type of the bans of a remote IP from starting identity verifications.
+	BanTypeIdentityVerificationIP = "iv_ip"
+)
 
 const (
 	// AuthType1FA is the string representing an auth log for first-factor authentication.
@@ -2,41 +2,73 @@ package regulation
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/models"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewRegulator create a regulator instance.
-func NewRegulator(configuration *schema.RegulationConfiguration, provider storage.RegulatorProvider, clock utils.Clock) *Regulator {
-	regulator := &Regulator{storageProvider: provider}
+func NewRegulator(configuration *schema.RegulationConfiguration, provider storage.RegulatorProvider, geoIP geoip.Provider, clock utils.Clock) *Regulator {
+	regulator := &Regulator{storageProvider: provider, geoIP: geoIP}
 	regulator.clock = clock
 
 	if configuration != nil {
-		findTime, err := utils.ParseDurationString(configuration.FindTime)
-		if err != nil {
-			panic(err)
+		// The user limit is enabled only if MaxRetries is not 0.
+		regulator.user = newLimit(schema.RegulationLimitConfiguration{
+			MaxRetries: configuration.MaxRetries,
+			FindTime:   configuration.FindTime,
+			BanTime:    configuration.BanTime,
+		})
+
+		for _, country := range configuration.TrustedCountries {
+			regulator.trustedCountries = append(regulator.trustedCountries, strings.ToUpper(country))
 		}
 
-		banTime, err := utils.ParseDurationString(configuration.BanTime)
-		if err != nil {
-			panic(err)
+		regulator.foreignMaxRetries = configuration.ForeignMaxRetries
+
+		if configuration.IP != nil {
+			regulator.ip = newLimit(*configuration.IP)
+		}
+
+		if configuration.Subnet != nil {
+			regulator.subnet = newLimit(configuration.Subnet.RegulationLimitConfiguration)
+			regulator.ipv4Mask = net.CIDRMask(configuration.Subnet.IPv4PrefixLength, 8*net.IPv4len)
+			regulator.ipv6Mask = net.CIDRMask(configuration.Subnet.IPv6PrefixLength, 8*net.IPv6len)
 		}
 
-		if findTime > banTime {
-			panic(fmt.Errorf("find_time cannot be greater than ban_time"))
+		if configuration.Global != nil {
+			regulator.global = newLimit(*configuration.Global)
+		}
+
+		if configuration.TOTP != nil {
+			regulator.totp = newLimit(*configuration.TOTP)
+		}
+
+		if configuration.IdentityVerification != nil {
+			if configuration.IdentityVerification.User != nil {
+				regulator.identityVerificationUser = newLimit(*configuration.IdentityVerification.User)
+			}
+
+			if configuration.IdentityVerification.IP != nil {
+				regulator.identityVerificationIP = newLimit(*configuration.IdentityVerification.IP)
+			}
 		}
 
-		// Set regulator enabled only if MaxRetries is not 0.
-		regulator.enabled = configuration.MaxRetries > 0
-		regulator.maxRetries = configuration.MaxRetries
-		regulator.findTime = findTime
-		regulator.banTime = banTime
+		if configuration.Backoff != nil {
+			regulator.backoffMultiplier = configuration.Backoff.Multiplier
+			regulator.maxBanTime = mustParseDuration(configuration.Backoff.MaxBanTime)
+			regulator.backoffResetTime = mustParseDuration(configuration.Backoff.ResetTime)
+		}
 	}
 
 	return regulator
@@ -45,6 +77,8 @@ func NewRegulator(configuration *schema.RegulationConfiguration, provider storag
 // Mark an authentication attempt.
 // We split Mark and Regulate in order to avoid timing attacks.
 func (r *Regulator) Mark(ctx context.Context, successful, banned bool, username, requestURI, requestMethod, authType string, remoteIP net.IP) error {
+	location := r.lookup(remoteIP)
+
 	return r.storageProvider.AppendAuthenticationLog(ctx, models.AuthenticationAttempt{
 		Time:          r.clock.Now(),
 		Successful:    successful,
@@ -52,51 +86,363 @@ func (r *Regulator) Mark(ctx context.Context, successful, banned bool, username,
 		Username:      username,
 		Type:          authType,
 		RemoteIP:      models.NewNullIP(remoteIP),
+		Country:       location.Country,
+		ASN:           location.ASN,
 		RequestURI:    requestURI,
 		RequestMethod: requestMethod,
 	})
 }
 
-// Regulate the authentication attempts for a given user.
-// This method returns ErrUserIsBanned if the user is banned along with the time until when the user is banned.
-func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, error) {
+// Regulate the authentication attempts for a given user made from a given remote IP.
+// This method returns ErrUserIsBanned if the user is banned, ErrRemoteIPIsBanned if the remote IP is banned,
+// ErrSubnetIsBanned if the subnet of the remote IP is banned, or ErrGlobalLimitReached if the global limit of failed
+// authentication attempts is reached, along with the time until when the authentication attempts are rejected.
+func (r *Regulator) Regulate(ctx context.Context, username string, remoteIP net.IP) (bannedUntil time.Time, err error) {
+	if bannedUntil, err = r.RegulateAuthenticated(ctx, username, remoteIP); err != nil {
+		return bannedUntil, err
+	}
+
+	return r.regulateGlobal(ctx)
+}
+
+// RegulateAuthenticated regulates the authentication attempts of a user who already has an authenticated session, such
+// as when the user logs in again. It's the same as Regulate except that the global limit doesn't apply, so the users who
+// are logged in aren't locked out by the global limit.
+func (r *Regulator) RegulateAuthenticated(ctx context.Context, username string, remoteIP net.IP) (bannedUntil time.Time, err error) {
+	if bannedUntil, err = r.regulateUser(ctx, username, remoteIP); err != nil {
+		return bannedUntil, err
+	}
+
+	if remoteIP != nil {
+		if bannedUntil, err = r.regulateRemoteIP(ctx, remoteIP); err != nil {
+			return bannedUntil, err
+		}
+
+		if bannedUntil, err = r.regulateSubnet(ctx, remoteIP); err != nil {
+			return bannedUntil, err
+		}
+	}
+
+	return time.Time{}, nil
+}
+
+// Unban revokes the active bans of the given type and value, and returns the number of bans revoked.
+func (r *Regulator) Unban(ctx context.Context, banType, value string) (count int64, err error) {
+	return r.storageProvider.RevokeRegulationBans(ctx, banType, value, r.clock.Now())
+}
+
+func (r *Regulator) regulateUser(ctx context.Context, username string, remoteIP net.IP) (time.Time, error) {
 	// If there is regulation configuration, no regulation applies.
-	if !r.enabled {
+	if r.user == nil {
 		return time.Time{}, nil
 	}
 
-	attempts, err := r.storageProvider.LoadAuthenticationLogs(ctx, username, r.clock.Now().Add(-r.banTime), 10, 0)
-	if err != nil {
+	maxRetries := r.user.maxRetries
+
+	// Stricter thresholds apply to foreign origins. An origin which can't be located is considered foreign.
+	if r.foreignMaxRetries != 0 && r.foreignMaxRetries < maxRetries && !r.isTrustedOrigin(remoteIP) {
+		maxRetries = r.foreignMaxRetries
+	}
+
+	bannedUntil, banned := r.regulate(ctx, BanTypeUser, username, r.user, maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+		attempts, err := r.storageProvider.LoadAuthenticationLogs(ctx, username, fromDate, 10, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		return latestFailedAttemptTimes(attempts, maxRetries), nil
+	})
+
+	if banned {
+		return bannedUntil, ErrUserIsBanned
+	}
+
+	return time.Time{}, nil
+}
+
+func (r *Regulator) regulateRemoteIP(ctx context.Context, remoteIP net.IP) (time.Time, error) {
+	if r.ip == nil {
 		return time.Time{}, nil
 	}
 
-	latestFailedAttempts := make([]models.AuthenticationAttempt, 0, r.maxRetries)
+	bannedUntil, banned := r.regulate(ctx, BanTypeIP, remoteIP.String(), r.ip, r.ip.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+		attempts, err := r.storageProvider.LoadFailedAuthenticationLogsByRemoteIP(ctx, remoteIP, fromDate, r.ip.maxRetries, 0)
 
-	for _, attempt := range attempts {
-		if attempt.Successful || len(latestFailedAttempts) >= r.maxRetries {
-			// We stop appending failed attempts once we find the first successful attempts or we reach
-			// the configured number of retries, meaning the user is already banned.
-			break
-		} else {
-			latestFailedAttempts = append(latestFailedAttempts, attempt)
-		}
+		return attemptTimes(attempts), err
+	})
+
+	if banned {
+		return bannedUntil, ErrRemoteIPIsBanned
 	}
 
-	// If the number of failed attempts within the ban time is less than the max number of retries
-	// then the user is not banned.
-	if len(latestFailedAttempts) < r.maxRetries {
+	return time.Time{}, nil
+}
+
+func (r *Regulator) regulateSubnet(ctx context.Context, remoteIP net.IP) (time.Time, error) {
+	if r.subnet == nil {
 		return time.Time{}, nil
 	}
 
-	// Now we compute the time between the latest attempt and the MaxRetry-th one. If it's
-	// within the FindTime then it means that the user has been banned.
-	durationBetweenLatestAttempts := latestFailedAttempts[0].Time.Sub(
-		latestFailedAttempts[r.maxRetries-1].Time)
+	mask := r.ipv4Mask
+	if remoteIP.To4() == nil {
+		mask = r.ipv6Mask
+	}
+
+	subnet := &net.IPNet{IP: remoteIP.Mask(mask), Mask: mask}
+
+	bannedUntil, banned := r.regulate(ctx, BanTypeSubnet, subnet.String(), r.subnet, r.subnet.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+		attempts, err := r.storageProvider.LoadFailedAuthenticationLogsBySubnet(ctx, subnet, fromDate, r.subnet.maxRetries, 0)
+
+		return attemptTimes(attempts), err
+	})
+
+	if banned {
+		return bannedUntil, ErrSubnetIsBanned
+	}
+
+	return time.Time{}, nil
+}
+
+func (r *Regulator) regulateGlobal(ctx context.Context) (time.Time, error) {
+	if r.global == nil {
+		return time.Time{}, nil
+	}
+
+	bannedUntil, banned := r.regulate(ctx, BanTypeGlobal, "", r.global, r.global.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+		attempts, err := r.storageProvider.LoadFailedAuthenticationLogs(ctx, fromDate, r.global.maxRetries, 0)
+
+		return attemptTimes(attempts), err
+	})
+
+	if banned {
+		return bannedUntil, ErrGlobalLimitReached
+	}
+
+	return time.Time{}, nil
+}
+
+// RegulateTOTP regulates the TOTP authentication attempts of a user separately from the first factor.
+// This method returns ErrUserIsBanned if the user is banned from TOTP authentication along with the time until when
+// the user is banned.
+func (r *Regulator) RegulateTOTP(ctx context.Context, username string) (time.Time, error) {
+	if r.totp == nil {
+		return time.Time{}, nil
+	}
+
+	bannedUntil, banned := r.regulate(ctx, BanTypeTOTP, username, r.totp, r.totp.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+		attempts, err := r.storageProvider.LoadAuthenticationLogsByType(ctx, username, AuthTypeTOTP, fromDate, r.totp.maxRetries, 0)
+		if err != nil {
+			return nil, err
+		}
 
-	if durationBetweenLatestAttempts < r.findTime {
-		bannedUntil := latestFailedAttempts[0].Time.Add(r.banTime)
+		return latestFailedAttemptTimes(attempts, r.totp.maxRetries), nil
+	})
+
+	if banned {
 		return bannedUntil, ErrUserIsBanned
 	}
 
 	return time.Time{}, nil
 }
+
+// RegulateIdentityVerification regulates the identity verification requests, such as password reset emails, of a user
+// made from a remote IP. This method returns ErrUserIsBanned if the user is banned or ErrRemoteIPIsBanned if the
+// remote IP is banned from starting identity verifications, along with the time until when they are banned.
+func (r *Regulator) RegulateIdentityVerification(ctx context.Context, username string, remoteIP net.IP) (time.Time, error) {
+	if l := r.identityVerificationUser; l != nil {
+		bannedUntil, banned := r.regulate(ctx, BanTypeIdentityVerificationUser, username, l, l.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+			return r.storageProvider.LoadIdentityVerificationTimesByUsername(ctx, username, fromDate, l.maxRetries)
+		})
+
+		if banned {
+			return bannedUntil, ErrUserIsBanned
+		}
+	}
+
+	if l := r.identityVerificationIP; l != nil && remoteIP != nil {
+		bannedUntil, banned := r.regulate(ctx, BanTypeIdentityVerificationIP, remoteIP.String(), l, l.maxRetries, func(fromDate time.Time) ([]time.Time, error) {
+			return r.storageProvider.LoadIdentityVerificationTimesByIssuedIP(ctx, remoteIP, fromDate, l.maxRetries)
+		})
+
+		if banned {
+			return bannedUntil, ErrRemoteIPIsBanned
+		}
+	}
+
+	return time.Time{}, nil
+}
+
+// regulate returns the time until when the attempts of a subject are rejected and true if the subject has an active
+// ban. Otherwise the times of the latest attempts returned by load are checked against the limit, and if they reach it
+// a ban is recorded. The attempts which were made before the latest ban of the subject are ignored as they have already
+// been accounted for, this prevents a revoked ban from being immediately recreated.
+func (r *Regulator) regulate(ctx context.Context, banType, value string, l *limit, maxRetries int, load func(fromDate time.Time) ([]time.Time, error)) (time.Time, bool) {
+	now := r.clock.Now()
+
+	since := now.Add(-l.banTime)
+	if r.backoffResetTime > l.banTime {
+		since = now.Add(-r.backoffResetTime)
+	}
+
+	bans, err := r.storageProvider.LoadRegulationBans(ctx, banType, value, since)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	fromDate := now.Add(-l.banTime)
+	previous := 0
+
+	for i, ban := range bans {
+		if !ban.Revoked && ban.Expires.After(now) {
+			return ban.Expires, true
+		}
+
+		if i == 0 && ban.Time.After(fromDate) {
+			fromDate = ban.Time
+		}
+
+		if !ban.Revoked && ban.Time.After(now.Add(-r.backoffResetTime)) {
+			previous++
+		}
+	}
+
+	times, err := load(fromDate)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if !l.reached(times, maxRetries) {
+		return time.Time{}, false
+	}
+
+	// The global bans reject everyone so they're never escalated, they only last the ban time.
+	if banType == BanTypeGlobal {
+		previous = 0
+	}
+
+	ban := models.RegulationBan{
+		Time:    now,
+		Expires: times[0].Add(r.banDuration(l.banTime, previous)),
+		Type:    banType,
+		Value:   value,
+	}
+
+	metrics.RecordRegulationBan(banType)
+
+	if err = r.storageProvider.SaveRegulationBan(ctx, ban); err != nil {
+		logging.Logger().Errorf("Unable to save the regulation ban of %s '%s': %+v", banType, value, err)
+	} else if r.userBanHandler != nil && (banType == BanTypeUser || banType == BanTypeTOTP) {
+		r.userBanHandler(ctx, value, ban.Expires)
+	}
+
+	return ban.Expires, true
+}
+
+// SetUserBanHandler sets the handler which is called each time the regulator bans a user from authenticating.
+func (r *Regulator) SetUserBanHandler(handler UserBanHandler) {
+	r.userBanHandler = handler
+}
+
+// banDuration returns the ban time multiplied by the backoff multiplier once for each previous ban of the subject,
+// up to the maximum ban time.
+func (r *Regulator) banDuration(banTime time.Duration, previous int) time.Duration {
+	if r.backoffMultiplier <= 1 || banTime >= r.maxBanTime {
+		return banTime
+	}
+
+	duration := banTime
+
+	for i := 0; i < previous; i++ {
+		duration *= time.Duration(r.backoffMultiplier)
+
+		if duration >= r.maxBanTime {
+			return r.maxBanTime
+		}
+	}
+
+	return duration
+}
+
+func (r *Regulator) isTrustedOrigin(remoteIP net.IP) bool {
+	return utils.IsStringInSlice(r.lookup(remoteIP).Country, r.trustedCountries)
+}
+
+func (r *Regulator) lookup(remoteIP net.IP) (location geoip.Location) {
+	if r.geoIP == nil {
+		return location
+	}
+
+	return r.geoIP.Lookup(remoteIP)
+}
+
+// IsBanned returns true if the error returned by Regulate means the authentication attempt must be rejected because
+// of one of the regulation limits.
+func IsBanned(err error) bool {
+	return errors.Is(err, ErrUserIsBanned) || errors.Is(err, ErrRemoteIPIsBanned) ||
+		errors.Is(err, ErrSubnetIsBanned) || errors.Is(err, ErrGlobalLimitReached)
+}
+
+// newLimit returns the limit of the given configuration, or nil if the limit is disabled.
+func newLimit(configuration schema.RegulationLimitConfiguration) *limit {
+	if configuration.MaxRetries <= 0 {
+		return nil
+	}
+
+	findTime := mustParseDuration(configuration.FindTime)
+	banTime := mustParseDuration(configuration.BanTime)
+
+	if findTime > banTime {
+		panic(fmt.Errorf("find_time cannot be greater than ban_time"))
+	}
+
+	return &limit{maxRetries: configuration.MaxRetries, findTime: findTime, banTime: banTime}
+}
+
+func mustParseDuration(input string) time.Duration {
+	duration, err := utils.ParseDurationString(input)
+	if err != nil {
+		panic(err)
+	}
+
+	return duration
+}
+
+// reached returns true if the times of the latest attempts, which must be sorted from the most recent, reach the limit
+// with the given number of retries.
+func (l *limit) reached(times []time.Time, maxRetries int) bool {
+	if len(times) < maxRetries {
+		return false
+	}
+
+	// Now we compute the time between the latest attempt and the MaxRetry-th one. If it's
+	// within the FindTime then it means that the limit has been reached.
+	return times[0].Sub(times[maxRetries-1]) < l.findTime
+}
+
+// attemptTimes returns the times of the given attempts.
+func attemptTimes(attempts []models.AuthenticationAttempt) (times []time.Time) {
+	times = make([]time.Time, len(attempts))
+
+	for i, attempt := range attempts {
+		times[i] = attempt.Time
+	}
+
+	return times
+}
+
+// latestFailedAttemptTimes returns the times of the latest failed attempts up to the first successful attempt.
+func latestFailedAttemptTimes(attempts []models.AuthenticationAttempt, maxRetries int) (times []time.Time) {
+	times = make([]time.Time, 0, maxRetries)
+
+	for _, attempt := range attempts {
+		if attempt.Successful || len(times) >= maxRetries {
+			// We stop appending failed attempts once we find the first successful attempts or we reach
+			// the configured number of retries, meaning the user is already banned.
+			break
+		}
+
+		times = append(times, attempt.Time)
+	}
+
+	return times
+}
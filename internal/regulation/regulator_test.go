@@ -2,6 +2,8 @@ package regulation_test
 
 import (
 	"context"
+	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/models"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -42,6 +45,19 @@ func (s *RegulatorSuite) TearDownTest() {
 	s.ctrl.Finish()
 }
 
+// expectNoBans expects the regulator to find no previous bans and to record the bans it decides on.
+func (s *RegulatorSuite) expectNoBans() {
+	s.storageMock.EXPECT().
+		LoadRegulationBans(s.ctx, gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	s.storageMock.EXPECT().
+		SaveRegulationBan(s.ctx, gomock.Any()).
+		Return(nil).
+		AnyTimes()
+}
+
 func (s *RegulatorSuite) TestShouldNotThrowWhenUserIsLegitimate() {
 	attemptsInDB := []models.AuthenticationAttempt{
 		{
@@ -55,9 +71,11 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenUserIsLegitimate() {
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 }
 
@@ -86,12 +104,58 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenFailedAuthenticationNotInFindTime
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 }
 
+func (s *RegulatorSuite) TestShouldCallUserBanHandlerOnlyForUserBans() {
+	remoteIP := net.ParseIP("192.0.2.1")
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-1 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+		{Username: "john", Time: s.clock.Now().Add(-4 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+		{Username: "john", Time: s.clock.Now().Add(-6 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+	}
+
+	s.configuration.IP = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "30", BanTime: "600"}
+
+	gomock.InOrder(
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("alice"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(nil, nil),
+		s.storageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.ctx, gomock.Eq(remoteIP), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+	)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	var banned []string
+
+	regulator.SetUserBanHandler(func(ctx context.Context, username string, until time.Time) {
+		assert.Equal(s.T(), s.clock.Now().Add(179*time.Second), until)
+
+		banned = append(banned, username)
+	})
+
+	_, err := regulator.Regulate(s.ctx, "john", nil)
+	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
+
+	_, err = regulator.Regulate(s.ctx, "alice", remoteIP)
+	assert.Equal(s.T(), regulation.ErrRemoteIPIsBanned, err)
+
+	assert.Equal(s.T(), []string{"john"}, banned)
+}
+
 // This test checks the case in which a user failed to authenticate many times only a few
 // seconds ago (meaning we are checking from now back to now-FindTime).
 func (s *RegulatorSuite) TestShouldBanUserIfLatestAttemptsAreWithinFinTime() {
@@ -122,9 +186,11 @@ func (s *RegulatorSuite) TestShouldBanUserIfLatestAttemptsAreWithinFinTime() {
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
 }
 
@@ -155,9 +221,11 @@ func (s *RegulatorSuite) TestShouldCheckUserIsStillBanned() {
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
 }
 
@@ -179,9 +247,11 @@ func (s *RegulatorSuite) TestShouldCheckUserIsNotYetBanned() {
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 }
 
@@ -211,9 +281,11 @@ func (s *RegulatorSuite) TestShouldCheckUserWasAboutToBeBanned() {
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 }
 
@@ -247,12 +319,86 @@ func (s *RegulatorSuite) TestShouldCheckRegulationHasBeenResetOnSuccessfulAttemp
 		LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
-	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, &s.clock)
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
 
-	_, err := regulator.Regulate(s.ctx, "john")
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 }
 
+func (s *RegulatorSuite) TestShouldApplyForeignMaxRetriesToForeignOrigins() {
+	attemptsInDB := []models.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.clock.Now().Add(-4 * time.Second),
+		},
+	}
+
+	geoIPMock := mocks.NewMockGeoIP(s.ctrl)
+
+	s.configuration.TrustedCountries = []string{"nz"}
+	s.configuration.ForeignMaxRetries = 2
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, geoIPMock, &s.clock)
+
+	trusted, foreign := net.ParseIP("192.0.2.1"), net.ParseIP("198.51.100.1")
+
+	gomock.InOrder(
+		geoIPMock.EXPECT().Lookup(gomock.Eq(trusted)).Return(geoip.Location{Country: "NZ"}),
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+		geoIPMock.EXPECT().Lookup(gomock.Eq(foreign)).Return(geoip.Location{Country: "US"}),
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+	)
+
+	_, err := regulator.Regulate(s.ctx, "john", trusted)
+	assert.NoError(s.T(), err)
+
+	_, err = regulator.Regulate(s.ctx, "john", foreign)
+	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
+}
+
+func (s *RegulatorSuite) TestShouldMarkAuthenticationAttemptWithLocation() {
+	geoIPMock := mocks.NewMockGeoIP(s.ctrl)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, geoIPMock, &s.clock)
+
+	remoteIP := net.ParseIP("192.0.2.1")
+
+	gomock.InOrder(
+		geoIPMock.EXPECT().Lookup(gomock.Eq(remoteIP)).Return(geoip.Location{Country: "NZ", ASN: 13335}),
+		s.storageMock.EXPECT().
+			AppendAuthenticationLog(s.ctx, gomock.Eq(models.AuthenticationAttempt{
+				Time:          s.clock.Now(),
+				Successful:    false,
+				Username:      "john",
+				Type:          regulation.AuthType1FA,
+				RemoteIP:      models.NewNullIP(remoteIP),
+				Country:       "NZ",
+				ASN:           13335,
+				RequestURI:    "https://example.com",
+				RequestMethod: "GET",
+			})).
+			Return(nil),
+	)
+
+	assert.NoError(s.T(), regulator.Mark(s.ctx, false, false, "john", "https://example.com", "GET", regulation.AuthType1FA, remoteIP))
+}
+
 func TestRunRegulatorSuite(t *testing.T) {
 	s := new(RegulatorSuite)
 	suite.Run(t, s)
@@ -289,8 +435,10 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 		BanTime:    "180",
 	}
 
-	regulator := regulation.NewRegulator(&configuration, s.storageMock, &s.clock)
-	_, err := regulator.Regulate(s.ctx, "john")
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&configuration, s.storageMock, nil, &s.clock)
+	_, err := regulator.Regulate(s.ctx, "john", nil)
 	assert.NoError(s.T(), err)
 
 	// Check Enabled Functionality
@@ -300,7 +448,367 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 		BanTime:    "180",
 	}
 
-	regulator = regulation.NewRegulator(&configuration, s.storageMock, &s.clock)
-	_, err = regulator.Regulate(s.ctx, "john")
+	regulator = regulation.NewRegulator(&configuration, s.storageMock, nil, &s.clock)
+	_, err = regulator.Regulate(s.ctx, "john", nil)
+	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
+}
+
+func (s *RegulatorSuite) TestShouldBanRemoteIPSprayingUsernames() {
+	remoteIP := net.ParseIP("192.0.2.1")
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-1 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+		{Username: "harry", Time: s.clock.Now().Add(-4 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+		{Username: "bob", Time: s.clock.Now().Add(-6 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+	}
+
+	s.configuration.IP = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "30", BanTime: "600"}
+
+	gomock.InOrder(
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("alice"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(nil, nil),
+		s.storageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteIP(s.ctx, gomock.Eq(remoteIP), gomock.Eq(s.clock.Now().Add(-600*time.Second)), gomock.Eq(3), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+	)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.Regulate(s.ctx, "alice", remoteIP)
+	assert.Equal(s.T(), regulation.ErrRemoteIPIsBanned, err)
+	assert.Equal(s.T(), s.clock.Now().Add(599*time.Second), bannedUntil)
+	assert.True(s.T(), regulation.IsBanned(err))
+}
+
+func (s *RegulatorSuite) TestShouldNotBanRemoteIPWhenAttemptsNotInFindTime() {
+	remoteIP := net.ParseIP("192.0.2.1")
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-1 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+		{Username: "harry", Time: s.clock.Now().Add(-40 * time.Second), RemoteIP: models.NewNullIP(remoteIP)},
+	}
+
+	s.configuration.MaxRetries = 0
+	s.configuration.IP = &schema.RegulationLimitConfiguration{MaxRetries: 2, FindTime: "30", BanTime: "600"}
+
+	s.storageMock.EXPECT().
+		LoadFailedAuthenticationLogsByRemoteIP(s.ctx, gomock.Eq(remoteIP), gomock.Any(), gomock.Eq(2), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.Regulate(s.ctx, "alice", remoteIP)
+	assert.NoError(s.T(), err)
+}
+
+func (s *RegulatorSuite) TestShouldBanSubnet() {
+	s.configuration.MaxRetries = 0
+	s.configuration.Subnet = &schema.RegulationSubnetConfiguration{
+		RegulationLimitConfiguration: schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "30", BanTime: "600"},
+		IPv4PrefixLength:             24,
+		IPv6PrefixLength:             64,
+	}
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-2 * time.Second), RemoteIP: models.NewNullIP(net.ParseIP("192.0.2.10"))},
+		{Username: "bob", Time: s.clock.Now().Add(-5 * time.Second), RemoteIP: models.NewNullIP(net.ParseIP("192.0.2.20"))},
+		{Username: "james", Time: s.clock.Now().Add(-6 * time.Second), RemoteIP: models.NewNullIP(net.ParseIP("192.0.2.30"))},
+	}
+
+	_, subnet, _ := net.ParseCIDR("192.0.2.0/24")
+
+	s.storageMock.EXPECT().
+		LoadFailedAuthenticationLogsBySubnet(s.ctx, gomock.Eq(subnet), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.Regulate(s.ctx, "alice", net.ParseIP("192.0.2.1"))
+	assert.Equal(s.T(), regulation.ErrSubnetIsBanned, err)
+	assert.Equal(s.T(), s.clock.Now().Add(598*time.Second), bannedUntil)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanIPv6SubnetBelowMaxRetries() {
+	s.configuration.MaxRetries = 0
+	s.configuration.Subnet = &schema.RegulationSubnetConfiguration{
+		RegulationLimitConfiguration: schema.RegulationLimitConfiguration{MaxRetries: 2, FindTime: "30", BanTime: "600"},
+		IPv4PrefixLength:             24,
+		IPv6PrefixLength:             64,
+	}
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-1 * time.Second), RemoteIP: models.NewNullIP(net.ParseIP("2001:db8:0:1::10"))},
+	}
+
+	_, subnet, _ := net.ParseCIDR("2001:db8:0:1::/64")
+
+	s.storageMock.EXPECT().
+		LoadFailedAuthenticationLogsBySubnet(s.ctx, gomock.Eq(subnet), gomock.Any(), gomock.Eq(2), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.Regulate(s.ctx, "alice", net.ParseIP("2001:db8:0:1::20"))
+	assert.NoError(s.T(), err)
+}
+
+func (s *RegulatorSuite) TestShouldReachGlobalLimit() {
+	s.configuration.MaxRetries = 0
+	s.configuration.Global = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "10", BanTime: "60"}
+
+	attemptsInDB := []models.AuthenticationAttempt{
+		{Username: "john", Time: s.clock.Now().Add(-1 * time.Second)},
+		{Username: "harry", Time: s.clock.Now().Add(-2 * time.Second)},
+		{Username: "bob", Time: s.clock.Now().Add(-3 * time.Second)},
+	}
+
+	s.storageMock.EXPECT().
+		LoadFailedAuthenticationLogs(s.ctx, gomock.Eq(s.clock.Now().Add(-60*time.Second)), gomock.Eq(3), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.Regulate(s.ctx, "alice", nil)
+	assert.Equal(s.T(), regulation.ErrGlobalLimitReached, err)
+	assert.Equal(s.T(), s.clock.Now().Add(59*time.Second), bannedUntil)
+}
+
+func (s *RegulatorSuite) TestShouldNotApplyGlobalLimitToAuthenticatedUsers() {
+	s.configuration.MaxRetries = 0
+	s.configuration.Global = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "10", BanTime: "60"}
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.RegulateAuthenticated(s.ctx, "alice", nil)
+	assert.NoError(s.T(), err)
+}
+
+func (s *RegulatorSuite) TestShouldNotEscalateGlobalBanTime() {
+	s.configuration.MaxRetries = 0
+	s.configuration.Global = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "10", BanTime: "60"}
+	s.configuration.Backoff = &schema.RegulationBackoffConfiguration{Multiplier: 2, MaxBanTime: "1d", ResetTime: "1d"}
+
+	latest := s.clock.Now().Add(-1 * time.Second)
+
+	gomock.InOrder(
+		s.storageMock.EXPECT().
+			LoadRegulationBans(s.ctx, gomock.Eq(regulation.BanTypeGlobal), gomock.Eq(""), gomock.Any()).
+			Return([]models.RegulationBan{
+				{Time: s.clock.Now().Add(-2 * time.Hour), Expires: s.clock.Now().Add(-119 * time.Minute), Type: regulation.BanTypeGlobal},
+				{Time: s.clock.Now().Add(-3 * time.Hour), Expires: s.clock.Now().Add(-179 * time.Minute), Type: regulation.BanTypeGlobal},
+			}, nil),
+		s.storageMock.EXPECT().
+			LoadFailedAuthenticationLogs(s.ctx, gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return([]models.AuthenticationAttempt{
+				{Username: "john", Time: latest},
+				{Username: "harry", Time: s.clock.Now().Add(-2 * time.Second)},
+				{Username: "bob", Time: s.clock.Now().Add(-3 * time.Second)},
+			}, nil),
+		s.storageMock.EXPECT().
+			SaveRegulationBan(s.ctx, gomock.Eq(models.RegulationBan{
+				Time:    s.clock.Now(),
+				Expires: latest.Add(60 * time.Second),
+				Type:    regulation.BanTypeGlobal,
+			})).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.Regulate(s.ctx, "alice", nil)
+	assert.Equal(s.T(), regulation.ErrGlobalLimitReached, err)
+	assert.Equal(s.T(), latest.Add(60*time.Second), bannedUntil)
+}
+
+func TestShouldDetermineIfErrorIsBanned(t *testing.T) {
+	assert.True(t, regulation.IsBanned(regulation.ErrUserIsBanned))
+	assert.True(t, regulation.IsBanned(regulation.ErrRemoteIPIsBanned))
+	assert.True(t, regulation.IsBanned(regulation.ErrSubnetIsBanned))
+	assert.True(t, regulation.IsBanned(regulation.ErrGlobalLimitReached))
+	assert.False(t, regulation.IsBanned(errors.New("failed to load authentication logs")))
+	assert.False(t, regulation.IsBanned(nil))
+}
+
+func (s *RegulatorSuite) TestShouldRejectWhileBanIsActive() {
+	bannedUntil := s.clock.Now().Add(10 * time.Minute)
+
+	s.storageMock.EXPECT().
+		LoadRegulationBans(s.ctx, gomock.Eq(regulation.BanTypeUser), gomock.Eq("john"), gomock.Eq(s.clock.Now().Add(-180*time.Second))).
+		Return([]models.RegulationBan{
+			{Time: s.clock.Now().Add(-time.Minute), Expires: bannedUntil, Type: regulation.BanTypeUser, Value: "john"},
+		}, nil)
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	until, err := regulator.Regulate(s.ctx, "john", nil)
+	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
+	assert.Equal(s.T(), bannedUntil, until)
+}
+
+func (s *RegulatorSuite) TestShouldIgnoreFailedAttemptsBeforeRevokedBan() {
+	revokedAt := s.clock.Now().Add(-5 * time.Second)
+	banTime := s.clock.Now().Add(-10 * time.Second)
+
+	gomock.InOrder(
+		s.storageMock.EXPECT().
+			LoadRegulationBans(s.ctx, gomock.Eq(regulation.BanTypeUser), gomock.Eq("john"), gomock.Any()).
+			Return([]models.RegulationBan{
+				{Time: banTime, Expires: s.clock.Now().Add(time.Minute), Revoked: true, RevokedAt: &revokedAt, Type: regulation.BanTypeUser, Value: "john"},
+			}, nil),
+		s.storageMock.EXPECT().
+			LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Eq(banTime), gomock.Eq(10), gomock.Eq(0)).
+			Return([]models.AuthenticationAttempt{
+				{Username: "john", Time: s.clock.Now().Add(-1 * time.Second)},
+			}, nil),
+	)
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.Regulate(s.ctx, "john", nil)
+	assert.NoError(s.T(), err)
+}
+
+func (s *RegulatorSuite) TestShouldEscalateBanTimeOfRepeatOffenders() {
+	testCases := []struct {
+		name       string
+		maxBanTime string
+		previous   int
+		expected   time.Duration
+	}{
+		{"ShouldNotEscalateFirstBan", "1d", 0, 180 * time.Second},
+		{"ShouldDoubleForEachPreviousBan", "1d", 2, 720 * time.Second},
+		{"ShouldNotExceedMaxBanTime", "10m", 3, 10 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.configuration.Backoff = &schema.RegulationBackoffConfiguration{Multiplier: 2, MaxBanTime: tc.maxBanTime, ResetTime: "1d"}
+
+			bans := make([]models.RegulationBan, 0, tc.previous+1)
+
+			for i := 0; i < tc.previous; i++ {
+				bans = append(bans, models.RegulationBan{Time: s.clock.Now().Add(-time.Duration(i+1) * time.Hour), Expires: s.clock.Now().Add(-time.Duration(i+1) * 30 * time.Minute), Type: regulation.BanTypeUser, Value: "john"})
+			}
+
+			// A revoked ban doesn't count as a previous ban.
+			bans = append(bans, models.RegulationBan{Time: s.clock.Now().Add(-20 * time.Hour), Expires: s.clock.Now().Add(-19 * time.Hour), Revoked: true, Type: regulation.BanTypeUser, Value: "john"})
+
+			latest := s.clock.Now().Add(-1 * time.Second)
+
+			gomock.InOrder(
+				s.storageMock.EXPECT().
+					LoadRegulationBans(s.ctx, gomock.Eq(regulation.BanTypeUser), gomock.Eq("john"), gomock.Eq(s.clock.Now().Add(-24*time.Hour))).
+					Return(bans, nil),
+				s.storageMock.EXPECT().
+					LoadAuthenticationLogs(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+					Return([]models.AuthenticationAttempt{
+						{Username: "john", Time: latest},
+						{Username: "john", Time: s.clock.Now().Add(-4 * time.Second)},
+						{Username: "john", Time: s.clock.Now().Add(-6 * time.Second)},
+					}, nil),
+				s.storageMock.EXPECT().
+					SaveRegulationBan(s.ctx, gomock.Eq(models.RegulationBan{
+						Time:    s.clock.Now(),
+						Expires: latest.Add(tc.expected),
+						Type:    regulation.BanTypeUser,
+						Value:   "john",
+					})).
+					Return(nil),
+			)
+
+			regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+			bannedUntil, err := regulator.Regulate(s.ctx, "john", nil)
+			s.Assert().Equal(regulation.ErrUserIsBanned, err)
+			s.Assert().Equal(latest.Add(tc.expected), bannedUntil)
+		})
+	}
+}
+
+func (s *RegulatorSuite) TestShouldUnban() {
+	s.storageMock.EXPECT().
+		RevokeRegulationBans(s.ctx, gomock.Eq(regulation.BanTypeIP), gomock.Eq("192.0.2.1"), gomock.Eq(s.clock.Now())).
+		Return(int64(1), nil)
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	count, err := regulator.Unban(s.ctx, regulation.BanTypeIP, "192.0.2.1")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), count)
+}
+
+func (s *RegulatorSuite) TestShouldBanUserFromTOTPSeparatelyFromFirstFactor() {
+	s.configuration.TOTP = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "30", BanTime: "600"}
+
+	s.storageMock.EXPECT().
+		LoadAuthenticationLogsByType(s.ctx, gomock.Eq("john"), gomock.Eq(regulation.AuthTypeTOTP), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+		Return([]models.AuthenticationAttempt{
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-1 * time.Second)},
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-4 * time.Second)},
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-6 * time.Second)},
+		}, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.RegulateTOTP(s.ctx, "john")
 	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
+	assert.Equal(s.T(), s.clock.Now().Add(599*time.Second), bannedUntil)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserFromTOTPAfterSuccessfulAttempt() {
+	s.configuration.TOTP = &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "30", BanTime: "600"}
+
+	s.storageMock.EXPECT().
+		LoadAuthenticationLogsByType(s.ctx, gomock.Eq("john"), gomock.Eq(regulation.AuthTypeTOTP), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+		Return([]models.AuthenticationAttempt{
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-1 * time.Second)},
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-4 * time.Second), Successful: true},
+			{Username: "john", Type: regulation.AuthTypeTOTP, Time: s.clock.Now().Add(-6 * time.Second)},
+		}, nil)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	_, err := regulator.RegulateTOTP(s.ctx, "john")
+	assert.NoError(s.T(), err)
+}
+
+func (s *RegulatorSuite) TestShouldBanRemoteIPFromIdentityVerification() {
+	remoteIP := net.ParseIP("192.0.2.1")
+
+	s.configuration.IdentityVerification = &schema.RegulationIdentityVerificationConfiguration{
+		User: &schema.RegulationLimitConfiguration{MaxRetries: 3, FindTime: "600", BanTime: "3600"},
+		IP:   &schema.RegulationLimitConfiguration{MaxRetries: 2, FindTime: "600", BanTime: "3600"},
+	}
+
+	gomock.InOrder(
+		s.storageMock.EXPECT().
+			LoadIdentityVerificationTimesByUsername(s.ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(3)).
+			Return([]time.Time{s.clock.Now().Add(-time.Minute)}, nil),
+		s.storageMock.EXPECT().
+			LoadIdentityVerificationTimesByIssuedIP(s.ctx, gomock.Eq(remoteIP), gomock.Any(), gomock.Eq(2)).
+			Return([]time.Time{s.clock.Now().Add(-time.Minute), s.clock.Now().Add(-2 * time.Minute)}, nil),
+	)
+
+	s.expectNoBans()
+
+	regulator := regulation.NewRegulator(&s.configuration, s.storageMock, nil, &s.clock)
+
+	bannedUntil, err := regulator.RegulateIdentityVerification(s.ctx, "john", remoteIP)
+	assert.Equal(s.T(), regulation.ErrRemoteIPIsBanned, err)
+	assert.Equal(s.T(), s.clock.Now().Add(59*time.Minute), bannedUntil)
 }
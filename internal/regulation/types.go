@@ -1,24 +1,64 @@
 package regulation
 
 import (
+	"context"
+	"net"
 	"time"
 
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // Regulator an authentication regulator preventing attackers to brute force the service.
 type Regulator struct {
-	// Is the regulation enabled.
-	enabled bool
-	// The number of failed authentication attempt before banning the user
-	maxRetries int
-	// If a user does the max number of retries within that duration, she will be banned.
-	findTime time.Duration
-	// If a user has been banned, this duration is the timelapse during which the user is banned.
-	banTime time.Duration
+	// The limit of failed authentication attempts made by a user.
+	user *limit
+	// The countries which are not considered as foreign origins.
+	trustedCountries []string
+	// The number of failed authentication attempts before banning the user when the request has a foreign origin.
+	foreignMaxRetries int
+
+	// The limit of failed authentication attempts made from a remote IP.
+	ip *limit
+	// The limit of failed authentication attempts made from a subnet.
+	subnet *limit
+	// The prefix lengths of the subnets of the subnet limit.
+	ipv4Mask, ipv6Mask net.IPMask
+	// The limit of failed authentication attempts made by everyone.
+	global *limit
+
+	// The limit of failed TOTP authentication attempts made by a user.
+	totp *limit
+	// The limits of identity verification requests made by a user and from a remote IP.
+	identityVerificationUser, identityVerificationIP *limit
+
+	// The factor the ban time is multiplied by for each previous ban of the subject, disabled if 1 or less.
+	backoffMultiplier int
+	// The maximum duration of a ban when the ban time is multiplied.
+	maxBanTime time.Duration
+	// The duration after which a previous ban doesn't multiply the ban time anymore.
+	backoffResetTime time.Duration
 
 	storageProvider storage.RegulatorProvider
 
+	geoIP geoip.Provider
+
 	clock utils.Clock
+
+	// The handler called when a user is banned.
+	userBanHandler UserBanHandler
+}
+
+// UserBanHandler is called with the username and the end of the ban when a user is banned from authenticating.
+type UserBanHandler func(ctx context.Context, username string, until time.Time)
+
+// limit is the thresholds of a user, IP, subnet or global regulation limit.
+type limit struct {
+	// The number of failed authentication attempts before the limit is reached.
+	maxRetries int
+	// The duration in which the number of failed authentication attempts must be made to reach the limit.
+	findTime time.Duration
+	// The duration the authentication attempts are rejected for once the limit is reached.
+	banTime time.Duration
 }
@@ -10,6 +10,7 @@ import (
 
 	duoapi "github.com/duosecurity/duo_api_golang"
 	"github.com/fasthttp/router"
+	"github.com/pires/go-proxyproto"
 	"github.com/valyala/fasthttp"
 	"github.com/valyala/fasthttp/expvarhandler"
 	"github.com/valyala/fasthttp/fasthttpadaptor"
@@ -19,6 +20,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/duo"
 	"github.com/authelia/authelia/v4/internal/handlers"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 )
 
@@ -45,6 +47,10 @@ func registerRoutes(configuration schema.Configuration, providers middlewares.Pr
 	serveSwaggerAPIHandler := ServeTemplatedFile(swaggerAssets, apiFile, configuration.Server.AssetPath, duoSelfEnrollment, rememberMe, resetPassword, configuration.Session.Name, configuration.Theme, https)
 
 	r := router.New()
+
+	// The path of the matched route is only saved for the routes registered after this is set.
+	r.SaveMatchedRoutePath = configuration.Server.Metrics.Enabled || configuration.Tracing.Enabled
+
 	r.GET("/", serveIndexHandler)
 	r.OPTIONS("/", autheliaMiddleware(handleOPTIONS))
 
@@ -91,6 +97,12 @@ func registerRoutes(configuration schema.Configuration, providers middlewares.Pr
 	r.GET("/api/user/info/totp", autheliaMiddleware(
 		middlewares.RequireFirstFactor(handlers.UserTOTPGet)))
 
+	// Sessions of the user.
+	r.GET("/api/user/sessions", autheliaMiddleware(
+		middlewares.RequireFirstFactor(handlers.UserSessionsGet)))
+	r.DELETE("/api/user/sessions/{id}", autheliaMiddleware(
+		middlewares.RequireFirstFactor(handlers.UserSessionDelete)))
+
 	// TOTP related endpoints.
 	r.POST("/api/secondfactor/totp/identity/start", autheliaMiddleware(
 		middlewares.RequireFirstFactor(handlers.SecondFactorTOTPIdentityStart)))
@@ -147,9 +159,18 @@ func registerRoutes(configuration schema.Configuration, providers middlewares.Pr
 		r.GET("/debug/vars", expvarhandler.ExpvarHandler)
 	}
 
+	if configuration.Server.Metrics.Enabled && configuration.Server.Metrics.ServerListener {
+		r.GET("/metrics", metrics.Handler())
+	}
+
 	r.NotFound = serveIndexHandler
 
-	handler := middlewares.LogRequestMiddleware(r.Handler)
+	handler := r.Handler
+	if configuration.Server.Metrics.Enabled {
+		handler = middlewares.MetricsRequestMiddleware(handler)
+	}
+
+	handler = middlewares.LogRequestMiddleware(handler)
 	if configuration.Server.Path != "" {
 		handler = middlewares.StripPathMiddleware(configuration.Server.Path, handler)
 	}
@@ -182,6 +203,25 @@ func Start(configuration schema.Configuration, providers middlewares.Providers)
 		logger.Fatalf("Error initializing listener: %s", err)
 	}
 
+	if len(configuration.Server.TrustedProxies) == 0 {
+		logger.Warn("No trusted proxies are configured so the X-Forwarded-For header is trusted from anyone and clients able to reach Authelia directly can spoof their remote IP, configure the 'server.trusted_proxies' option with the reverse proxies in front of Authelia")
+	}
+
+	if configuration.Server.Metrics.Enabled && configuration.Server.Metrics.ServerListener {
+		logger.Warn("The metrics are served by the server without authentication, make sure the reverse proxies in front of Authelia don't expose the '/metrics' path")
+	}
+
+	if configuration.Server.EnableProxyProtocol {
+		var policy proxyproto.PolicyFunc
+
+		if policy, err = proxyproto.LaxWhiteListPolicy(configuration.Server.TrustedProxies); err != nil {
+			logger.Fatalf("Error initializing PROXY protocol listener: %s", err)
+		}
+
+		// The PROXY protocol header is only used when sent by a trusted proxy and ignored otherwise.
+		listener = &proxyproto.Listener{Listener: listener, Policy: policy}
+	}
+
 	if configuration.Server.TLS.Certificate != "" && configuration.Server.TLS.Key != "" {
 		if err = writeHealthCheckEnv(configuration.Server.DisableHealthcheck, "https", configuration.Server.Host, configuration.Server.Path, configuration.Server.Port); err != nil {
 			logger.Fatalf("Could not configure healthcheck: %v", err)
@@ -207,3 +247,73 @@ func Start(configuration schema.Configuration, providers middlewares.Providers)
 		logger.Fatal(server.Serve(listener))
 	}
 }
+
+func registerAdminRoutes(configuration schema.Configuration, providers middlewares.Providers) fasthttp.RequestHandler {
+	r := router.New()
+	r.SaveMatchedRoutePath = configuration.Tracing.Enabled
+
+	handlers.RegisterAdmin(r, middlewares.AutheliaMiddleware(configuration, providers))
+
+	return middlewares.LogRequestMiddleware(r.Handler)
+}
+
+// StartAdmin starts Authelia's administrative API webserver with the given configuration and providers. It's served on
+// its own listener so it can be bound to a different interface than the portal.
+func StartAdmin(configuration schema.Configuration, providers middlewares.Providers) {
+	logger := logging.Logger()
+
+	admin := configuration.Server.Admin
+
+	server := &fasthttp.Server{
+		ErrorHandler:          autheliaErrorHandler,
+		Handler:               registerAdminRoutes(configuration, providers),
+		NoDefaultServerHeader: true,
+		ReadBufferSize:        configuration.Server.ReadBufferSize,
+		WriteBufferSize:       configuration.Server.WriteBufferSize,
+	}
+
+	addrPattern := net.JoinHostPort(admin.Host, strconv.Itoa(admin.Port))
+
+	listener, err := net.Listen("tcp", addrPattern)
+	if err != nil {
+		logger.Fatalf("Error initializing administrative API listener: %s", err)
+	}
+
+	if admin.TLS.Certificate != "" && admin.TLS.Key != "" {
+		logger.Infof("Administrative API listening for TLS connections on '%s'", addrPattern)
+
+		logger.Fatal(server.ServeTLS(listener, admin.TLS.Certificate, admin.TLS.Key))
+	} else {
+		logger.Infof("Administrative API listening for non-TLS connections on '%s'", addrPattern)
+
+		logger.Fatal(server.Serve(listener))
+	}
+}
+
+// StartMetrics starts the webserver exposing the metrics with the given configuration on its own listener so they can
+// be scraped without exposing them on the same interface as the portal.
+func StartMetrics(configuration schema.Configuration) {
+	logger := logging.Logger()
+
+	r := router.New()
+	r.GET("/metrics", metrics.Handler())
+
+	server := &fasthttp.Server{
+		ErrorHandler:          autheliaErrorHandler,
+		Handler:               middlewares.LogRequestMiddleware(r.Handler),
+		NoDefaultServerHeader: true,
+		ReadBufferSize:        configuration.Server.ReadBufferSize,
+		WriteBufferSize:       configuration.Server.WriteBufferSize,
+	}
+
+	addrPattern := net.JoinHostPort(configuration.Server.Metrics.Host, strconv.Itoa(configuration.Server.Metrics.Port))
+
+	listener, err := net.Listen("tcp", addrPattern)
+	if err != nil {
+		logger.Fatalf("Error initializing metrics listener: %s", err)
+	}
+
+	logger.Infof("Metrics listening for non-TLS connections on '%s' path '/metrics'", addrPattern)
+
+	logger.Fatal(server.Serve(listener))
+}
@@ -9,5 +9,9 @@ const (
 
 const (
 	userSessionStorerKey = "UserSession"
+	inventoryStorerKey   = "Inventory"
+	inventoryKeyPrefix   = "inventory:"
+	redisKeyPrefix       = "authelia-session"
 	randomSessionChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_!#$%^*"
+	requestHostUserValue = "authelia_session_host"
 )
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"net"
+	"strings"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewCookieConfigurations returns the session cookies of the session configuration. The cookie of the domain option
+// comes first and is the default cookie. The options a cookie doesn't set are inherited from the session configuration.
+func NewCookieConfigurations(configuration schema.SessionConfiguration) (cookies []schema.SessionCookieConfiguration) {
+	cookies = make([]schema.SessionCookieConfiguration, 0, len(configuration.Cookies)+1)
+
+	if configuration.Domain != "" || len(configuration.Cookies) == 0 {
+		cookies = append(cookies, schema.SessionCookieConfiguration{
+			Domain:     configuration.Domain,
+			Name:       configuration.Name,
+			SameSite:   configuration.SameSite,
+			Expiration: configuration.Expiration,
+		})
+	}
+
+	for _, cookie := range configuration.Cookies {
+		if cookie.Name == "" {
+			cookie.Name = configuration.Name
+		}
+
+		if cookie.SameSite == "" {
+			cookie.SameSite = configuration.SameSite
+		}
+
+		if cookie.Expiration == "" {
+			cookie.Expiration = configuration.Expiration
+		}
+
+		cookies = append(cookies, cookie)
+	}
+
+	return cookies
+}
+
+// GetCookieConfiguration returns the session cookie used for the request. It's the cookie with the most specific
+// domain the host of the request belongs to, falling back to the default cookie when none matches.
+func GetCookieConfiguration(configuration schema.SessionConfiguration, ctx *fasthttp.RequestCtx) schema.SessionCookieConfiguration {
+	cookies := NewCookieConfigurations(configuration)
+
+	return cookies[matchCookie(cookies, requestHost(ctx))]
+}
+
+// SetRequestHost sets the host the session cookie of the request is selected with. The verify endpoint sets the host of
+// the protected resource as the proxies don't all send it in the X-Forwarded-Host header.
+func SetRequestHost(ctx *fasthttp.RequestCtx, host string) {
+	ctx.SetUserValue(requestHostUserValue, strings.ToLower(host))
+}
+
+// requestHost returns the host of the request without the port. The host set with SetRequestHost takes precedence,
+// followed by the X-Forwarded-Host header and the Host header.
+func requestHost(ctx *fasthttp.RequestCtx) string {
+	if host, ok := ctx.UserValue(requestHostUserValue).(string); ok && host != "" {
+		return host
+	}
+
+	host := ctx.Request.Header.Peek(fasthttp.HeaderXForwardedHost)
+	if len(host) == 0 {
+		host = ctx.Request.Host()
+	}
+
+	hostname := strings.ToLower(string(host))
+
+	if h, _, err := net.SplitHostPort(hostname); err == nil {
+		return h
+	}
+
+	return hostname
+}
+
+// matchCookie returns the index of the cookie with the longest domain the host is equal to or a subdomain of. The
+// index of the default cookie is returned when none of the domains match.
+func matchCookie(cookies []schema.SessionCookieConfiguration, host string) (index int) {
+	length := 0
+
+	for i, cookie := range cookies {
+		domain := strings.ToLower(cookie.Domain)
+
+		if len(domain) <= length || (host != domain && !strings.HasSuffix(host, "."+domain)) {
+			continue
+		}
+
+		index, length = i, len(domain)
+	}
+
+	return index
+}
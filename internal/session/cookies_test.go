@@ -0,0 +1,118 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newTestMultiDomainConfiguration() schema.SessionConfiguration {
+	configuration := schema.SessionConfiguration{}
+	configuration.Domain = testDomain
+	configuration.Name = testName
+	configuration.Expiration = testExpiration
+	configuration.SameSite = "lax"
+	configuration.Cookies = []schema.SessionCookieConfiguration{
+		{Domain: "example.org", AutheliaURL: "https://auth.example.org", Name: "org_session", SameSite: "strict"},
+		{Domain: "dev.example.org", Expiration: "2h"},
+	}
+
+	return configuration
+}
+
+func newTestRequest(host string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.Set(fasthttp.HeaderXForwardedHost, host)
+
+	return ctx
+}
+
+func TestShouldInheritCookieConfigurationOptions(t *testing.T) {
+	cookies := NewCookieConfigurations(newTestMultiDomainConfiguration())
+
+	require.Len(t, cookies, 3)
+
+	assert.Equal(t, schema.SessionCookieConfiguration{Domain: testDomain, Name: testName, SameSite: "lax", Expiration: testExpiration}, cookies[0])
+	assert.Equal(t, schema.SessionCookieConfiguration{Domain: "example.org", AutheliaURL: "https://auth.example.org", Name: "org_session", SameSite: "strict", Expiration: testExpiration}, cookies[1])
+	assert.Equal(t, schema.SessionCookieConfiguration{Domain: "dev.example.org", Name: testName, SameSite: "lax", Expiration: "2h"}, cookies[2])
+}
+
+func TestShouldSelectCookieConfigurationFromHost(t *testing.T) {
+	configuration := newTestMultiDomainConfiguration()
+
+	testCases := []struct {
+		host, expected string
+	}{
+		{"app.example.com", testDomain},
+		{"example.com:8443", testDomain},
+		{"auth.example.org", "example.org"},
+		{"APP.Example.Org", "example.org"},
+		{"app.dev.example.org", "dev.example.org"},
+		{"notexample.org", testDomain},
+		{"example.net", testDomain},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.host, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetCookieConfiguration(configuration, newTestRequest(tc.host)).Domain)
+		})
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetHost("auth.example.org")
+
+	assert.Equal(t, "example.org", GetCookieConfiguration(configuration, ctx).Domain)
+
+	// The host set with SetRequestHost takes precedence over the headers.
+	ctx = newTestRequest("app.example.com")
+	SetRequestHost(ctx, "App.Dev.Example.Org")
+
+	assert.Equal(t, "dev.example.org", GetCookieConfiguration(configuration, ctx).Domain)
+}
+
+func TestShouldUseCookieOfRequestDomain(t *testing.T) {
+	provider := NewProvider(newTestMultiDomainConfiguration(), nil, nil)
+
+	ctx := newTestRequest("auth.example.org")
+
+	userSession, err := provider.GetSession(ctx)
+	require.NoError(t, err)
+
+	userSession.SetOneFactor(userSession.RefreshTTL, &authentication.UserDetails{Username: testUsername}, false)
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey("org_session")
+	require.True(t, ctx.Response.Header.Cookie(cookie))
+
+	assert.Equal(t, "example.org", string(cookie.Domain()))
+	assert.Equal(t, fasthttp.CookieSameSiteStrictMode, cookie.SameSite())
+
+	cookie.SetKey(testName)
+	assert.False(t, ctx.Response.Header.Cookie(cookie))
+
+	// The session cookie of the example.org domain is only read for hosts on this domain.
+	sessionID := provider.getSessionID(ctx)
+	require.NotEmpty(t, sessionID)
+
+	request := newTestRequest("app.example.org")
+	request.Request.Header.SetCookieBytesKV([]byte("org_session"), sessionID)
+
+	userSession, err = provider.GetSession(request)
+	require.NoError(t, err)
+	assert.Equal(t, testUsername, userSession.Username)
+
+	request = newTestRequest("app.example.com")
+	request.Request.Header.SetCookieBytesKV([]byte("org_session"), sessionID)
+
+	userSession, err = provider.GetSession(request)
+	require.NoError(t, err)
+	assert.Equal(t, "", userSession.Username)
+}
@@ -0,0 +1,263 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	fasthttpsession "github.com/fasthttp/session/v2"
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ErrSessionNotFound is returned when a session which is not in the inventory of a user is revoked.
+var ErrSessionNotFound = errors.New("session not found")
+
+// ActiveSession is an authenticated session from the inventory of a user.
+type ActiveSession struct {
+	ID        string
+	SessionID []byte
+
+	RemoteIP  string
+	UserAgent string
+	Device    string
+	Created   time.Time
+
+	KeepMeLoggedIn bool
+	LastActivity   time.Time
+
+	FirstFactorAuthn  time.Time
+	SecondFactorAuthn time.Time
+}
+
+// inventoryRecord is the serialized form of an entry in the inventory of a user.
+type inventoryRecord struct {
+	ID        string `json:"id"`
+	SessionID string `json:"session_id"`
+	RemoteIP  string `json:"remote_ip"`
+	UserAgent string `json:"user_agent"`
+	Created   int64  `json:"created"`
+}
+
+// RecordSession adds the current session to the inventory of the given user. It should be called once the session ID
+// is regenerated after a successful first factor authentication.
+func (p *Provider) RecordSession(ctx *fasthttp.RequestCtx, username string, remoteIP net.IP) (err error) {
+	sessionID := p.getSessionID(ctx)
+	if len(sessionID) == 0 {
+		return fmt.Errorf("unable to record session for user '%s': the request has no session", username)
+	}
+
+	return p.inventory.Save(username, inventoryRecord{
+		ID:        uuid.New().String(),
+		SessionID: string(sessionID),
+		RemoteIP:  remoteIP.String(),
+		UserAgent: string(ctx.UserAgent()),
+		Created:   time.Now().Unix(),
+	}, p.inventoryExpiration())
+}
+
+// ListSessions returns the sessions of the given user which are still active. Sessions which have expired or been
+// destroyed are removed from the inventory.
+func (p *Provider) ListSessions(username string) (sessions []ActiveSession, err error) {
+	records, err := p.inventory.Load(username)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions = make([]ActiveSession, 0, len(records))
+
+	for _, record := range records {
+		userSession, found, err := p.loadSessionByID([]byte(record.SessionID))
+		if err != nil {
+			return nil, err
+		}
+
+		if !found || userSession.Username != username {
+			if err = p.inventory.Delete(username, record.SessionID); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		sessions = append(sessions, ActiveSession{
+			ID:                record.ID,
+			SessionID:         []byte(record.SessionID),
+			RemoteIP:          record.RemoteIP,
+			UserAgent:         record.UserAgent,
+			Device:            deviceFromUserAgent(record.UserAgent),
+			Created:           time.Unix(record.Created, 0),
+			KeepMeLoggedIn:    userSession.KeepMeLoggedIn,
+			LastActivity:      unixOrZero(userSession.LastActivity),
+			FirstFactorAuthn:  unixOrZero(userSession.FirstFactorAuthnTimestamp),
+			SecondFactorAuthn: unixOrZero(userSession.SecondFactorAuthnTimestamp),
+		})
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession destroys the session with the given inventory ID which belongs to the given user.
+func (p *Provider) RevokeSession(username, id string) (err error) {
+	records, err := p.inventory.Load(username)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if record.ID != id {
+			continue
+		}
+
+		return p.revokeRecord(username, record)
+	}
+
+	return ErrSessionNotFound
+}
+
+// RevokeSessions destroys all of the sessions of the given user and returns the number of sessions destroyed.
+func (p *Provider) RevokeSessions(username string) (count int, err error) {
+	records, err := p.inventory.Load(username)
+	if err != nil {
+		return 0, err
+	}
+
+	// The records are deleted one by one so a session recorded concurrently is kept in the inventory.
+	for _, record := range records {
+		if err = p.revokeRecord(username, record); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// IsCurrentSession returns true if the given session is the session of the request.
+func (p *Provider) IsCurrentSession(ctx *fasthttp.RequestCtx, session ActiveSession) bool {
+	return bytes.Equal(p.getSessionID(ctx), session.SessionID)
+}
+
+func (p *Provider) revokeRecord(username string, record inventoryRecord) (err error) {
+	if err = p.provider.Destroy([]byte(record.SessionID)); err != nil {
+		return fmt.Errorf("unable to destroy session '%s' of user '%s': %w", record.ID, username, err)
+	}
+
+	return p.inventory.Delete(username, record.SessionID)
+}
+
+func (p *Provider) renameInventoryRecord(username string, sessionID, newSessionID []byte) (err error) {
+	records, err := p.inventory.Load(username)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if record.SessionID != string(sessionID) {
+			continue
+		}
+
+		record.SessionID = string(newSessionID)
+
+		if err = p.inventory.Save(username, record, p.inventoryExpiration()); err != nil {
+			return err
+		}
+
+		return p.inventory.Delete(username, string(sessionID))
+	}
+
+	return nil
+}
+
+func (p *Provider) removeInventoryRecord(username string, sessionID []byte) (err error) {
+	return p.inventory.Delete(username, string(sessionID))
+}
+
+// inventoryExpiration returns the longest lifetime a session in the inventory can have.
+func (p *Provider) inventoryExpiration() (expiration time.Duration) {
+	expiration = p.RememberMe
+
+	for _, cookie := range p.cookies {
+		if duration, _ := utils.ParseDurationString(cookie.Expiration); duration > expiration {
+			expiration = duration
+		}
+	}
+
+	return expiration
+}
+
+func (p *Provider) loadSessionByID(sessionID []byte) (userSession UserSession, found bool, err error) {
+	if len(sessionID) == 0 {
+		return userSession, false, nil
+	}
+
+	data, err := p.provider.Get(sessionID)
+	if err != nil {
+		return userSession, false, fmt.Errorf("unable to load session: %w", err)
+	}
+
+	if len(data) == 0 {
+		return userSession, false, nil
+	}
+
+	dict := fasthttpsession.Dict{}
+
+	if err = p.config.DecodeFunc(&dict, data); err != nil {
+		return userSession, false, fmt.Errorf("unable to decode session: %w", err)
+	}
+
+	userSessionJSON, ok := dict.Get(userSessionStorerKey).([]byte)
+	if !ok {
+		return userSession, false, nil
+	}
+
+	if err = json.Unmarshal(userSessionJSON, &userSession); err != nil {
+		return userSession, false, fmt.Errorf("unable to decode session: %w", err)
+	}
+
+	return userSession, true, nil
+}
+
+// getSessionID returns a copy of the session ID of the request as the underlying buffer is reused when the session
+// cookie is changed.
+func (p *Provider) getSessionID(ctx *fasthttp.RequestCtx) []byte {
+	return append([]byte(nil), ctx.Request.Header.Cookie(p.cookies[matchCookie(p.cookies, requestHost(ctx))].Name)...)
+}
+
+func removeRecord(records []inventoryRecord, sessionID string) []inventoryRecord {
+	remaining := make([]inventoryRecord, 0, len(records))
+
+	for _, record := range records {
+		if record.SessionID != sessionID {
+			remaining = append(remaining, record)
+		}
+	}
+
+	return remaining
+}
+
+// sortRecords sorts the records from the oldest to the most recent.
+func sortRecords(records []inventoryRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		if records[i].Created != records[j].Created {
+			return records[i].Created < records[j].Created
+		}
+
+		return records[i].ID < records[j].ID
+	})
+}
+
+func unixOrZero(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(timestamp, 0)
+}
@@ -0,0 +1,247 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	fasthttpsession "github.com/fasthttp/session/v2"
+	fasthttpredis "github.com/fasthttp/session/v2/providers/redis"
+	"github.com/go-redis/redis/v8"
+
+	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// inventoryStore stores the inventory records of the users. Each record is saved and deleted on its own so the
+// concurrent requests of every Authelia instance sharing the session store don't overwrite each other's records.
+type inventoryStore interface {
+	// Save saves the record, replacing the record of the same session.
+	Save(username string, record inventoryRecord, expiration time.Duration) (err error)
+
+	// Load returns the records of the user from the oldest to the most recent.
+	Load(username string) (records []inventoryRecord, err error)
+
+	// Delete deletes the record of the session.
+	Delete(username, sessionID string) (err error)
+}
+
+// memoryInventoryStore is the inventory store of the memory session provider. The sessions only exist in the process
+// so the records don't need to be shared either.
+type memoryInventoryStore struct {
+	mutex   sync.Mutex
+	records map[string][]inventoryRecord
+}
+
+func newMemoryInventoryStore() *memoryInventoryStore {
+	return &memoryInventoryStore{records: map[string][]inventoryRecord{}}
+}
+
+func (s *memoryInventoryStore) Save(username string, record inventoryRecord, _ time.Duration) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.records[username] = append(removeRecord(s.records[username], record.SessionID), record)
+
+	return nil
+}
+
+func (s *memoryInventoryStore) Load(username string) (records []inventoryRecord, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]inventoryRecord(nil), s.records[username]...), nil
+}
+
+func (s *memoryInventoryStore) Delete(username, sessionID string) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if remaining := removeRecord(s.records[username], sessionID); len(remaining) != 0 {
+		s.records[username] = remaining
+	} else {
+		delete(s.records, username)
+	}
+
+	return nil
+}
+
+// redisInventoryStore stores the records of each user in a Redis hash whose fields are the session IDs. The values are
+// encoded with the serializer of the sessions so they're encrypted like the sessions themselves.
+type redisInventoryStore struct {
+	db        redis.Cmdable
+	keyPrefix string
+
+	encode func(src fasthttpsession.Dict) ([]byte, error)
+	decode func(dst *fasthttpsession.Dict, src []byte) error
+}
+
+func newRedisInventoryStore(db redis.Cmdable, config fasthttpsession.Config) *redisInventoryStore {
+	return &redisInventoryStore{db: db, keyPrefix: redisKeyPrefix, encode: config.EncodeFunc, decode: config.DecodeFunc}
+}
+
+// newRedisClient returns a client with the options of the Redis session provider, which doesn't expose its own client.
+func newRedisClient(config *fasthttpredis.Config) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Network:      config.Network,
+		Addr:         config.Addr,
+		Username:     config.Username,
+		Password:     config.Password,
+		DB:           config.DB,
+		PoolSize:     config.PoolSize,
+		MinIdleConns: config.MinIdleConns,
+		IdleTimeout:  config.IdleTimeout,
+		TLSConfig:    config.TLSConfig,
+	})
+}
+
+// newRedisFailoverClient returns a client with the options of the Redis Sentinel session provider, which doesn't expose
+// its own client.
+func newRedisFailoverClient(config *fasthttpredis.FailoverConfig) *redis.ClusterClient {
+	return redis.NewFailoverClusterClient(&redis.FailoverOptions{
+		MasterName:       config.MasterName,
+		SentinelAddrs:    config.SentinelAddrs,
+		SentinelUsername: config.SentinelUsername,
+		SentinelPassword: config.SentinelPassword,
+		RouteByLatency:   config.RouteByLatency,
+		RouteRandomly:    config.RouteRandomly,
+		Username:         config.Username,
+		Password:         config.Password,
+		DB:               config.DB,
+		PoolSize:         config.PoolSize,
+		MinIdleConns:     config.MinIdleConns,
+		IdleTimeout:      config.IdleTimeout,
+		TLSConfig:        config.TLSConfig,
+	})
+}
+
+// key returns the key of the hash of the user. It differs from the key the inventory was stored at as a single value
+// so the values left by previous versions don't conflict with the hashes.
+func (s *redisInventoryStore) key(username string) string {
+	return s.keyPrefix + "-" + inventoryKeyPrefix + username
+}
+
+func (s *redisInventoryStore) Save(username string, record inventoryRecord, expiration time.Duration) (err error) {
+	value, err := s.encodeRecord(record)
+	if err != nil {
+		return fmt.Errorf("unable to encode the session inventory record of user '%s': %w", username, err)
+	}
+
+	ctx := context.Background()
+
+	// The hash lives as long as the longest session which can be added to it.
+	_, err = s.db.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, s.key(username), record.SessionID, value)
+		pipe.Expire(ctx, s.key(username), expiration)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("unable to save the session inventory record of user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+func (s *redisInventoryStore) Load(username string) (records []inventoryRecord, err error) {
+	values, err := s.db.HGetAll(context.Background(), s.key(username)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the session inventory of user '%s': %w", username, err)
+	}
+
+	records = make([]inventoryRecord, 0, len(values))
+
+	for _, value := range values {
+		record, err := s.decodeRecord([]byte(value))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the session inventory of user '%s': %w", username, err)
+		}
+
+		records = append(records, record)
+	}
+
+	sortRecords(records)
+
+	return records, nil
+}
+
+func (s *redisInventoryStore) Delete(username, sessionID string) (err error) {
+	if err = s.db.HDel(context.Background(), s.key(username), sessionID).Err(); err != nil {
+		return fmt.Errorf("unable to delete the session inventory record of user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+func (s *redisInventoryStore) encodeRecord(record inventoryRecord) (value []byte, err error) {
+	recordJSON, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+
+	dict := fasthttpsession.Dict{}
+	dict.Set(inventoryStorerKey, recordJSON)
+
+	return s.encode(dict)
+}
+
+func (s *redisInventoryStore) decodeRecord(value []byte) (record inventoryRecord, err error) {
+	dict := fasthttpsession.Dict{}
+
+	if err = s.decode(&dict, value); err != nil {
+		return record, err
+	}
+
+	recordJSON, ok := dict.Get(inventoryStorerKey).([]byte)
+	if !ok {
+		return record, fmt.Errorf("the record has no value")
+	}
+
+	err = json.Unmarshal(recordJSON, &record)
+
+	return record, err
+}
+
+// storageInventoryStore stores each record in a row of the storage provider, used along with the storage session
+// provider.
+type storageInventoryStore struct {
+	storage storage.Provider
+}
+
+func (s *storageInventoryStore) Save(username string, record inventoryRecord, _ time.Duration) (err error) {
+	return s.storage.SaveSessionInventoryRecord(context.Background(), models.SessionInventoryRecord{
+		RecordID:  record.ID,
+		SessionID: record.SessionID,
+		Username:  username,
+		RemoteIP:  record.RemoteIP,
+		UserAgent: record.UserAgent,
+		CreatedAt: time.Unix(record.Created, 0),
+	})
+}
+
+func (s *storageInventoryStore) Load(username string) (records []inventoryRecord, err error) {
+	rows, err := s.storage.LoadSessionInventoryRecords(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	records = make([]inventoryRecord, len(rows))
+
+	for i, row := range rows {
+		records[i] = inventoryRecord{
+			ID:        row.RecordID,
+			SessionID: row.SessionID,
+			RemoteIP:  row.RemoteIP,
+			UserAgent: row.UserAgent,
+			Created:   row.CreatedAt.Unix(),
+		}
+	}
+
+	return records, nil
+}
+
+func (s *storageInventoryStore) Delete(username, sessionID string) (err error) {
+	return s.storage.DeleteSessionInventoryRecord(context.Background(), username, sessionID)
+}
@@ -0,0 +1,186 @@
+package session
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func newTestInventoryProvider() *Provider {
+	configuration := schema.SessionConfiguration{}
+	configuration.Domain = testDomain
+	configuration.Name = testName
+	configuration.Expiration = testExpiration
+
+	return NewProvider(configuration, nil, nil)
+}
+
+func newTestAuthenticatedRequest(t *testing.T, provider *Provider, userAgent string) *fasthttp.RequestCtx {
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetUserAgent(userAgent)
+
+	userSession, err := provider.GetSession(ctx)
+	require.NoError(t, err)
+
+	userSession.SetOneFactor(time.Unix(1625048140, 0), &authentication.UserDetails{Username: testUsername}, false)
+
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+	require.NoError(t, provider.RecordSession(ctx, testUsername, net.ParseIP("192.168.0.10")))
+
+	return ctx
+}
+
+func TestShouldRecordAndListSessions(t *testing.T) {
+	provider := newTestInventoryProvider()
+
+	ctx := newTestAuthenticatedRequest(t, provider, "Mozilla/5.0 (X11; Linux x86_64; rv:95.0) Gecko/20100101 Firefox/95.0")
+	other := newTestAuthenticatedRequest(t, provider, "curl/7.80.0")
+
+	sessions, err := provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	assert.Equal(t, "192.168.0.10", sessions[0].RemoteIP)
+	assert.Equal(t, "Firefox on Linux", sessions[0].Device)
+	assert.Equal(t, time.Unix(1625048140, 0), sessions[0].FirstFactorAuthn)
+	assert.True(t, sessions[0].SecondFactorAuthn.IsZero())
+	assert.True(t, provider.IsCurrentSession(ctx, sessions[0]))
+	assert.False(t, provider.IsCurrentSession(other, sessions[0]))
+
+	assert.Equal(t, "curl", sessions[1].Device)
+	assert.True(t, provider.IsCurrentSession(other, sessions[1]))
+
+	sessions, err = provider.ListSessions("harry")
+	require.NoError(t, err)
+	assert.Len(t, sessions, 0)
+}
+
+func TestShouldKeepSessionInInventoryWhenRegenerated(t *testing.T) {
+	provider := newTestInventoryProvider()
+
+	ctx := newTestAuthenticatedRequest(t, provider, "")
+
+	sessions, err := provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	id := sessions[0].ID
+
+	require.NoError(t, provider.RegenerateSession(ctx))
+
+	sessions, err = provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+
+	assert.Equal(t, id, sessions[0].ID)
+	assert.True(t, provider.IsCurrentSession(ctx, sessions[0]))
+}
+
+func TestShouldRemoveDestroyedSessionFromInventory(t *testing.T) {
+	provider := newTestInventoryProvider()
+
+	ctx := newTestAuthenticatedRequest(t, provider, "")
+
+	require.NoError(t, provider.DestroySession(ctx))
+
+	sessions, err := provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 0)
+}
+
+func TestShouldRevokeSession(t *testing.T) {
+	provider := newTestInventoryProvider()
+
+	ctx := newTestAuthenticatedRequest(t, provider, "")
+	other := newTestAuthenticatedRequest(t, provider, "")
+
+	sessions, err := provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+
+	assert.EqualError(t, provider.RevokeSession(testUsername, "invalid"), "session not found")
+	assert.EqualError(t, provider.RevokeSession("harry", sessions[1].ID), "session not found")
+
+	require.NoError(t, provider.RevokeSession(testUsername, sessions[1].ID))
+
+	userSession, err := provider.GetSession(other)
+	require.NoError(t, err)
+	assert.Equal(t, "", userSession.Username)
+
+	userSession, err = provider.GetSession(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testUsername, userSession.Username)
+
+	sessions, err = provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.True(t, provider.IsCurrentSession(ctx, sessions[0]))
+}
+
+func TestShouldRevokeAllSessions(t *testing.T) {
+	provider := newTestInventoryProvider()
+
+	ctx := newTestAuthenticatedRequest(t, provider, "")
+	other := newTestAuthenticatedRequest(t, provider, "")
+
+	count, err := provider.RevokeSessions(testUsername)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	for _, c := range []*fasthttp.RequestCtx{ctx, other} {
+		userSession, err := provider.GetSession(c)
+		require.NoError(t, err)
+		assert.Equal(t, "", userSession.Username)
+	}
+
+	sessions, err := provider.ListSessions(testUsername)
+	require.NoError(t, err)
+	assert.Len(t, sessions, 0)
+}
+
+func TestShouldDescribeDeviceFromUserAgent(t *testing.T) {
+	testCases := []struct {
+		userAgent, expected string
+	}{
+		{"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.110 Safari/537.36 Edg/96.0.1054.62", "Edge on Windows"},
+		{"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.1 Safari/605.1.15", "Safari on macOS"},
+		{"Mozilla/5.0 (Linux; Android 12) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/96.0.4664.104 Mobile Safari/537.36", "Chrome on Android"},
+		{"Mozilla/5.0 (iPhone; CPU iPhone OS 15_2 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/15.2 Mobile/15E148 Safari/604.1", "Safari on iOS"},
+		{"curl/7.80.0", "curl"},
+		{"", "Unknown"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, deviceFromUserAgent(tc.userAgent))
+		})
+	}
+}
+
+func TestShouldEncodeRedisInventoryRecords(t *testing.T) {
+	serializer := NewEncryptingSerializer("a_secret")
+
+	store := &redisInventoryStore{keyPrefix: redisKeyPrefix, encode: serializer.Encode, decode: serializer.Decode}
+
+	assert.Equal(t, "authelia-session-inventory:john", store.key(testUsername))
+
+	record := inventoryRecord{ID: "a", SessionID: "session-a", RemoteIP: "192.168.0.10", UserAgent: "curl/7.80.0", Created: 1625048140}
+
+	value, err := store.encodeRecord(record)
+	require.NoError(t, err)
+	assert.NotContains(t, string(value), "session-a")
+
+	decoded, err := store.decodeRecord(value)
+	require.NoError(t, err)
+	assert.Equal(t, record, decoded)
+
+	_, err = (&redisInventoryStore{encode: serializer.Encode, decode: NewEncryptingSerializer("another_secret").Decode}).decodeRecord(value)
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"time"
@@ -12,22 +13,41 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/tracing"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // Provider a session provider.
 type Provider struct {
-	sessionHolder *fasthttpsession.Session
-	RememberMe    time.Duration
-	Inactivity    time.Duration
+	cookies    []schema.SessionCookieConfiguration
+	holders    []*fasthttpsession.Session
+	provider   fasthttpsession.Provider
+	config     fasthttpsession.Config
+	RememberMe time.Duration
+	Inactivity time.Duration
+
+	inventory inventoryStore
 }
 
-// NewProvider instantiate a session provider given a configuration.
-func NewProvider(configuration schema.SessionConfiguration, certPool *x509.CertPool) *Provider {
+// NewProvider instantiate a session provider given a configuration. The storage provider is only used when the
+// sessions are configured to be stored in it.
+func NewProvider(configuration schema.SessionConfiguration, certPool *x509.CertPool, storageProvider storage.Provider) *Provider {
 	providerConfig := NewProviderConfig(configuration, certPool)
 
 	provider := new(Provider)
-	provider.sessionHolder = fasthttpsession.New(providerConfig.config)
+	provider.cookies = NewCookieConfigurations(configuration)
+	provider.config = providerConfig.config
+
+	// Each cookie has its own session holder but they all share the same storage.
+	for _, cookie := range provider.cookies {
+		provider.holders = append(provider.holders, fasthttpsession.New(NewCookieConfig(providerConfig.config, cookie)))
+	}
+
+	// Use the same defaults as the session holder to decode the sessions when no serializer is configured.
+	if provider.config.EncodeFunc == nil || provider.config.DecodeFunc == nil {
+		provider.config.EncodeFunc, provider.config.DecodeFunc = fasthttpsession.Base64Encode, fasthttpsession.Base64Decode
+	}
 
 	logger := logging.Logger()
 
@@ -53,29 +73,72 @@ func NewProvider(configuration schema.SessionConfiguration, certPool *x509.CertP
 		if err != nil {
 			logger.Fatal(err)
 		}
+
+		provider.inventory = newRedisInventoryStore(newRedisClient(providerConfig.redisConfig), provider.config)
+	case providerConfig.redisClusterConfig != nil:
+		var clusterProvider *redisClusterProvider
+
+		clusterProvider, err = newRedisClusterProvider(providerConfig.redisClusterConfig, redisKeyPrefix)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		providerImpl = clusterProvider
+		provider.inventory = newRedisInventoryStore(clusterProvider.db, provider.config)
 	case providerConfig.redisSentinelConfig != nil:
 		providerImpl, err = redis.NewFailoverCluster(*providerConfig.redisSentinelConfig)
 		if err != nil {
 			logger.Fatal(err)
 		}
+
+		provider.inventory = newRedisInventoryStore(newRedisFailoverClient(providerConfig.redisSentinelConfig), provider.config)
+	case configuration.Storage != nil:
+		if storageProvider == nil {
+			logger.Fatal("the storage session provider requires a storage provider")
+		}
+
+		duration, err = utils.ParseDurationString(configuration.Storage.GarbageCollectionInterval)
+		if err != nil {
+			logger.Fatal(err)
+		}
+
+		providerImpl = newStorageProvider(storageProvider, duration)
+		provider.inventory = &storageInventoryStore{storage: storageProvider}
 	default:
 		providerImpl, err = memory.New(memory.Config{})
 		if err != nil {
 			logger.Fatal(err)
 		}
+
+		provider.inventory = newMemoryInventoryStore()
 	}
 
-	err = provider.sessionHolder.SetProvider(providerImpl)
-	if err != nil {
-		logger.Fatal(err)
+	for _, holder := range provider.holders {
+		if err = holder.SetProvider(providerImpl); err != nil {
+			logger.Fatal(err)
+		}
 	}
 
+	provider.provider = providerImpl
+
 	return provider
 }
 
+// StartGC deletes the expired sessions at an interval until the context is done when the sessions are stored in the
+// storage provider, the other providers expire the sessions on their own. It's meant to be run in its own goroutine
+// by the server, the commands which only manage the sessions don't run it.
+func (p *Provider) StartGC(ctx context.Context) {
+	if provider, ok := p.provider.(*storageProvider); ok {
+		provider.startGC(ctx)
+	}
+}
+
 // GetSession return the user session from a request.
-func (p *Provider) GetSession(ctx *fasthttp.RequestCtx) (UserSession, error) {
-	store, err := p.sessionHolder.Get(ctx)
+func (p *Provider) GetSession(ctx *fasthttp.RequestCtx) (userSession UserSession, err error) {
+	_, span := tracing.Start(tracing.RequestContext(ctx), "session.GetSession")
+	defer func() { tracing.End(span, err) }()
+
+	store, err := p.sessionHolder(ctx).Get(ctx)
 
 	if err != nil {
 		return NewDefaultUserSession(), err
@@ -86,14 +149,13 @@ func (p *Provider) GetSession(ctx *fasthttp.RequestCtx) (UserSession, error) {
 	// If userSession is not yet defined we create the new session with default values
 	// and save it in the store.
 	if !ok {
-		userSession := NewDefaultUserSession()
+		userSession = NewDefaultUserSession()
 
 		store.Set(userSessionStorerKey, userSession)
 
 		return userSession, nil
 	}
 
-	var userSession UserSession
 	err = json.Unmarshal(userSessionJSON, &userSession)
 
 	if err != nil {
@@ -104,8 +166,11 @@ func (p *Provider) GetSession(ctx *fasthttp.RequestCtx) (UserSession, error) {
 }
 
 // SaveSession save the user session.
-func (p *Provider) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession) error {
-	store, err := p.sessionHolder.Get(ctx)
+func (p *Provider) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession) (err error) {
+	_, span := tracing.Start(tracing.RequestContext(ctx), "session.SaveSession")
+	defer func() { tracing.End(span, err) }()
+
+	store, err := p.sessionHolder(ctx).Get(ctx)
 
 	if err != nil {
 		return err
@@ -119,7 +184,7 @@ func (p *Provider) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession
 
 	store.Set(userSessionStorerKey, userSessionJSON)
 
-	err = p.sessionHolder.Save(ctx, store)
+	err = p.sessionHolder(ctx).Save(ctx, store)
 
 	if err != nil {
 		return err
@@ -128,21 +193,50 @@ func (p *Provider) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession
 	return nil
 }
 
-// RegenerateSession regenerate a session ID.
-func (p *Provider) RegenerateSession(ctx *fasthttp.RequestCtx) error {
-	err := p.sessionHolder.Regenerate(ctx)
+// RegenerateSession regenerate a session ID. The inventory of the user is updated with the new session ID.
+func (p *Provider) RegenerateSession(ctx *fasthttp.RequestCtx) (err error) {
+	_, span := tracing.Start(tracing.RequestContext(ctx), "session.RegenerateSession")
+	defer func() { tracing.End(span, err) }()
+
+	sessionID := p.getSessionID(ctx)
+	userSession, _, _ := p.loadSessionByID(sessionID)
+
+	if err = p.sessionHolder(ctx).Regenerate(ctx); err != nil {
+		return err
+	}
+
+	if userSession.Username == "" {
+		return nil
+	}
 
-	return err
+	return p.renameInventoryRecord(userSession.Username, sessionID, p.getSessionID(ctx))
 }
 
-// DestroySession destroy a session ID and delete the cookie.
-func (p *Provider) DestroySession(ctx *fasthttp.RequestCtx) error {
-	return p.sessionHolder.Destroy(ctx)
+// DestroySession destroy a session ID and delete the cookie. The session is removed from the inventory of the user.
+func (p *Provider) DestroySession(ctx *fasthttp.RequestCtx) (err error) {
+	_, span := tracing.Start(tracing.RequestContext(ctx), "session.DestroySession")
+	defer func() { tracing.End(span, err) }()
+
+	sessionID := p.getSessionID(ctx)
+	userSession, _, _ := p.loadSessionByID(sessionID)
+
+	if err = p.sessionHolder(ctx).Destroy(ctx); err != nil {
+		return err
+	}
+
+	if userSession.Username == "" {
+		return nil
+	}
+
+	return p.removeInventoryRecord(userSession.Username, sessionID)
 }
 
 // UpdateExpiration update the expiration of the cookie and session.
-func (p *Provider) UpdateExpiration(ctx *fasthttp.RequestCtx, expiration time.Duration) error {
-	store, err := p.sessionHolder.Get(ctx)
+func (p *Provider) UpdateExpiration(ctx *fasthttp.RequestCtx, expiration time.Duration) (err error) {
+	_, span := tracing.Start(tracing.RequestContext(ctx), "session.UpdateExpiration")
+	defer func() { tracing.End(span, err) }()
+
+	store, err := p.sessionHolder(ctx).Get(ctx)
 
 	if err != nil {
 		return err
@@ -154,12 +248,12 @@ func (p *Provider) UpdateExpiration(ctx *fasthttp.RequestCtx, expiration time.Du
 		return err
 	}
 
-	return p.sessionHolder.Save(ctx, store)
+	return p.sessionHolder(ctx).Save(ctx, store)
 }
 
 // GetExpiration get the expiration of the current session.
 func (p *Provider) GetExpiration(ctx *fasthttp.RequestCtx) (time.Duration, error) {
-	store, err := p.sessionHolder.Get(ctx)
+	store, err := p.sessionHolder(ctx).Get(ctx)
 
 	if err != nil {
 		return time.Duration(0), err
@@ -167,3 +261,8 @@ func (p *Provider) GetExpiration(ctx *fasthttp.RequestCtx) (time.Duration, error
 
 	return store.GetExpiration(), nil
 }
+
+// sessionHolder returns the session holder of the cookie used for the request.
+func (p *Provider) sessionHolder(ctx *fasthttp.RequestCtx) *fasthttpsession.Session {
+	return p.holders[matchCookie(p.cookies, requestHost(ctx))]
+}
@@ -6,9 +6,11 @@ import (
 	"crypto/x509"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fasthttp/session/v2"
 	"github.com/fasthttp/session/v2/providers/redis"
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -39,16 +41,7 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 	config.Domain = configuration.Domain
 
 	// Set the cookie SameSite option.
-	switch configuration.SameSite {
-	case "strict":
-		config.CookieSameSite = fasthttp.CookieSameSiteStrictMode
-	case "none":
-		config.CookieSameSite = fasthttp.CookieSameSiteNoneMode
-	case "lax":
-		config.CookieSameSite = fasthttp.CookieSameSiteLaxMode
-	default:
-		config.CookieSameSite = fasthttp.CookieSameSiteLaxMode
-	}
+	config.CookieSameSite = cookieSameSite(configuration.SameSite)
 
 	// Only serve the header over HTTPS.
 	config.Secure = true
@@ -64,6 +57,8 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 
 	var redisSentinelConfig *redis.FailoverConfig
 
+	var redisClusterConfig *goredis.ClusterOptions
+
 	var providerName string
 
 	// If redis configuration is provided, then use the redis provider.
@@ -77,7 +72,34 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 			tlsConfig = utils.NewTLSConfig(configuration.Redis.TLS, tls.VersionTLS12, certPool)
 		}
 
-		if configuration.Redis.HighAvailability != nil && configuration.Redis.HighAvailability.SentinelName != "" {
+		switch {
+		case configuration.Redis.Cluster != nil:
+			addrs := make([]string, 0)
+
+			if configuration.Redis.Host != "" {
+				addrs = append(addrs, fmt.Sprintf("%s:%d", strings.ToLower(configuration.Redis.Host), configuration.Redis.Port))
+			}
+
+			for _, node := range configuration.Redis.Cluster.Nodes {
+				addr := fmt.Sprintf("%s:%d", strings.ToLower(node.Host), node.Port)
+				if !utils.IsStringInSlice(addr, addrs) {
+					addrs = append(addrs, addr)
+				}
+			}
+
+			providerName = "redis-cluster"
+			redisClusterConfig = &goredis.ClusterOptions{
+				Addrs:          addrs,
+				RouteByLatency: configuration.Redis.Cluster.RouteByLatency,
+				RouteRandomly:  configuration.Redis.Cluster.RouteRandomly,
+				Username:       configuration.Redis.Username,
+				Password:       configuration.Redis.Password,
+				PoolSize:       configuration.Redis.MaximumActiveConnections,
+				MinIdleConns:   configuration.Redis.MinimumIdleConnections,
+				IdleTimeout:    300 * time.Second,
+				TLSConfig:      tlsConfig,
+			}
+		case configuration.Redis.HighAvailability != nil && configuration.Redis.HighAvailability.SentinelName != "":
 			addrs := make([]string, 0)
 
 			if configuration.Redis.Host != "" {
@@ -105,11 +127,11 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 				DB:               configuration.Redis.DatabaseIndex, // DB is the fasthttp/session property for the Redis DB Index.
 				PoolSize:         configuration.Redis.MaximumActiveConnections,
 				MinIdleConns:     configuration.Redis.MinimumIdleConnections,
-				IdleTimeout:      300,
+				IdleTimeout:      300 * time.Second,
 				TLSConfig:        tlsConfig,
-				KeyPrefix:        "authelia-session",
+				KeyPrefix:        redisKeyPrefix,
 			}
-		} else {
+		default:
 			providerName = "redis"
 			network := "tcp"
 
@@ -131,14 +153,16 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 				DB:           configuration.Redis.DatabaseIndex, // DB is the fasthttp/session property for the Redis DB Index.
 				PoolSize:     configuration.Redis.MaximumActiveConnections,
 				MinIdleConns: configuration.Redis.MinimumIdleConnections,
-				IdleTimeout:  300,
+				IdleTimeout:  300 * time.Second,
 				TLSConfig:    tlsConfig,
-				KeyPrefix:    "authelia-session",
+				KeyPrefix:    redisKeyPrefix,
 			}
 		}
 
 		config.EncodeFunc = serializer.Encode
 		config.DecodeFunc = serializer.Decode
+	case configuration.Storage != nil:
+		providerName = "storage"
 	default:
 		providerName = "memory"
 	}
@@ -147,6 +171,31 @@ func NewProviderConfig(configuration schema.SessionConfiguration, certPool *x509
 		config,
 		redisConfig,
 		redisSentinelConfig,
+		redisClusterConfig,
 		providerName,
 	}
 }
+
+// NewCookieConfig creates the configuration of the session holder of a cookie from the configuration created by
+// NewProviderConfig.
+func NewCookieConfig(config session.Config, cookie schema.SessionCookieConfiguration) session.Config {
+	config.CookieName = cookie.Name
+	config.Domain = cookie.Domain
+	config.CookieSameSite = cookieSameSite(cookie.SameSite)
+
+	// Ignore the error as it will be handled by validator.
+	config.Expiration, _ = utils.ParseDurationString(cookie.Expiration)
+
+	return config
+}
+
+func cookieSameSite(sameSite string) fasthttp.CookieSameSite {
+	switch sameSite {
+	case "strict":
+		return fasthttp.CookieSameSiteStrictMode
+	case "none":
+		return fasthttp.CookieSameSiteNoneMode
+	default:
+		return fasthttp.CookieSameSiteLaxMode
+	}
+}
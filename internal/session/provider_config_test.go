@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/fasthttp/session/v2"
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
@@ -63,6 +64,7 @@ func TestShouldCreateRedisSessionProviderTLS(t *testing.T) {
 	assert.Equal(t, 0, pConfig.DB)
 	assert.Equal(t, 0, pConfig.PoolSize)
 	assert.Equal(t, 0, pConfig.MinIdleConns)
+	assert.Equal(t, 300*time.Second, pConfig.IdleTimeout)
 
 	require.NotNil(t, pConfig.TLSConfig)
 	require.Equal(t, uint16(tls.VersionTLS13), pConfig.TLSConfig.MinVersion)
@@ -98,6 +100,7 @@ func TestShouldCreateRedisSessionProvider(t *testing.T) {
 	assert.Equal(t, 0, pConfig.DB)
 	assert.Equal(t, 0, pConfig.PoolSize)
 	assert.Equal(t, 0, pConfig.MinIdleConns)
+	assert.Equal(t, 300*time.Second, pConfig.IdleTimeout)
 
 	assert.Nil(t, pConfig.TLSConfig)
 }
@@ -179,12 +182,80 @@ func TestShouldCreateRedisSentinelSessionProvider(t *testing.T) {
 	assert.False(t, pConfig.RouteByLatency)
 	assert.Equal(t, 8, pConfig.PoolSize)
 	assert.Equal(t, 2, pConfig.MinIdleConns)
+	assert.Equal(t, 300*time.Second, pConfig.IdleTimeout)
 
 	// DbNumber is the fasthttp/session property for the Redis DB Index
 	assert.Equal(t, 0, pConfig.DB)
 	assert.Nil(t, pConfig.TLSConfig)
 }
 
+func TestShouldCreateRedisClusterSessionProvider(t *testing.T) {
+	configuration := schema.SessionConfiguration{}
+	configuration.Domain = testDomain
+	configuration.Name = testName
+	configuration.Expiration = testExpiration
+	configuration.Redis = &schema.RedisSessionConfiguration{
+		Host:                     "Redis.example.com",
+		Port:                     6379,
+		Username:                 "authelia",
+		Password:                 "pass",
+		MaximumActiveConnections: 8,
+		MinimumIdleConnections:   2,
+		TLS: &schema.TLSConfig{
+			ServerName:     "redis.example.com",
+			MinimumVersion: "TLS1.2",
+		},
+		Cluster: &schema.RedisClusterConfiguration{
+			Nodes: []schema.RedisNode{
+				{Host: "redis.example.com", Port: 6379},
+				{Host: "redis2.example.com", Port: 6380},
+			},
+			RedisRouteConfiguration: schema.RedisRouteConfiguration{RouteByLatency: true},
+		},
+	}
+	providerConfig := NewProviderConfig(configuration, nil)
+
+	assert.Nil(t, providerConfig.redisConfig)
+	assert.Nil(t, providerConfig.redisSentinelConfig)
+	assert.Equal(t, "redis-cluster", providerConfig.providerName)
+	assert.NotNil(t, providerConfig.config.EncodeFunc)
+
+	pConfig := providerConfig.redisClusterConfig
+	require.NotNil(t, pConfig)
+	assert.Equal(t, []string{"redis.example.com:6379", "redis2.example.com:6380"}, pConfig.Addrs)
+	assert.Equal(t, "authelia", pConfig.Username)
+	assert.Equal(t, "pass", pConfig.Password)
+	assert.True(t, pConfig.RouteByLatency)
+	assert.False(t, pConfig.RouteRandomly)
+	assert.Equal(t, 8, pConfig.PoolSize)
+	assert.Equal(t, 2, pConfig.MinIdleConns)
+	assert.Equal(t, 300*time.Second, pConfig.IdleTimeout)
+
+	require.NotNil(t, pConfig.TLSConfig)
+	assert.Equal(t, uint16(tls.VersionTLS12), pConfig.TLSConfig.MinVersion)
+	assert.Equal(t, "redis.example.com", pConfig.TLSConfig.ServerName)
+}
+
+func TestShouldFailToCreateRedisClusterProviderWithoutNodes(t *testing.T) {
+	_, err := newRedisClusterProvider(&goredis.ClusterOptions{}, redisKeyPrefix)
+
+	assert.EqualError(t, err, "redis cluster: at least one node address is required")
+}
+
+func TestShouldCreateStorageSessionProvider(t *testing.T) {
+	configuration := schema.SessionConfiguration{}
+	configuration.Domain = testDomain
+	configuration.Name = testName
+	configuration.Expiration = testExpiration
+	configuration.Storage = &schema.SessionStorageConfiguration{GarbageCollectionInterval: "5m"}
+	providerConfig := NewProviderConfig(configuration, nil)
+
+	assert.Nil(t, providerConfig.redisConfig)
+	assert.Nil(t, providerConfig.redisSentinelConfig)
+	assert.Nil(t, providerConfig.redisClusterConfig)
+	assert.Equal(t, "storage", providerConfig.providerName)
+}
+
 func TestShouldSetCookieSameSite(t *testing.T) {
 	configuration := schema.SessionConfiguration{}
 	configuration.Domain = testDomain
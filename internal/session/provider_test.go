@@ -20,7 +20,7 @@ func TestShouldInitializerSession(t *testing.T) {
 	configuration.Name = testName
 	configuration.Expiration = testExpiration
 
-	provider := NewProvider(configuration, nil)
+	provider := NewProvider(configuration, nil, nil)
 	session, err := provider.GetSession(ctx)
 	require.NoError(t, err)
 
@@ -35,7 +35,7 @@ func TestShouldUpdateSession(t *testing.T) {
 	configuration.Name = testName
 	configuration.Expiration = testExpiration
 
-	provider := NewProvider(configuration, nil)
+	provider := NewProvider(configuration, nil, nil)
 	session, _ := provider.GetSession(ctx)
 
 	session.Username = testUsername
@@ -65,7 +65,7 @@ func TestShouldSetSessionAuthenticationLevels(t *testing.T) {
 	configuration.Name = testName
 	configuration.Expiration = testExpiration
 
-	provider := NewProvider(configuration, nil)
+	provider := NewProvider(configuration, nil, nil)
 	session, _ := provider.GetSession(ctx)
 
 	session.SetOneFactor(timeOneFactor, &authentication.UserDetails{Username: testUsername}, false)
@@ -131,7 +131,7 @@ func TestShouldDestroySessionAndWipeSessionData(t *testing.T) {
 	configuration.Name = testName
 	configuration.Expiration = testExpiration
 
-	provider := NewProvider(configuration, nil)
+	provider := NewProvider(configuration, nil, nil)
 	session, err := provider.GetSession(ctx)
 	require.NoError(t, err)
 
@@ -0,0 +1,135 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisClusterProvider is a session provider which stores the sessions in a Redis Cluster.
+type redisClusterProvider struct {
+	keyPrefix string
+	db        *redis.ClusterClient
+}
+
+// newRedisClusterProvider returns a new redisClusterProvider after checking the cluster can be reached and is healthy.
+func newRedisClusterProvider(options *redis.ClusterOptions, keyPrefix string) (provider *redisClusterProvider, err error) {
+	if len(options.Addrs) == 0 {
+		return nil, errors.New("redis cluster: at least one node address is required")
+	}
+
+	redis.SetLogger(newRedisLogger())
+
+	db := redis.NewClusterClient(options)
+
+	if err = checkRedisCluster(context.Background(), db); err != nil {
+		_ = db.Close()
+
+		return nil, err
+	}
+
+	return &redisClusterProvider{keyPrefix: keyPrefix, db: db}, nil
+}
+
+func checkRedisCluster(ctx context.Context, db *redis.ClusterClient) (err error) {
+	if err = db.Ping(ctx).Err(); err != nil {
+		return fmt.Errorf("redis cluster: error connecting to the seed nodes: %w", err)
+	}
+
+	info, err := db.ClusterInfo(ctx).Result()
+	if err != nil {
+		return fmt.Errorf("redis cluster: error retrieving the cluster state: %w", err)
+	}
+
+	if !strings.Contains(info, "cluster_state:ok") {
+		return errors.New("redis cluster: the cluster state is not ok")
+	}
+
+	// Every shard must be reachable otherwise the sessions stored in the hash slots it serves are unavailable.
+	err = db.ForEachShard(ctx, func(ctx context.Context, shard *redis.Client) error {
+		return shard.Ping(ctx).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("redis cluster: error connecting to a shard: %w", err)
+	}
+
+	return nil
+}
+
+func (p *redisClusterProvider) key(id []byte) string {
+	return p.keyPrefix + ":" + string(id)
+}
+
+// Get returns the data of the given session id.
+func (p *redisClusterProvider) Get(id []byte) ([]byte, error) {
+	reply, err := p.db.Get(context.Background(), p.key(id)).Bytes()
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// Save saves the session data and expiration from the given session id.
+func (p *redisClusterProvider) Save(id, data []byte, expiration time.Duration) error {
+	return p.db.Set(context.Background(), p.key(id), data, expiration).Err()
+}
+
+// Regenerate moves the data of the given session id to the new session id. RENAME can't be used as the two keys are
+// usually in different hash slots so the data is copied then deleted instead.
+func (p *redisClusterProvider) Regenerate(id, newID []byte, expiration time.Duration) error {
+	ctx := context.Background()
+
+	data, err := p.db.Get(ctx, p.key(id)).Bytes()
+
+	switch {
+	case err == redis.Nil:
+		return nil
+	case err != nil:
+		return err
+	}
+
+	if err = p.db.Set(ctx, p.key(newID), data, expiration).Err(); err != nil {
+		return err
+	}
+
+	return p.db.Del(ctx, p.key(id)).Err()
+}
+
+// Destroy destroys the session from the given id.
+func (p *redisClusterProvider) Destroy(id []byte) error {
+	return p.db.Del(context.Background(), p.key(id)).Err()
+}
+
+// Count returns the total of stored sessions.
+func (p *redisClusterProvider) Count() int {
+	var count int64
+
+	// The function is called concurrently for each master.
+	_ = p.db.ForEachMaster(context.Background(), func(ctx context.Context, master *redis.Client) error {
+		iter := master.Scan(ctx, 0, p.key([]byte("*")), 0).Iterator()
+
+		for iter.Next(ctx) {
+			atomic.AddInt64(&count, 1)
+		}
+
+		return iter.Err()
+	})
+
+	return int(count)
+}
+
+// NeedGC indicates if the GC needs to be run.
+func (p *redisClusterProvider) NeedGC() bool {
+	return false
+}
+
+// GC destroys the expired sessions.
+func (p *redisClusterProvider) GC() error {
+	return nil
+}
@@ -0,0 +1,103 @@
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// storageProvider is a session provider which stores the sessions in the storage provider. The storage provider
+// encrypts the data of the sessions with the storage encryption key.
+type storageProvider struct {
+	storage  storage.Provider
+	interval time.Duration
+}
+
+// newStorageProvider returns a new storageProvider which deletes the expired sessions at the given interval once its
+// GC is started.
+func newStorageProvider(provider storage.Provider, interval time.Duration) *storageProvider {
+	return &storageProvider{storage: provider, interval: interval}
+}
+
+// startGC deletes the expired sessions at each interval until the context is done. This is done here rather than by
+// the session holders as each cookie domain has its own holder which would all delete the same rows.
+func (p *storageProvider) startGC(ctx context.Context) {
+	logger := logging.Logger()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		count, err := p.storage.DeleteExpiredSessionData(ctx)
+		if err != nil {
+			logger.Errorf("Error occurred deleting expired sessions: %+v", err)
+
+			continue
+		}
+
+		if count != 0 {
+			logger.Debugf("Deleted %d expired sessions", count)
+		}
+	}
+}
+
+// Get returns the data of the given session id.
+func (p *storageProvider) Get(id []byte) ([]byte, error) {
+	return p.storage.LoadSessionData(context.Background(), string(id))
+}
+
+// Save saves the session data and expiration from the given session id.
+func (p *storageProvider) Save(id, data []byte, expiration time.Duration) error {
+	return p.storage.SaveSessionData(context.Background(), string(id), data, expiresAt(expiration))
+}
+
+// Regenerate updates the session id and expiration with the new session id of the given current session id.
+func (p *storageProvider) Regenerate(id, newID []byte, expiration time.Duration) error {
+	return p.storage.RenameSessionData(context.Background(), string(id), string(newID), expiresAt(expiration))
+}
+
+// Destroy destroys the session from the given id.
+func (p *storageProvider) Destroy(id []byte) error {
+	return p.storage.DeleteSessionData(context.Background(), string(id))
+}
+
+// Count returns the total of stored sessions.
+func (p *storageProvider) Count() int {
+	count, err := p.storage.CountSessionData(context.Background())
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// NeedGC indicates if the GC needs to be run. It's always false as the provider runs its own GC.
+func (p *storageProvider) NeedGC() bool {
+	return false
+}
+
+// GC destroys the expired sessions.
+func (p *storageProvider) GC() error {
+	_, err := p.storage.DeleteExpiredSessionData(context.Background())
+
+	return err
+}
+
+// expiresAt returns the time a session with the given expiration expires, or nil if it never expires.
+func expiresAt(expiration time.Duration) *time.Time {
+	if expiration <= 0 {
+		return nil
+	}
+
+	t := time.Now().Add(expiration)
+
+	return &t
+}
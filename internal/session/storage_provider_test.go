@@ -0,0 +1,151 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+func TestShouldStoreSessionsInStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	configuration := schema.SessionConfiguration{
+		Name:       "authelia_session",
+		Domain:     "example.com",
+		Expiration: "1h",
+		Storage:    &schema.SessionStorageConfiguration{GarbageCollectionInterval: "1h"},
+	}
+
+	provider := session.NewProvider(configuration, nil, storageMock)
+
+	var (
+		stored    []byte
+		storedID  string
+		expiresAt *time.Time
+	)
+
+	storageMock.EXPECT().
+		SaveSessionData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, sessionID string, data []byte, expires *time.Time) error {
+			storedID, stored, expiresAt = sessionID, data, expires
+
+			return nil
+		})
+
+	ctx := &fasthttp.RequestCtx{}
+
+	userSession, err := provider.GetSession(ctx)
+	require.NoError(t, err)
+
+	userSession.SetOneFactor(time.Unix(1625048140, 0), &authentication.UserDetails{Username: "john"}, false)
+	require.NoError(t, provider.SaveSession(ctx, userSession))
+
+	require.NotEmpty(t, storedID)
+	require.NotNil(t, expiresAt)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *expiresAt, time.Minute)
+
+	storageMock.EXPECT().
+		LoadSessionData(gomock.Any(), storedID).
+		Return(stored, nil)
+
+	other := &fasthttp.RequestCtx{}
+	other.Request.Header.SetCookie("authelia_session", storedID)
+
+	userSession, err = provider.GetSession(other)
+	require.NoError(t, err)
+	assert.Equal(t, "john", userSession.Username)
+
+	storageMock.EXPECT().
+		LoadSessionData(gomock.Any(), storedID).
+		Return(stored, nil)
+
+	storageMock.EXPECT().
+		RenameSessionData(gomock.Any(), storedID, gomock.Not(storedID), gomock.Any()).
+		Return(nil)
+
+	storageMock.EXPECT().
+		LoadSessionInventoryRecords(gomock.Any(), "john").
+		Return(nil, nil)
+
+	require.NoError(t, provider.RegenerateSession(other))
+}
+
+func TestShouldDestroySessionInStorage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	configuration := schema.SessionConfiguration{
+		Name:       "authelia_session",
+		Domain:     "example.com",
+		Expiration: "1h",
+		Storage:    &schema.SessionStorageConfiguration{GarbageCollectionInterval: "1h"},
+	}
+
+	provider := session.NewProvider(configuration, nil, storageMock)
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetCookie("authelia_session", "abc")
+
+	storageMock.EXPECT().
+		LoadSessionData(gomock.Any(), "abc").
+		Return(nil, nil)
+
+	storageMock.EXPECT().
+		DeleteSessionData(gomock.Any(), "abc").
+		Return(nil)
+
+	require.NoError(t, provider.DestroySession(ctx))
+}
+
+func TestShouldDeleteExpiredSessionsUntilGCIsStopped(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	configuration := schema.SessionConfiguration{
+		Name:       "authelia_session",
+		Domain:     "example.com",
+		Expiration: "1h",
+		Storage:    &schema.SessionStorageConfiguration{GarbageCollectionInterval: "1s"},
+	}
+
+	provider := session.NewProvider(configuration, nil, storageMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	storageMock.EXPECT().
+		DeleteExpiredSessionData(gomock.Any()).
+		DoAndReturn(func(_ context.Context) (int, error) {
+			cancel()
+
+			return 2, nil
+		})
+
+	go func() {
+		provider.StartGC(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("the session GC didn't stop once the context was done")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/fasthttp/session/v2"
 	"github.com/fasthttp/session/v2/providers/redis"
+	goredis "github.com/go-redis/redis/v8"
 	"github.com/sirupsen/logrus"
 	"github.com/tstranex/u2f"
 
@@ -19,6 +20,7 @@ type ProviderConfig struct {
 	config              session.Config
 	redisConfig         *redis.Config
 	redisSentinelConfig *redis.FailoverConfig
+	redisClusterConfig  *goredis.ClusterOptions
 	providerName        string
 }
 
@@ -36,6 +38,9 @@ type UserSession struct {
 	Groups []string
 	Emails []string
 
+	// The preferred locale of the user as provided by the authentication backend if any.
+	Locale string
+
 	KeepMeLoggedIn      bool
 	AuthenticationLevel authentication.Level
 	LastActivity        int64
@@ -43,6 +48,10 @@ type UserSession struct {
 	FirstFactorAuthnTimestamp  int64
 	SecondFactorAuthnTimestamp int64
 
+	// The session generation of the user when the first factor was completed. The session is no longer valid once the
+	// session generation of the user is incremented.
+	Generation int
+
 	// The challenge generated in first step of U2F registration (after identity verification) or authentication.
 	// This is used reused in the second phase to check that the challenge has been completed.
 	U2FChallenge *u2f.Challenge
@@ -62,8 +71,12 @@ type UserSession struct {
 
 // Identity identity of the user who is being verified.
 type Identity struct {
-	Username string
-	Email    string
+	Username    string
+	DisplayName string
+	Email       string
+
+	// Locale is the preferred locale of the user as provided by the authentication backend if any.
+	Locale string
 }
 
 // OIDCWorkflowSession represent an OIDC workflow session.
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"strings"
+)
+
+// deviceFromUserAgent returns a short human readable description of the browser and platform of a User-Agent header.
+func deviceFromUserAgent(userAgent string) string {
+	var browser, platform string
+
+	for _, b := range userAgentBrowsers {
+		if strings.Contains(userAgent, b.token) {
+			browser = b.name
+			break
+		}
+	}
+
+	for _, o := range userAgentPlatforms {
+		if strings.Contains(userAgent, o.token) {
+			platform = o.name
+			break
+		}
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	default:
+		return "Unknown"
+	}
+}
+
+type userAgentToken struct {
+	token string
+	name  string
+}
+
+// The order of these tokens is significant as most browsers include the tokens of the browsers they're derived from.
+var userAgentBrowsers = []userAgentToken{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+	{"curl/", "curl"},
+}
+
+var userAgentPlatforms = []userAgentToken{
+	{"Android", "Android"},
+	{"iPhone", "iOS"},
+	{"iPad", "iPadOS"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
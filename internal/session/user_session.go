@@ -29,6 +29,7 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.DisplayName = details.DisplayName
 	s.Groups = details.Groups
 	s.Emails = details.Emails
+	s.Locale = details.Locale
 }
 
 // SetTwoFactor sets the expected property values for two factor authentication.
@@ -13,14 +13,119 @@ const (
 	tableAuthenticationLogs   = "authentication_logs"
 	tableMigrations           = "migrations"
 	tableEncryption           = "encryption"
+	tableSessionGenerations   = "session_generations"
+	tableSessions             = "sessions"
+	tableRegulationBans       = "regulation_bans"
+	tableSessionInventory     = "session_inventory"
 
 	tablePrefixBackup = "_bkp_"
 )
 
 const (
 	encryptionNameCheck = "check"
+
+	encryptionKeyIDLength = 8
+	encryptionPageSize    = 100
+)
+
+// encryptedColumns are the columns of the latest schema version which hold values encrypted with the encryption keys.
+var encryptedColumns = []encryptedColumn{
+	encryptedColumnTOTPSecret,
+	encryptedColumnU2FPublicKey,
+	{tableSessions, "data"},
+	{tableEncryption, "value"},
+}
+
+var (
+	encryptedColumnTOTPSecret   = encryptedColumn{tableTOTPConfigurations, "secret"}
+	encryptedColumnU2FPublicKey = encryptedColumn{tableU2FDevices, "public_key"}
+)
+
+// encryptionMagic is the prefix of the encrypted values which are tagged with the ID of the key which encrypted them.
+var encryptionMagic = []byte{0x00, 'A', 'K', 0x01}
+
+const (
+	exportFormat        = "authelia-storage-export"
+	exportFormatVersion = 1
+
+	exportPageSize    = 100
+	exportMaxLineSize = 1024 * 1024
+)
+
+const (
+	exportColumnString exportColumnKind = iota
+	exportColumnInteger
+	exportColumnBoolean
+	exportColumnTime
+	exportColumnBytes
+	exportColumnEncrypted
 )
 
+// exportTables are the tables of the latest schema version which are exported in the order they're imported. The
+// sessions and their inventory are short lived and the migrations and encryption tables are specific to a database so
+// they're excluded. The row IDs are excluded so the auto increment sequences of the database the rows are imported into
+// stay valid.
+var exportTables = []exportTable{
+	{tableUserPreferences, []exportColumn{
+		{"username", exportColumnString},
+		{"second_factor_method", exportColumnString},
+	}},
+	{tableIdentityVerification, []exportColumn{
+		{"jti", exportColumnString},
+		{"iat", exportColumnTime},
+		{"issued_ip", exportColumnString},
+		{"exp", exportColumnTime},
+		{"username", exportColumnString},
+		{"action", exportColumnString},
+		{"consumed", exportColumnTime},
+		{"consumed_ip", exportColumnString},
+	}},
+	{tableTOTPConfigurations, []exportColumn{
+		{"username", exportColumnString},
+		{"issuer", exportColumnString},
+		{"algorithm", exportColumnString},
+		{"digits", exportColumnInteger},
+		{"period", exportColumnInteger},
+		{"secret", exportColumnEncrypted},
+	}},
+	{tableU2FDevices, []exportColumn{
+		{"username", exportColumnString},
+		{"description", exportColumnString},
+		{"key_handle", exportColumnBytes},
+		{"public_key", exportColumnEncrypted},
+	}},
+	{tableDuoDevices, []exportColumn{
+		{"username", exportColumnString},
+		{"device", exportColumnString},
+		{"method", exportColumnString},
+	}},
+	{tableAuthenticationLogs, []exportColumn{
+		{"time", exportColumnTime},
+		{"successful", exportColumnBoolean},
+		{"banned", exportColumnBoolean},
+		{"username", exportColumnString},
+		{"auth_type", exportColumnString},
+		{"remote_ip", exportColumnString},
+		{"remote_ip_bytes", exportColumnBytes},
+		{"request_uri", exportColumnString},
+		{"request_method", exportColumnString},
+		{"country", exportColumnString},
+		{"asn", exportColumnInteger},
+	}},
+	{tableSessionGenerations, []exportColumn{
+		{"username", exportColumnString},
+		{"generation", exportColumnInteger},
+	}},
+	{tableRegulationBans, []exportColumn{
+		{"time", exportColumnTime},
+		{"expires", exportColumnTime},
+		{"revoked", exportColumnBoolean},
+		{"revoked_at", exportColumnTime},
+		{"ban_type", exportColumnString},
+		{"ban_value", exportColumnString},
+	}},
+}
+
 // WARNING: Do not change/remove these consts. They are used for Pre1 migrations.
 const (
 	tablePre1TOTPSecrets                = "totp_secrets"
@@ -55,7 +160,7 @@ const (
 
 const (
 	// This is the latest schema version for the purpose of tests.
-	testLatestVersion = 1
+	testLatestVersion = 7
 )
 
 const (
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// newEncryptionKeys returns the encryptionKeys of the primary encryption key, which encrypts and decrypts values, and
+// of the decryption keys, which only decrypt values.
+func newEncryptionKeys(primary string, decryptionKeys []string) (keys encryptionKeys) {
+	keys.primary = newEncryptionKey(primary)
+
+	for _, key := range decryptionKeys {
+		keys.decryption = append(keys.decryption, newEncryptionKey(key))
+	}
+
+	return keys
+}
+
+// newEncryptionKey derives the AES-256 key from the configured encryption key, and the ID of the AES-256 key from its
+// checksum so the ID doesn't reveal anything about the key.
+func newEncryptionKey(value string) (key encryptionKey) {
+	key.key = sha256.Sum256([]byte(value))
+
+	sum := sha256.Sum256(key.key[:])
+
+	copy(key.id[:], sum[:encryptionKeyIDLength])
+
+	return key
+}
+
+// encryptionKeys are the keys used to encrypt and decrypt values in the database.
+type encryptionKeys struct {
+	primary    encryptionKey
+	decryption []encryptionKey
+}
+
+// decrypt decrypts a value with the key whose ID the value is tagged with. Values which were encrypted before the
+// values were tagged, or whose tag matches no key, are decrypted by trying each key. The value must be encrypted again
+// with the primary key when primary is false.
+func (k encryptionKeys) decrypt(cipherText []byte) (clearText []byte, primary bool, err error) {
+	if id, header, value, ok := encryptionTagged(cipherText); ok {
+		if key, found := k.find(id); found {
+			if clearText, err = utils.DecryptWithAdditionalData(value, &key.key, header); err == nil {
+				return clearText, key.id == k.primary.id, nil
+			}
+		}
+	}
+
+	if clearText, err = utils.Decrypt(cipherText, &k.primary.key); err == nil {
+		return clearText, false, nil
+	}
+
+	for _, key := range k.decryption {
+		if clearText, err = utils.Decrypt(cipherText, &key.key); err == nil {
+			return clearText, false, nil
+		}
+	}
+
+	return nil, false, err
+}
+
+// isPrimary returns true if the value is tagged with the ID of the primary key.
+func (k encryptionKeys) isPrimary(cipherText []byte) bool {
+	id, _, _, ok := encryptionTagged(cipherText)
+
+	return ok && id == k.primary.id
+}
+
+func (k encryptionKeys) find(id [encryptionKeyIDLength]byte) (key encryptionKey, found bool) {
+	if k.primary.id == id {
+		return k.primary, true
+	}
+
+	for _, key = range k.decryption {
+		if key.id == id {
+			return key, true
+		}
+	}
+
+	return key, false
+}
+
+// encryptionKey is an AES-256 key and the ID the values it encrypts are tagged with.
+type encryptionKey struct {
+	id  [encryptionKeyIDLength]byte
+	key [32]byte
+}
+
+// encrypt encrypts a value and tags it with the ID of the key. The tagged value takes the form
+// magic|id|nonce|ciphertext|tag where '|' indicates concatenation. The magic and the ID are authenticated as additional
+// data so the tag of a value can't be altered without the value failing to decrypt.
+func (k encryptionKey) encrypt(clearText []byte) (cipherText []byte, err error) {
+	header := make([]byte, 0, len(encryptionMagic)+encryptionKeyIDLength)
+	header = append(header, encryptionMagic...)
+	header = append(header, k.id[:]...)
+
+	value, err := utils.EncryptWithAdditionalData(clearText, &k.key, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(header, value...), nil
+}
+
+// encryptionTagged splits a tagged value into the ID of its key, its header which is the magic and the ID, and the
+// encrypted value. The values which were encrypted before the values were tagged start with a random nonce, so they
+// have a very small chance of looking tagged which is why decrypt falls back to trying each key.
+func encryptionTagged(cipherText []byte) (id [encryptionKeyIDLength]byte, header, value []byte, ok bool) {
+	n := len(encryptionMagic) + encryptionKeyIDLength
+
+	if len(cipherText) < n || !bytes.HasPrefix(cipherText, encryptionMagic) {
+		return id, nil, nil, false
+	}
+
+	copy(id[:], cipherText[len(encryptionMagic):])
+
+	return id, cipherText[:n], cipherText[n:], true
+}
+
+// encryptedColumn is a column of a table which holds values encrypted with the encryption keys.
+type encryptedColumn struct {
+	table  string
+	column string
+}
+
+// encryptedValue is the ID of a row and the encrypted value of one of its columns.
+type encryptedValue struct {
+	ID    int    `db:"id"`
+	Value []byte `db:"value"`
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestShouldEncryptAndDecryptWithEncryptionKeys(t *testing.T) {
+	keys := newEncryptionKeys("a-new-encryption-key-which-is-long-enough", []string{"an-old-encryption-key-which-is-long-enough"})
+	old := newEncryptionKey("an-old-encryption-key-which-is-long-enough")
+
+	assert.NotEqual(t, keys.primary.id, old.id)
+	assert.Equal(t, old.id, keys.decryption[0].id)
+
+	cipherText, err := keys.primary.encrypt([]byte("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, encryptionMagic, cipherText[:len(encryptionMagic)])
+	assert.Equal(t, keys.primary.id[:], cipherText[len(encryptionMagic):len(encryptionMagic)+encryptionKeyIDLength])
+	assert.True(t, keys.isPrimary(cipherText))
+
+	clearText, primary, err := keys.decrypt(cipherText)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abc"), clearText)
+	assert.True(t, primary)
+
+	// A value encrypted with a decryption key.
+	cipherText, err = old.encrypt([]byte("def"))
+	require.NoError(t, err)
+	assert.False(t, keys.isPrimary(cipherText))
+
+	clearText, primary, err = keys.decrypt(cipherText)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("def"), clearText)
+	assert.False(t, primary)
+
+	// A value encrypted before the values were tagged.
+	cipherText, err = utils.Encrypt([]byte("ghi"), &keys.primary.key)
+	require.NoError(t, err)
+	assert.False(t, keys.isPrimary(cipherText))
+
+	clearText, primary, err = keys.decrypt(cipherText)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ghi"), clearText)
+	assert.False(t, primary)
+
+	// A value encrypted with a key which isn't configured.
+	cipherText, err = newEncryptionKey("an-unknown-encryption-key-which-is-long-enough").encrypt([]byte("jkl"))
+	require.NoError(t, err)
+
+	_, _, err = keys.decrypt(cipherText)
+	assert.EqualError(t, err, "cipher: message authentication failed")
+}
+
+func TestShouldAuthenticateHeaderOfEncryptedValues(t *testing.T) {
+	keys := newEncryptionKeys("a-new-encryption-key-which-is-long-enough", nil)
+
+	cipherText, err := keys.primary.encrypt([]byte("abc"))
+	require.NoError(t, err)
+
+	header := cipherText[:len(encryptionMagic)+encryptionKeyIDLength]
+
+	// The header is authenticated so the value doesn't decrypt without it.
+	_, _, err = keys.decrypt(cipherText[len(header):])
+	assert.EqualError(t, err, "cipher: message authentication failed")
+
+	// A header added to a value which wasn't encrypted with it doesn't decrypt either.
+	value, err := utils.Encrypt([]byte("abc"), &keys.primary.key)
+	require.NoError(t, err)
+
+	_, _, err = keys.decrypt(append(append([]byte{}, header...), value...))
+	assert.EqualError(t, err, "cipher: message authentication failed")
+}
+
+func TestShouldReencryptWithPrimaryEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "db.sqlite3")
+
+	newProvider := func(encryptionKey string, decryptionKeys ...string) *SQLiteProvider {
+		provider := NewSQLiteProvider(&schema.Configuration{
+			Storage: schema.StorageConfiguration{
+				EncryptionKey:  encryptionKey,
+				DecryptionKeys: decryptionKeys,
+				Local:          &schema.LocalStorageConfiguration{Path: path},
+			},
+		})
+
+		t.Cleanup(func() {
+			_ = provider.Close()
+		})
+
+		return provider
+	}
+
+	oldKey, newKey := "an-old-encryption-key-which-is-long-enough", "a-new-encryption-key-which-is-long-enough"
+
+	provider := newProvider(oldKey)
+	require.NoError(t, provider.StartupCheck())
+
+	for _, username := range []string{"john", "harry", "bob"} {
+		require.NoError(t, provider.SaveTOTPConfiguration(ctx, models.TOTPConfiguration{
+			Username: username, Issuer: "Authelia", Algorithm: "SHA1", Digits: 6, Period: 30, Secret: []byte("secret-" + username),
+		}))
+	}
+
+	require.NoError(t, provider.SaveU2FDevice(ctx, models.U2FDevice{Username: "john", KeyHandle: []byte("handle"), PublicKey: []byte("public key")}))
+	require.NoError(t, provider.SaveSessionData(ctx, "session", []byte("data"), nil))
+
+	// The new key can't be used until the old key is configured as a decryption key.
+	assert.EqualError(t, newProvider(newKey).SchemaEncryptionCheckKey(ctx, false), "the encryption key is not valid against the schema check value")
+
+	provider = newProvider(newKey, oldKey)
+	require.NoError(t, provider.StartupCheck())
+
+	// The values are encrypted again with the new key when they're loaded.
+	config, err := provider.LoadTOTPConfiguration(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret-john"), config.Secret)
+
+	count, err := provider.SchemaEncryptionReencrypt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = provider.SchemaEncryptionReencrypt(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// The old key is no longer required.
+	provider = newProvider(newKey)
+	require.NoError(t, provider.SchemaEncryptionCheckKey(ctx, true))
+
+	config, err = provider.LoadTOTPConfiguration(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("secret-bob"), config.Secret)
+
+	device, err := provider.LoadU2FDevice(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("public key"), device.PublicKey)
+
+	data, err := provider.LoadSessionData(ctx, "session")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+}
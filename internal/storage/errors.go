@@ -36,6 +36,12 @@ var (
 	// ErrSchemaEncryptionInvalidKey is returned when the schema is checked if the encryption key is valid for
 	// the database but the key doesn't appear to be valid.
 	ErrSchemaEncryptionInvalidKey = errors.New("the encryption key is not valid against the schema check value")
+
+	// ErrImportEmpty is returned when the data being imported is empty.
+	ErrImportEmpty = errors.New("the import is empty")
+
+	// ErrImportInvalidFormat is returned when the data being imported isn't an export of the storage.
+	ErrImportInvalidFormat = errors.New("the import is not an Authelia storage export")
 )
 
 // Error formats for the storage provider.
@@ -54,6 +60,21 @@ const (
 		"version, you must downgrade to schema version %d before you can use this version of Authelia"
 )
 
+const (
+	errFmtImportFormatVersionUnsupported = "the import format version %d is not supported, the supported format version is %d"
+	errFmtImportSchemaVersionMismatch    = "the import is from schema version %d but the schema is version %d, both " +
+		"must be the same version"
+	errFmtImportTableNotEmpty = "the table '%s' must be empty to import data but it has %d rows"
+)
+
+const (
+	errFmtSchemaEncryptionReencryptInvalid = "%d values could not be decrypted with any of the encryption keys"
+)
+
+const (
+	errFmtPruneBatchSize = "the prune batch size must be greater than 0 but it is %d"
+)
+
 const (
 	logFmtMigrationFromTo   = "Storage schema migration from %s to %s is being attempted"
 	logFmtMigrationComplete = "Storage schema migration from %s to %s is complete"
@@ -0,0 +1,2 @@
+ALTER TABLE authentication_logs DROP COLUMN asn;
+ALTER TABLE authentication_logs DROP COLUMN country;
@@ -0,0 +1,2 @@
+ALTER TABLE authentication_logs ADD COLUMN country VARCHAR(2) NOT NULL DEFAULT '';
+ALTER TABLE authentication_logs ADD COLUMN asn INTEGER NOT NULL DEFAULT 0;
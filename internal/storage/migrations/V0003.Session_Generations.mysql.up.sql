@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS session_generations (
+    id INTEGER AUTO_INCREMENT,
+    username VARCHAR(100) NOT NULL,
+    generation INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (id),
+    UNIQUE KEY (username)
+);
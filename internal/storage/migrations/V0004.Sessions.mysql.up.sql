@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id INTEGER AUTO_INCREMENT,
+    session_id VARCHAR(255) NOT NULL,
+    data MEDIUMBLOB NOT NULL,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    UNIQUE KEY (session_id)
+);
+
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS sessions (
+    id SERIAL,
+    session_id VARCHAR(255) NOT NULL,
+    data BYTEA NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    PRIMARY KEY (id),
+    UNIQUE (session_id)
+);
+
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS regulation_bans (
+    id INTEGER AUTO_INCREMENT,
+    time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires TIMESTAMP NULL DEFAULT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_at TIMESTAMP NULL DEFAULT NULL,
+    ban_type VARCHAR(10) NOT NULL,
+    ban_value VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX regulation_bans_lookup_idx ON regulation_bans (ban_type, ban_value, time);
+CREATE INDEX regulation_bans_expires_idx ON regulation_bans (expires, revoked);
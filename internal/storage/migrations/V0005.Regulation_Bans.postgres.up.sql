@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS regulation_bans (
+    id SERIAL,
+    time TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    revoked BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    ban_type VARCHAR(10) NOT NULL,
+    ban_value VARCHAR(100) NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX regulation_bans_lookup_idx ON regulation_bans (ban_type, ban_value, time);
+CREATE INDEX regulation_bans_expires_idx ON regulation_bans (expires, revoked);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS session_inventory (
+    id INTEGER,
+    record_id CHAR(36) NOT NULL,
+    session_id VARCHAR(255) NOT NULL,
+    username VARCHAR(100) NOT NULL,
+    remote_ip VARCHAR(39) NOT NULL,
+    user_agent TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    UNIQUE (session_id)
+);
+
+CREATE INDEX session_inventory_username_idx ON session_inventory (username);
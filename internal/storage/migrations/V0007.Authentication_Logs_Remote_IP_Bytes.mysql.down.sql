@@ -0,0 +1,2 @@
+DROP INDEX authentication_logs_remote_ip_bytes_idx ON authentication_logs;
+ALTER TABLE authentication_logs DROP COLUMN remote_ip_bytes;
@@ -0,0 +1,3 @@
+ALTER TABLE authentication_logs ADD COLUMN remote_ip_bytes BYTEA NULL DEFAULT NULL;
+
+CREATE INDEX authentication_logs_remote_ip_bytes_idx ON authentication_logs (remote_ip_bytes, time);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS authentication_logs_remote_ip_bytes_idx;
+ALTER TABLE authentication_logs DROP COLUMN remote_ip_bytes;
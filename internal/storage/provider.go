@@ -2,6 +2,8 @@ package storage
 
 import (
 	"context"
+	"io"
+	"net"
 	"time"
 
 	"github.com/authelia/authelia/v4/internal/models"
@@ -28,12 +30,29 @@ type Provider interface {
 
 	SaveU2FDevice(ctx context.Context, device models.U2FDevice) (err error)
 	LoadU2FDevice(ctx context.Context, username string) (device *models.U2FDevice, err error)
+	DeleteU2FDevice(ctx context.Context, username string) (err error)
 	LoadU2FDevices(ctx context.Context, limit, page int) (devices []models.U2FDevice, err error)
 
 	SavePreferredDuoDevice(ctx context.Context, device models.DuoDevice) (err error)
 	DeletePreferredDuoDevice(ctx context.Context, username string) (err error)
 	LoadPreferredDuoDevice(ctx context.Context, username string) (device *models.DuoDevice, err error)
 
+	LoadAuthenticationLogsHistory(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+
+	LoadSessionGeneration(ctx context.Context, username string) (generation int, err error)
+	IncrementSessionGeneration(ctx context.Context, username string) (err error)
+
+	SaveSessionData(ctx context.Context, sessionID string, data []byte, expiresAt *time.Time) (err error)
+	LoadSessionData(ctx context.Context, sessionID string) (data []byte, err error)
+	RenameSessionData(ctx context.Context, sessionID, newSessionID string, expiresAt *time.Time) (err error)
+	DeleteSessionData(ctx context.Context, sessionID string) (err error)
+	DeleteExpiredSessionData(ctx context.Context) (count int64, err error)
+	CountSessionData(ctx context.Context) (count int, err error)
+
+	SaveSessionInventoryRecord(ctx context.Context, record models.SessionInventoryRecord) (err error)
+	LoadSessionInventoryRecords(ctx context.Context, username string) (records []models.SessionInventoryRecord, err error)
+	DeleteSessionInventoryRecord(ctx context.Context, username, sessionID string) (err error)
+
 	SchemaTables(ctx context.Context) (tables []string, err error)
 	SchemaVersion(ctx context.Context) (version int, err error)
 	SchemaLatestVersion() (version int, err error)
@@ -45,12 +64,32 @@ type Provider interface {
 
 	SchemaEncryptionChangeKey(ctx context.Context, encryptionKey string) (err error)
 	SchemaEncryptionCheckKey(ctx context.Context, verbose bool) (err error)
+	SchemaEncryptionReencrypt(ctx context.Context) (count int, err error)
+
+	ExportData(ctx context.Context, w io.Writer) (count int, err error)
+	ImportData(ctx context.Context, r io.Reader) (count int, err error)
+
+	PruneData(ctx context.Context, opts models.PruneOptions) (result models.PruneResult, err error)
 
 	Close() (err error)
 }
 
 // RegulatorProvider is an interface providing storage capabilities for persisting any kind of data related to the regulator.
+// Its methods only read from the primary database, never from the replicas which may lag behind it.
 type RegulatorProvider interface {
 	AppendAuthenticationLog(ctx context.Context, attempt models.AuthenticationAttempt) (err error)
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+	LoadAuthenticationLogsByType(ctx context.Context, username, authType string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogsByRemoteIP(ctx context.Context, remoteIP net.IP, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogsBySubnet(ctx context.Context, subnet *net.IPNet, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogs(ctx context.Context, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error)
+	CountSuccessfulAuthenticationLogsByRemoteIP(ctx context.Context, username, authType string, remoteIP net.IP, fromDate time.Time) (count int, err error)
+
+	LoadIdentityVerificationTimesByUsername(ctx context.Context, username string, fromDate time.Time, limit int) (times []time.Time, err error)
+	LoadIdentityVerificationTimesByIssuedIP(ctx context.Context, ip net.IP, fromDate time.Time, limit int) (times []time.Time, err error)
+
+	SaveRegulationBan(ctx context.Context, ban models.RegulationBan) (err error)
+	LoadRegulationBans(ctx context.Context, banType, value string, since time.Time) (bans []models.RegulationBan, err error)
+	LoadActiveRegulationBans(ctx context.Context, now time.Time, limit, page int) (bans []models.RegulationBan, err error)
+	RevokeRegulationBans(ctx context.Context, banType, value string, now time.Time) (count int64, err error)
 }
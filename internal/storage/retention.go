@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/models"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewRetentionJanitor returns a new RetentionJanitor which prunes the rows of the provider which are older than the
+// retention configuration allows.
+func NewRetentionJanitor(config *schema.StorageRetentionConfiguration, provider Provider) *RetentionJanitor {
+	janitor := &RetentionJanitor{
+		provider: provider,
+		clock:    utils.RealClock{},
+		log:      logging.Logger(),
+
+		authenticationLogs:   retentionDuration(config.AuthenticationLogs),
+		identityVerification: retentionDuration(config.IdentityVerification),
+		regulationBans:       retentionDuration(config.RegulationBans),
+
+		interval:  retentionDuration(config.Interval),
+		batchSize: config.BatchSize,
+	}
+
+	if janitor.interval <= 0 {
+		janitor.interval = retentionDuration(schema.DefaultStorageRetentionConfiguration.Interval)
+	}
+
+	if janitor.batchSize <= 0 {
+		janitor.batchSize = schema.DefaultStorageRetentionConfiguration.BatchSize
+	}
+
+	return janitor
+}
+
+// RetentionJanitor prunes the rows of the tables which grow with every request at an interval.
+type RetentionJanitor struct {
+	provider Provider
+	clock    utils.Clock
+	log      *logrus.Logger
+
+	authenticationLogs   time.Duration
+	identityVerification time.Duration
+	regulationBans       time.Duration
+
+	interval  time.Duration
+	batchSize int
+}
+
+// Options returns the prune options of the retention configuration relative to the current time.
+func (j *RetentionJanitor) Options(dryRun bool) (opts models.PruneOptions) {
+	now := j.clock.Now()
+
+	return models.PruneOptions{
+		AuthenticationLogs:   retentionBefore(now, j.authenticationLogs),
+		IdentityVerification: retentionBefore(now, j.identityVerification),
+		RegulationBans:       retentionBefore(now, j.regulationBans),
+
+		BatchSize: j.batchSize,
+		DryRun:    dryRun,
+	}
+}
+
+// Prune prunes the rows which are older than the retention configuration allows.
+func (j *RetentionJanitor) Prune(ctx context.Context) (result models.PruneResult, err error) {
+	return j.provider.PruneData(ctx, j.Options(false))
+}
+
+// Start prunes the rows immediately and then at each interval until the context is done. It's meant to be run in its
+// own goroutine.
+func (j *RetentionJanitor) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := j.Prune(ctx)
+
+		switch {
+		case err != nil:
+			j.log.Errorf("Error occurred pruning the storage: %+v", err)
+		case result.Total() != 0:
+			j.log.Debugf("Pruned %d authentication logs, %d identity verifications, and %d regulation bans from the storage",
+				result.AuthenticationLogs, result.IdentityVerification, result.RegulationBans)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// retentionDuration parses a retention duration which has already been validated, a retention which can't be parsed
+// keeps every row.
+func retentionDuration(value string) (duration time.Duration) {
+	if value == "" {
+		return 0
+	}
+
+	duration, _ = utils.ParseDurationString(value)
+
+	return duration
+}
+
+func retentionBefore(now time.Time, retention time.Duration) time.Time {
+	if retention <= 0 {
+		return time.Time{}
+	}
+
+	return now.Add(-retention)
+}
@@ -2,10 +2,10 @@ package storage
 
 import (
 	"context"
-	"crypto/sha256"
 	"database/sql"
 	"errors"
 	"fmt"
+	"net"
 	"time"
 
 	"github.com/jmoiron/sqlx"
@@ -23,20 +23,35 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 
 	provider = SQLProvider{
 		db:         db,
-		key:        sha256.Sum256([]byte(config.Storage.EncryptionKey)),
+		keys:       newEncryptionKeys(config.Storage.EncryptionKey, config.Storage.DecryptionKeys),
 		name:       name,
 		driverName: driverName,
 		config:     config,
 		errOpen:    err,
 		log:        logging.Logger(),
 
-		sqlInsertAuthenticationAttempt:            fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
-		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
+		sqlInsertAuthenticationAttempt:                   fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
+		sqlSelectAuthenticationAttemptsByUsername:        fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
+		sqlSelectAuthenticationAttemptsByUsernameAndType: fmt.Sprintf(queryFmtSelectAuthenticationLogEntryByUsernameAndType, tableAuthenticationLogs),
+		sqlSelectAuthenticationAttemptsHistory:           fmt.Sprintf(queryFmtSelectAuthenticationLogEntriesByUsername, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptsByRemoteIP:  fmt.Sprintf(queryFmtSelect1FAFailedAuthenticationLogEntryByRemoteIP, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptsBySubnet:    fmt.Sprintf(queryFmtSelect1FAFailedAuthenticationLogEntryBySubnet, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttempts:            fmt.Sprintf(queryFmtSelect1FAFailedAuthenticationLogEntry, tableAuthenticationLogs),
+
+		sqlSelectSuccessfulAuthenticationAttemptCountByRemoteIP: fmt.Sprintf(queryFmtSelectSuccessfulAuthenticationLogEntryCountByRemoteIP, tableAuthenticationLogs),
+
+		sqlInsertRegulationBan:        fmt.Sprintf(queryFmtInsertRegulationBan, tableRegulationBans),
+		sqlSelectRegulationBans:       fmt.Sprintf(queryFmtSelectRegulationBans, tableRegulationBans),
+		sqlSelectActiveRegulationBans: fmt.Sprintf(queryFmtSelectActiveRegulationBans, tableRegulationBans),
+		sqlRevokeRegulationBans:       fmt.Sprintf(queryFmtRevokeRegulationBans, tableRegulationBans),
 
 		sqlInsertIdentityVerification:  fmt.Sprintf(queryFmtInsertIdentityVerification, tableIdentityVerification),
 		sqlConsumeIdentityVerification: fmt.Sprintf(queryFmtConsumeIdentityVerification, tableIdentityVerification),
 		sqlSelectIdentityVerification:  fmt.Sprintf(queryFmtSelectIdentityVerification, tableIdentityVerification),
 
+		sqlSelectIdentityVerificationTimesByUsername: fmt.Sprintf(queryFmtSelectIdentityVerificationTimesByUsername, tableIdentityVerification),
+		sqlSelectIdentityVerificationTimesByIssuedIP: fmt.Sprintf(queryFmtSelectIdentityVerificationTimesByIssuedIP, tableIdentityVerification),
+
 		sqlUpsertTOTPConfig:  fmt.Sprintf(queryFmtUpsertTOTPConfiguration, tableTOTPConfigurations),
 		sqlDeleteTOTPConfig:  fmt.Sprintf(queryFmtDeleteTOTPConfiguration, tableTOTPConfigurations),
 		sqlSelectTOTPConfig:  fmt.Sprintf(queryFmtSelectTOTPConfiguration, tableTOTPConfigurations),
@@ -48,6 +63,7 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpsertU2FDevice:  fmt.Sprintf(queryFmtUpsertU2FDevice, tableU2FDevices),
 		sqlSelectU2FDevice:  fmt.Sprintf(queryFmtSelectU2FDevice, tableU2FDevices),
 		sqlSelectU2FDevices: fmt.Sprintf(queryFmtSelectU2FDevices, tableU2FDevices),
+		sqlDeleteU2FDevice:  fmt.Sprintf(queryFmtDeleteU2FDevice, tableU2FDevices),
 
 		sqlUpdateU2FDevicePublicKey:           fmt.Sprintf(queryFmtUpdateU2FDevicePublicKey, tableU2FDevices),
 		sqlUpdateU2FDevicePublicKeyByUsername: fmt.Sprintf(queryFmtUpdateUpdateU2FDevicePublicKeyByUsername, tableU2FDevices),
@@ -56,6 +72,22 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlDeleteDuoDevice: fmt.Sprintf(queryFmtDeleteDuoDevice, tableDuoDevices),
 		sqlSelectDuoDevice: fmt.Sprintf(queryFmtSelectDuoDevice, tableDuoDevices),
 
+		sqlSelectSessionGeneration:    fmt.Sprintf(queryFmtSelectSessionGeneration, tableSessionGenerations),
+		sqlIncrementSessionGeneration: fmt.Sprintf(queryFmtIncrementSessionGeneration, tableSessionGenerations),
+
+		sqlUpsertSessionData:        fmt.Sprintf(queryFmtUpsertSessionData, tableSessions),
+		sqlSelectSessionData:        fmt.Sprintf(queryFmtSelectSessionData, tableSessions),
+		sqlUpdateSessionDataID:      fmt.Sprintf(queryFmtUpdateSessionDataID, tableSessions),
+		sqlDeleteSessionData:        fmt.Sprintf(queryFmtDeleteSessionData, tableSessions),
+		sqlDeleteExpiredSessionData: fmt.Sprintf(queryFmtDeleteExpiredSessionData, tableSessions),
+		sqlDeleteAllSessionData:     fmt.Sprintf(queryFmtDeleteAllSessionData, tableSessions),
+		sqlSelectSessionDataCount:   fmt.Sprintf(queryFmtSelectSessionDataCount, tableSessions),
+
+		sqlUpsertSessionInventoryRecord:          fmt.Sprintf(queryFmtUpsertSessionInventoryRecord, tableSessionInventory),
+		sqlSelectSessionInventoryRecords:         fmt.Sprintf(queryFmtSelectSessionInventoryRecords, tableSessionInventory),
+		sqlDeleteSessionInventoryRecord:          fmt.Sprintf(queryFmtDeleteSessionInventoryRecord, tableSessionInventory),
+		sqlDeleteOrphanedSessionInventoryRecords: fmt.Sprintf(queryFmtDeleteOrphanedSessionInventoryRecords, tableSessionInventory, tableSessions),
+
 		sqlUpsertPreferred2FAMethod: fmt.Sprintf(queryFmtUpsertPreferred2FAMethod, tableUserPreferences),
 		sqlSelectPreferred2FAMethod: fmt.Sprintf(queryFmtSelectPreferred2FAMethod, tableUserPreferences),
 		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableU2FDevices, tableDuoDevices, tableUserPreferences),
@@ -76,24 +108,44 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 // SQLProvider is a storage provider persisting data in a SQL database.
 type SQLProvider struct {
 	db         *sqlx.DB
-	key        [32]byte
+	keys       encryptionKeys
 	name       string
 	driverName string
 	schema     string
 	config     *schema.Configuration
 	errOpen    error
 
+	replicas     []*sqlx.DB
+	replicaNext  uint32
+	queryTimeout time.Duration
+
 	log *logrus.Logger
 
 	// Table: authentication_logs.
-	sqlInsertAuthenticationAttempt            string
-	sqlSelectAuthenticationAttemptsByUsername string
+	sqlInsertAuthenticationAttempt                   string
+	sqlSelectAuthenticationAttemptsByUsername        string
+	sqlSelectAuthenticationAttemptsHistory           string
+	sqlSelectAuthenticationAttemptsByUsernameAndType string
+	sqlSelectFailedAuthenticationAttemptsByRemoteIP  string
+	sqlSelectFailedAuthenticationAttemptsBySubnet    string
+	sqlSelectFailedAuthenticationAttempts            string
+
+	sqlSelectSuccessfulAuthenticationAttemptCountByRemoteIP string
+
+	// Table: regulation_bans.
+	sqlInsertRegulationBan        string
+	sqlSelectRegulationBans       string
+	sqlSelectActiveRegulationBans string
+	sqlRevokeRegulationBans       string
 
 	// Table: identity_verification.
 	sqlInsertIdentityVerification  string
 	sqlConsumeIdentityVerification string
 	sqlSelectIdentityVerification  string
 
+	sqlSelectIdentityVerificationTimesByUsername string
+	sqlSelectIdentityVerificationTimesByIssuedIP string
+
 	// Table: totp_configurations.
 	sqlUpsertTOTPConfig  string
 	sqlDeleteTOTPConfig  string
@@ -107,6 +159,7 @@ type SQLProvider struct {
 	sqlUpsertU2FDevice  string
 	sqlSelectU2FDevice  string
 	sqlSelectU2FDevices string
+	sqlDeleteU2FDevice  string
 
 	sqlUpdateU2FDevicePublicKey           string
 	sqlUpdateU2FDevicePublicKeyByUsername string
@@ -116,6 +169,25 @@ type SQLProvider struct {
 	sqlDeleteDuoDevice string
 	sqlSelectDuoDevice string
 
+	// Table: session_generations.
+	sqlSelectSessionGeneration    string
+	sqlIncrementSessionGeneration string
+
+	// Table: sessions.
+	sqlUpsertSessionData        string
+	sqlSelectSessionData        string
+	sqlUpdateSessionDataID      string
+	sqlDeleteSessionData        string
+	sqlDeleteExpiredSessionData string
+	sqlDeleteAllSessionData     string
+	sqlSelectSessionDataCount   string
+
+	// Table: session_inventory.
+	sqlUpsertSessionInventoryRecord          string
+	sqlSelectSessionInventoryRecords         string
+	sqlDeleteSessionInventoryRecord          string
+	sqlDeleteOrphanedSessionInventoryRecords string
+
 	// Table: user_preferences.
 	sqlUpsertPreferred2FAMethod string
 	sqlSelectPreferred2FAMethod string
@@ -137,6 +209,12 @@ type SQLProvider struct {
 
 // Close the underlying database connection.
 func (p *SQLProvider) Close() (err error) {
+	if err = p.closeReplicas(); err != nil {
+		_ = p.db.Close()
+
+		return err
+	}
+
 	return p.db.Close()
 }
 
@@ -159,10 +237,14 @@ func (p *SQLProvider) StartupCheck() (err error) {
 		return fmt.Errorf("error pinging database: %w", err)
 	}
 
-	p.log.Infof("Storage schema is being checked for updates")
-
 	ctx := context.Background()
 
+	if err = p.pingReplicas(ctx); err != nil {
+		return err
+	}
+
+	p.log.Infof("Storage schema is being checked for updates")
+
 	if err = p.SchemaEncryptionCheckKey(ctx, false); err != nil && !errors.Is(err, ErrSchemaEncryptionVersionUnsupported) {
 		return err
 	}
@@ -182,6 +264,9 @@ func (p *SQLProvider) StartupCheck() (err error) {
 
 // SavePreferred2FAMethod save the preferred method for 2FA to the database.
 func (p *SQLProvider) SavePreferred2FAMethod(ctx context.Context, username string, method string) (err error) {
+	ctx, done := p.operation(ctx, "SavePreferred2FAMethod")
+	defer done(&err)
+
 	_, err = p.db.ExecContext(ctx, p.sqlUpsertPreferred2FAMethod, username, method)
 
 	return err
@@ -189,6 +274,9 @@ func (p *SQLProvider) SavePreferred2FAMethod(ctx context.Context, username strin
 
 // LoadPreferred2FAMethod load the preferred method for 2FA from the database.
 func (p *SQLProvider) LoadPreferred2FAMethod(ctx context.Context, username string) (method string, err error) {
+	ctx, done := p.operation(ctx, "LoadPreferred2FAMethod")
+	defer done(&err)
+
 	err = p.db.GetContext(ctx, &method, p.sqlSelectPreferred2FAMethod, username)
 
 	switch {
@@ -203,7 +291,12 @@ func (p *SQLProvider) LoadPreferred2FAMethod(ctx context.Context, username strin
 
 // LoadUserInfo loads the models.UserInfo from the database.
 func (p *SQLProvider) LoadUserInfo(ctx context.Context, username string) (info models.UserInfo, err error) {
-	err = p.db.GetContext(ctx, &info, p.sqlSelectUserInfo, username, username, username, username)
+	ctx, done := p.operation(ctx, "LoadUserInfo")
+	defer done(&err)
+
+	err = p.read(ctx, func(db *sqlx.DB) error {
+		return db.GetContext(ctx, &info, p.sqlSelectUserInfo, username, username, username, username)
+	})
 
 	switch {
 	case err == nil:
@@ -225,6 +318,9 @@ func (p *SQLProvider) LoadUserInfo(ctx context.Context, username string) (info m
 
 // SaveIdentityVerification save an identity verification record to the database.
 func (p *SQLProvider) SaveIdentityVerification(ctx context.Context, verification models.IdentityVerification) (err error) {
+	ctx, done := p.operation(ctx, "SaveIdentityVerification")
+	defer done(&err)
+
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertIdentityVerification,
 		verification.JTI, verification.IssuedAt, verification.IssuedIP, verification.ExpiresAt,
 		verification.Username, verification.Action); err != nil {
@@ -236,6 +332,9 @@ func (p *SQLProvider) SaveIdentityVerification(ctx context.Context, verification
 
 // ConsumeIdentityVerification marks an identity verification record in the database as consumed.
 func (p *SQLProvider) ConsumeIdentityVerification(ctx context.Context, jti string, ip models.NullIP) (err error) {
+	ctx, done := p.operation(ctx, "ConsumeIdentityVerification")
+	defer done(&err)
+
 	if _, err = p.db.ExecContext(ctx, p.sqlConsumeIdentityVerification, ip, jti); err != nil {
 		return fmt.Errorf("error updating identity verification: %w", err)
 	}
@@ -245,6 +344,9 @@ func (p *SQLProvider) ConsumeIdentityVerification(ctx context.Context, jti strin
 
 // FindIdentityVerification checks if an identity verification record is in the database and active.
 func (p *SQLProvider) FindIdentityVerification(ctx context.Context, jti string) (found bool, err error) {
+	ctx, done := p.operation(ctx, "FindIdentityVerification")
+	defer done(&err)
+
 	verification := models.IdentityVerification{}
 	if err = p.db.GetContext(ctx, &verification, p.sqlSelectIdentityVerification, jti); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -262,8 +364,37 @@ func (p *SQLProvider) FindIdentityVerification(ctx context.Context, jti string)
 	}
 }
 
+// LoadIdentityVerificationTimesByUsername loads the times of the latest identity verifications issued to a user after
+// the given time, the most recent first.
+func (p *SQLProvider) LoadIdentityVerificationTimesByUsername(ctx context.Context, username string, fromDate time.Time, limit int) (times []time.Time, err error) {
+	ctx, done := p.operation(ctx, "LoadIdentityVerificationTimesByUsername")
+	defer done(&err)
+
+	if err = p.db.SelectContext(ctx, &times, p.sqlSelectIdentityVerificationTimesByUsername, fromDate, username, limit); err != nil {
+		return nil, fmt.Errorf("error selecting identity verification times for user '%s': %w", username, err)
+	}
+
+	return times, nil
+}
+
+// LoadIdentityVerificationTimesByIssuedIP loads the times of the latest identity verifications issued to a remote IP
+// after the given time, the most recent first.
+func (p *SQLProvider) LoadIdentityVerificationTimesByIssuedIP(ctx context.Context, ip net.IP, fromDate time.Time, limit int) (times []time.Time, err error) {
+	ctx, done := p.operation(ctx, "LoadIdentityVerificationTimesByIssuedIP")
+	defer done(&err)
+
+	if err = p.db.SelectContext(ctx, &times, p.sqlSelectIdentityVerificationTimesByIssuedIP, fromDate, models.NewIP(ip), limit); err != nil {
+		return nil, fmt.Errorf("error selecting identity verification times for remote ip '%s': %w", ip, err)
+	}
+
+	return times, nil
+}
+
 // SaveTOTPConfiguration save a TOTP configuration of a given user in the database.
 func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config models.TOTPConfiguration) (err error) {
+	ctx, done := p.operation(ctx, "SaveTOTPConfiguration")
+	defer done(&err)
+
 	if config.Secret, err = p.encrypt(config.Secret); err != nil {
 		return fmt.Errorf("error encrypting the TOTP configuration secret: %v", err)
 	}
@@ -278,6 +409,9 @@ func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config models.T
 
 // DeleteTOTPConfiguration delete a TOTP configuration from the database given a username.
 func (p *SQLProvider) DeleteTOTPConfiguration(ctx context.Context, username string) (err error) {
+	ctx, done := p.operation(ctx, "DeleteTOTPConfiguration")
+	defer done(&err)
+
 	if _, err = p.db.ExecContext(ctx, p.sqlDeleteTOTPConfig, username); err != nil {
 		return fmt.Errorf("error deleting TOTP configuration: %w", err)
 	}
@@ -287,9 +421,14 @@ func (p *SQLProvider) DeleteTOTPConfiguration(ctx context.Context, username stri
 
 // LoadTOTPConfiguration load a TOTP configuration given a username from the database.
 func (p *SQLProvider) LoadTOTPConfiguration(ctx context.Context, username string) (config *models.TOTPConfiguration, err error) {
+	ctx, done := p.operation(ctx, "LoadTOTPConfiguration")
+	defer done(&err)
+
 	config = &models.TOTPConfiguration{}
 
-	if err = p.db.QueryRowxContext(ctx, p.sqlSelectTOTPConfig, username).StructScan(config); err != nil {
+	if err = p.read(ctx, func(db *sqlx.DB) error {
+		return db.QueryRowxContext(ctx, p.sqlSelectTOTPConfig, username).StructScan(config)
+	}); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNoTOTPConfiguration
 		}
@@ -297,7 +436,7 @@ func (p *SQLProvider) LoadTOTPConfiguration(ctx context.Context, username string
 		return nil, fmt.Errorf("error selecting TOTP configuration: %w", err)
 	}
 
-	if config.Secret, err = p.decrypt(config.Secret); err != nil {
+	if config.Secret, err = p.decryptReencrypt(ctx, encryptedColumnTOTPSecret, config.ID, config.Secret); err != nil {
 		return nil, fmt.Errorf("error decrypting the TOTP secret: %v", err)
 	}
 
@@ -306,6 +445,9 @@ func (p *SQLProvider) LoadTOTPConfiguration(ctx context.Context, username string
 
 // LoadTOTPConfigurations load a set of TOTP configurations.
 func (p *SQLProvider) LoadTOTPConfigurations(ctx context.Context, limit, page int) (configs []models.TOTPConfiguration, err error) {
+	ctx, done := p.operation(ctx, "LoadTOTPConfigurations")
+	defer done(&err)
+
 	rows, err := p.db.QueryxContext(ctx, p.sqlSelectTOTPConfigs, limit, limit*page)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -357,6 +499,9 @@ func (p *SQLProvider) updateTOTPConfigurationSecret(ctx context.Context, config
 
 // SaveU2FDevice saves a registered U2F device.
 func (p *SQLProvider) SaveU2FDevice(ctx context.Context, device models.U2FDevice) (err error) {
+	ctx, done := p.operation(ctx, "SaveU2FDevice")
+	defer done(&err)
+
 	if device.PublicKey, err = p.encrypt(device.PublicKey); err != nil {
 		return fmt.Errorf("error encrypting the U2F device public key: %v", err)
 	}
@@ -370,6 +515,9 @@ func (p *SQLProvider) SaveU2FDevice(ctx context.Context, device models.U2FDevice
 
 // LoadU2FDevice loads a U2F device registration for a given username.
 func (p *SQLProvider) LoadU2FDevice(ctx context.Context, username string) (device *models.U2FDevice, err error) {
+	ctx, done := p.operation(ctx, "LoadU2FDevice")
+	defer done(&err)
+
 	device = &models.U2FDevice{}
 
 	if err = p.db.GetContext(ctx, device, p.sqlSelectU2FDevice, username); err != nil {
@@ -380,15 +528,30 @@ func (p *SQLProvider) LoadU2FDevice(ctx context.Context, username string) (devic
 		return nil, fmt.Errorf("error selecting U2F device: %w", err)
 	}
 
-	if device.PublicKey, err = p.decrypt(device.PublicKey); err != nil {
+	if device.PublicKey, err = p.decryptReencrypt(ctx, encryptedColumnU2FPublicKey, device.ID, device.PublicKey); err != nil {
 		return nil, fmt.Errorf("error decrypting the U2F device public key: %v", err)
 	}
 
 	return device, nil
 }
 
+// DeleteU2FDevice deletes the U2F device registration of a given username.
+func (p *SQLProvider) DeleteU2FDevice(ctx context.Context, username string) (err error) {
+	ctx, done := p.operation(ctx, "DeleteU2FDevice")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteU2FDevice, username); err != nil {
+		return fmt.Errorf("error deleting U2F device: %w", err)
+	}
+
+	return nil
+}
+
 // LoadU2FDevices loads U2F device registrations.
 func (p *SQLProvider) LoadU2FDevices(ctx context.Context, limit, page int) (devices []models.U2FDevice, err error) {
+	ctx, done := p.operation(ctx, "LoadU2FDevices")
+	defer done(&err)
+
 	rows, err := p.db.QueryxContext(ctx, p.sqlSelectU2FDevices, limit, limit*page)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -440,18 +603,27 @@ func (p *SQLProvider) updateU2FDevicePublicKey(ctx context.Context, device model
 
 // SavePreferredDuoDevice saves a Duo device.
 func (p *SQLProvider) SavePreferredDuoDevice(ctx context.Context, device models.DuoDevice) (err error) {
+	ctx, done := p.operation(ctx, "SavePreferredDuoDevice")
+	defer done(&err)
+
 	_, err = p.db.ExecContext(ctx, p.sqlUpsertDuoDevice, device.Username, device.Device, device.Method)
 	return err
 }
 
 // DeletePreferredDuoDevice deletes a Duo device of a given user.
 func (p *SQLProvider) DeletePreferredDuoDevice(ctx context.Context, username string) (err error) {
+	ctx, done := p.operation(ctx, "DeletePreferredDuoDevice")
+	defer done(&err)
+
 	_, err = p.db.ExecContext(ctx, p.sqlDeleteDuoDevice, username)
 	return err
 }
 
 // LoadPreferredDuoDevice loads a Duo device of a given user.
 func (p *SQLProvider) LoadPreferredDuoDevice(ctx context.Context, username string) (device *models.DuoDevice, err error) {
+	ctx, done := p.operation(ctx, "LoadPreferredDuoDevice")
+	defer done(&err)
+
 	device = &models.DuoDevice{}
 
 	if err := p.db.QueryRowxContext(ctx, p.sqlSelectDuoDevice, username).StructScan(device); err != nil {
@@ -465,20 +637,261 @@ func (p *SQLProvider) LoadPreferredDuoDevice(ctx context.Context, username strin
 	return device, nil
 }
 
+// LoadSessionGeneration loads the session generation of a user. Sessions of the user which were created with an older
+// generation are no longer valid.
+func (p *SQLProvider) LoadSessionGeneration(ctx context.Context, username string) (generation int, err error) {
+	ctx, done := p.operation(ctx, "LoadSessionGeneration")
+	defer done(&err)
+
+	err = p.db.GetContext(ctx, &generation, p.sqlSelectSessionGeneration, username)
+
+	switch {
+	case err == nil:
+		return generation, nil
+	case errors.Is(err, sql.ErrNoRows):
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("error selecting session generation for user '%s': %w", username, err)
+	}
+}
+
+// IncrementSessionGeneration increments the session generation of a user which invalidates all of their sessions.
+func (p *SQLProvider) IncrementSessionGeneration(ctx context.Context, username string) (err error) {
+	ctx, done := p.operation(ctx, "IncrementSessionGeneration")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlIncrementSessionGeneration, username); err != nil {
+		return fmt.Errorf("error incrementing session generation for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// SaveSessionData saves the data of a session encrypted with the encryption key. The session never expires when
+// expiresAt is nil.
+func (p *SQLProvider) SaveSessionData(ctx context.Context, sessionID string, data []byte, expiresAt *time.Time) (err error) {
+	ctx, done := p.operation(ctx, "SaveSessionData")
+	defer done(&err)
+
+	if data, err = p.encrypt(data); err != nil {
+		return fmt.Errorf("error encrypting the session data: %w", err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertSessionData, sessionID, data, expiresAt); err != nil {
+		return fmt.Errorf("error upserting session data: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSessionData loads the decrypted data of a session which hasn't expired. The data is nil when there is no such
+// session.
+func (p *SQLProvider) LoadSessionData(ctx context.Context, sessionID string) (data []byte, err error) {
+	ctx, done := p.operation(ctx, "LoadSessionData")
+	defer done(&err)
+
+	if err = p.db.GetContext(ctx, &data, p.sqlSelectSessionData, sessionID, time.Now()); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting session data: %w", err)
+	}
+
+	if data, err = p.decrypt(data); err != nil {
+		return nil, fmt.Errorf("error decrypting the session data: %w", err)
+	}
+
+	return data, nil
+}
+
+// RenameSessionData changes the ID and the expiration of a session.
+func (p *SQLProvider) RenameSessionData(ctx context.Context, sessionID, newSessionID string, expiresAt *time.Time) (err error) {
+	ctx, done := p.operation(ctx, "RenameSessionData")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateSessionDataID, newSessionID, expiresAt, sessionID); err != nil {
+		return fmt.Errorf("error updating session id: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSessionData deletes a session.
+func (p *SQLProvider) DeleteSessionData(ctx context.Context, sessionID string) (err error) {
+	ctx, done := p.operation(ctx, "DeleteSessionData")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteSessionData, sessionID); err != nil {
+		return fmt.Errorf("error deleting session data: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpiredSessionData deletes the sessions which have expired and returns the number of sessions deleted.
+func (p *SQLProvider) DeleteExpiredSessionData(ctx context.Context) (count int64, err error) {
+	ctx, done := p.operation(ctx, "DeleteExpiredSessionData")
+	defer done(&err)
+
+	result, err := p.db.ExecContext(ctx, p.sqlDeleteExpiredSessionData, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("error deleting expired session data: %w", err)
+	}
+
+	if count, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error deleting expired session data: %w", err)
+	}
+
+	// The inventory records of the sessions which expired or were deleted without being removed from the inventory.
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteOrphanedSessionInventoryRecords); err != nil {
+		return count, fmt.Errorf("error deleting orphaned session inventory records: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountSessionData returns the number of sessions which haven't expired.
+func (p *SQLProvider) CountSessionData(ctx context.Context) (count int, err error) {
+	ctx, done := p.operation(ctx, "CountSessionData")
+	defer done(&err)
+
+	if err = p.db.GetContext(ctx, &count, p.sqlSelectSessionDataCount, time.Now()); err != nil {
+		return 0, fmt.Errorf("error counting session data: %w", err)
+	}
+
+	return count, nil
+}
+
+// SaveSessionInventoryRecord saves a record of the session inventory of a user, replacing the record of the same
+// session.
+func (p *SQLProvider) SaveSessionInventoryRecord(ctx context.Context, record models.SessionInventoryRecord) (err error) {
+	ctx, done := p.operation(ctx, "SaveSessionInventoryRecord")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertSessionInventoryRecord,
+		record.RecordID, record.SessionID, record.Username, record.RemoteIP, record.UserAgent, record.CreatedAt); err != nil {
+		return fmt.Errorf("error upserting session inventory record for user '%s': %w", record.Username, err)
+	}
+
+	return nil
+}
+
+// LoadSessionInventoryRecords loads the records of the session inventory of a user from the oldest to the most recent.
+func (p *SQLProvider) LoadSessionInventoryRecords(ctx context.Context, username string) (records []models.SessionInventoryRecord, err error) {
+	ctx, done := p.operation(ctx, "LoadSessionInventoryRecords")
+	defer done(&err)
+
+	records = make([]models.SessionInventoryRecord, 0)
+
+	if err = p.db.SelectContext(ctx, &records, p.sqlSelectSessionInventoryRecords, username); err != nil {
+		return nil, fmt.Errorf("error selecting session inventory records for user '%s': %w", username, err)
+	}
+
+	return records, nil
+}
+
+// DeleteSessionInventoryRecord deletes the record of a session from the session inventory of a user.
+func (p *SQLProvider) DeleteSessionInventoryRecord(ctx context.Context, username, sessionID string) (err error) {
+	ctx, done := p.operation(ctx, "DeleteSessionInventoryRecord")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteSessionInventoryRecord, username, sessionID); err != nil {
+		return fmt.Errorf("error deleting session inventory record for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
 // AppendAuthenticationLog append a mark to the authentication log.
 func (p *SQLProvider) AppendAuthenticationLog(ctx context.Context, attempt models.AuthenticationAttempt) (err error) {
+	ctx, done := p.operation(ctx, "AppendAuthenticationLog")
+	defer done(&err)
+
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertAuthenticationAttempt,
 		attempt.Time, attempt.Successful, attempt.Banned, attempt.Username,
-		attempt.Type, attempt.RemoteIP, attempt.RequestURI, attempt.RequestMethod); err != nil {
+		attempt.Type, attempt.RemoteIP, ipBytes(attempt.RemoteIP.IP), attempt.Country, attempt.ASN, attempt.RequestURI, attempt.RequestMethod); err != nil {
 		return fmt.Errorf("error inserting authentication attempt: %w", err)
 	}
 
 	return nil
 }
 
-// LoadAuthenticationLogs retrieve the latest failed authentications from the authentication log.
+// LoadAuthenticationLogs retrieve the latest failed authentications from the authentication log. It's only read from
+// the primary database as the regulation must see the attempts which were just made.
 func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
-	rows, err := p.db.QueryxContext(ctx, p.sqlSelectAuthenticationAttemptsByUsername, fromDate, username, limit, limit*page)
+	ctx, done := p.operation(ctx, "LoadAuthenticationLogs")
+	defer done(&err)
+
+	return p.loadAuthenticationLogs(ctx, p.db, p.sqlSelectAuthenticationAttemptsByUsername, limit, fromDate, username, limit, limit*page)
+}
+
+// LoadAuthenticationLogsHistory retrieve all authentications of a given user from the authentication log including
+// the banned attempts.
+func (p *SQLProvider) LoadAuthenticationLogsHistory(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
+	ctx, done := p.operation(ctx, "LoadAuthenticationLogsHistory")
+	defer done(&err)
+
+	err = p.read(ctx, func(db *sqlx.DB) (err error) {
+		attempts, err = p.loadAuthenticationLogs(ctx, db, p.sqlSelectAuthenticationAttemptsHistory, limit, fromDate, username, limit, limit*page)
+
+		return err
+	})
+
+	return attempts, err
+}
+
+// LoadAuthenticationLogsByType retrieve the latest authentications of a given type from the authentication log.
+func (p *SQLProvider) LoadAuthenticationLogsByType(ctx context.Context, username, authType string, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
+	ctx, done := p.operation(ctx, "LoadAuthenticationLogsByType")
+	defer done(&err)
+
+	return p.loadAuthenticationLogs(ctx, p.db, p.sqlSelectAuthenticationAttemptsByUsernameAndType, limit, fromDate, username, authType, limit, limit*page)
+}
+
+// LoadFailedAuthenticationLogsByRemoteIP retrieve the latest failed authentications made from the remote IP from the
+// authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogsByRemoteIP(ctx context.Context, remoteIP net.IP, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
+	ctx, done := p.operation(ctx, "LoadFailedAuthenticationLogsByRemoteIP")
+	defer done(&err)
+
+	return p.loadAuthenticationLogs(ctx, p.db, p.sqlSelectFailedAuthenticationAttemptsByRemoteIP, limit, fromDate, models.NewNullIP(remoteIP), limit, limit*page)
+}
+
+// LoadFailedAuthenticationLogsBySubnet retrieve the latest failed authentications made from the subnet from the
+// authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogsBySubnet(ctx context.Context, subnet *net.IPNet, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
+	ctx, done := p.operation(ctx, "LoadFailedAuthenticationLogsBySubnet")
+	defer done(&err)
+
+	first, last := subnetBytesRange(subnet)
+
+	return p.loadAuthenticationLogs(ctx, p.db, p.sqlSelectFailedAuthenticationAttemptsBySubnet, limit, fromDate, first, last, limit, limit*page)
+}
+
+// LoadFailedAuthenticationLogs retrieve the latest failed authentications of all users from the authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogs(ctx context.Context, fromDate time.Time, limit, page int) (attempts []models.AuthenticationAttempt, err error) {
+	ctx, done := p.operation(ctx, "LoadFailedAuthenticationLogs")
+	defer done(&err)
+
+	return p.loadAuthenticationLogs(ctx, p.db, p.sqlSelectFailedAuthenticationAttempts, limit, fromDate, limit, limit*page)
+}
+
+// CountSuccessfulAuthenticationLogsByRemoteIP counts the successful authentications of a given type made by the user
+// from the remote IP after the given date.
+func (p *SQLProvider) CountSuccessfulAuthenticationLogsByRemoteIP(ctx context.Context, username, authType string, remoteIP net.IP, fromDate time.Time) (count int, err error) {
+	ctx, done := p.operation(ctx, "CountSuccessfulAuthenticationLogsByRemoteIP")
+	defer done(&err)
+
+	if err = p.db.GetContext(ctx, &count, p.sqlSelectSuccessfulAuthenticationAttemptCountByRemoteIP, fromDate, username, authType, models.NewNullIP(remoteIP)); err != nil {
+		return 0, fmt.Errorf("error counting the successful authentications of user '%s' from remote IP '%s': %w", username, remoteIP, err)
+	}
+
+	return count, nil
+}
+
+func (p *SQLProvider) loadAuthenticationLogs(ctx context.Context, db sqlx.QueryerContext, query string, limit int, args ...interface{}) (attempts []models.AuthenticationAttempt, err error) {
+	rows, err := db.QueryxContext(ctx, query, args...)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNoAuthenticationLogs
@@ -507,3 +920,87 @@ func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username strin
 
 	return attempts, nil
 }
+
+// SaveRegulationBan saves a regulation ban.
+func (p *SQLProvider) SaveRegulationBan(ctx context.Context, ban models.RegulationBan) (err error) {
+	ctx, done := p.operation(ctx, "SaveRegulationBan")
+	defer done(&err)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertRegulationBan, ban.Time, ban.Expires, ban.Type, ban.Value); err != nil {
+		return fmt.Errorf("error inserting regulation ban for %s '%s': %w", ban.Type, ban.Value, err)
+	}
+
+	return nil
+}
+
+// LoadRegulationBans loads the regulation bans of a subject which were created or expire after the given time, the most
+// recent first.
+func (p *SQLProvider) LoadRegulationBans(ctx context.Context, banType, value string, since time.Time) (bans []models.RegulationBan, err error) {
+	ctx, done := p.operation(ctx, "LoadRegulationBans")
+	defer done(&err)
+
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectRegulationBans, banType, value, since, since); err != nil {
+		return nil, fmt.Errorf("error selecting regulation bans for %s '%s': %w", banType, value, err)
+	}
+
+	return bans, nil
+}
+
+// LoadActiveRegulationBans loads the regulation bans which aren't revoked and expire after the given time, the most
+// recent first.
+func (p *SQLProvider) LoadActiveRegulationBans(ctx context.Context, now time.Time, limit, page int) (bans []models.RegulationBan, err error) {
+	ctx, done := p.operation(ctx, "LoadActiveRegulationBans")
+	defer done(&err)
+
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectActiveRegulationBans, now, limit, limit*page); err != nil {
+		return nil, fmt.Errorf("error selecting active regulation bans: %w", err)
+	}
+
+	return bans, nil
+}
+
+// RevokeRegulationBans revokes the active regulation bans of a subject and returns the number of bans revoked.
+func (p *SQLProvider) RevokeRegulationBans(ctx context.Context, banType, value string, now time.Time) (count int64, err error) {
+	ctx, done := p.operation(ctx, "RevokeRegulationBans")
+	defer done(&err)
+
+	result, err := p.db.ExecContext(ctx, p.sqlRevokeRegulationBans, now, banType, value, now)
+	if err != nil {
+		return 0, fmt.Errorf("error revoking regulation bans for %s '%s': %w", banType, value, err)
+	}
+
+	if count, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error revoking regulation bans for %s '%s': %w", banType, value, err)
+	}
+
+	return count, nil
+}
+
+// ipBytes returns the 16 byte form of the IP stored along with the IP so the IPs of a subnet can be selected with a
+// range condition, or nil when there is no IP. IPv4 addresses are stored as IPv4-mapped IPv6 addresses.
+func ipBytes(ip net.IP) interface{} {
+	if ip = ip.To16(); ip == nil {
+		return nil
+	}
+
+	return []byte(ip)
+}
+
+// subnetBytesRange returns the 16 byte forms of the first and last IPs of the subnet.
+func subnetBytesRange(subnet *net.IPNet) (first, last []byte) {
+	ip, mask := subnet.IP.To16(), subnet.Mask
+
+	// The mask of an IPv4 subnet only covers the last 4 bytes of the IPv4-mapped IPv6 address.
+	if ones, bits := mask.Size(); bits == 8*net.IPv4len {
+		mask = net.CIDRMask(8*(net.IPv6len-net.IPv4len)+ones, 8*net.IPv6len)
+	}
+
+	first, last = make([]byte, net.IPv6len), make([]byte, net.IPv6len)
+
+	for i := range ip {
+		first[i] = ip[i] & mask[i]
+		last[i] = ip[i] | ^mask[i]
+	}
+
+	return first, last
+}
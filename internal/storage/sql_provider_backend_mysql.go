@@ -25,10 +25,30 @@ func NewMySQLProvider(config *schema.Configuration) (provider *MySQLProvider) {
 
 	// Specific alterations to this provider.
 	provider.sqlFmtRenameTable = queryFmtMySQLRenameTable
+	provider.sqlIncrementSessionGeneration = fmt.Sprintf(queryFmtMySQLIncrementSessionGeneration, tableSessionGenerations)
+
+	provider.configureSQL(config.Storage.MySQL.SQLStorageConfiguration, dataSourceNamesMySQLReplicas(*config.Storage.MySQL))
 
 	return provider
 }
 
+// dataSourceNamesMySQLReplicas returns the data source names of the replicas, which use the port of the database when
+// they don't have one.
+func dataSourceNamesMySQLReplicas(config schema.MySQLStorageConfiguration) (dataSourceNames []string) {
+	for _, replica := range config.Replicas {
+		c := config
+		c.Host = replica.Host
+
+		if replica.Port > 0 {
+			c.Port = replica.Port
+		}
+
+		dataSourceNames = append(dataSourceNames, dataSourceNameMySQL(c))
+	}
+
+	return dataSourceNames
+}
+
 func dataSourceNameMySQL(config schema.MySQLStorageConfiguration) (dataSourceName string) {
 	dataSourceName = fmt.Sprintf("%s:%s", config.Username, config.Password)
 
@@ -31,6 +31,8 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlUpsertTOTPConfig = fmt.Sprintf(queryFmtPostgresUpsertTOTPConfiguration, tableTOTPConfigurations)
 	provider.sqlUpsertPreferred2FAMethod = fmt.Sprintf(queryFmtPostgresUpsertPreferred2FAMethod, tableUserPreferences)
 	provider.sqlUpsertEncryptionValue = fmt.Sprintf(queryFmtPostgresUpsertEncryptionValue, tableEncryption)
+	provider.sqlUpsertSessionData = fmt.Sprintf(queryFmtPostgresUpsertSessionData, tableSessions)
+	provider.sqlUpsertSessionInventoryRecord = fmt.Sprintf(queryFmtPostgresUpsertSessionInventoryRecord, tableSessionInventory)
 
 	// PostgreSQL requires rebinding of any query that contains a '?' placeholder to use the '$#' notation placeholders.
 	provider.sqlFmtRenameTable = provider.db.Rebind(provider.sqlFmtRenameTable)
@@ -39,6 +41,8 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlSelectIdentityVerification = provider.db.Rebind(provider.sqlSelectIdentityVerification)
 	provider.sqlInsertIdentityVerification = provider.db.Rebind(provider.sqlInsertIdentityVerification)
 	provider.sqlConsumeIdentityVerification = provider.db.Rebind(provider.sqlConsumeIdentityVerification)
+	provider.sqlSelectIdentityVerificationTimesByUsername = provider.db.Rebind(provider.sqlSelectIdentityVerificationTimesByUsername)
+	provider.sqlSelectIdentityVerificationTimesByIssuedIP = provider.db.Rebind(provider.sqlSelectIdentityVerificationTimesByIssuedIP)
 	provider.sqlSelectTOTPConfig = provider.db.Rebind(provider.sqlSelectTOTPConfig)
 	provider.sqlDeleteTOTPConfig = provider.db.Rebind(provider.sqlDeleteTOTPConfig)
 	provider.sqlSelectTOTPConfigs = provider.db.Rebind(provider.sqlSelectTOTPConfigs)
@@ -46,12 +50,32 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 	provider.sqlUpdateTOTPConfigSecretByUsername = provider.db.Rebind(provider.sqlUpdateTOTPConfigSecretByUsername)
 	provider.sqlSelectU2FDevice = provider.db.Rebind(provider.sqlSelectU2FDevice)
 	provider.sqlSelectU2FDevices = provider.db.Rebind(provider.sqlSelectU2FDevices)
+	provider.sqlDeleteU2FDevice = provider.db.Rebind(provider.sqlDeleteU2FDevice)
 	provider.sqlUpdateU2FDevicePublicKey = provider.db.Rebind(provider.sqlUpdateU2FDevicePublicKey)
 	provider.sqlUpdateU2FDevicePublicKeyByUsername = provider.db.Rebind(provider.sqlUpdateU2FDevicePublicKeyByUsername)
 	provider.sqlSelectDuoDevice = provider.db.Rebind(provider.sqlSelectDuoDevice)
 	provider.sqlDeleteDuoDevice = provider.db.Rebind(provider.sqlDeleteDuoDevice)
+	provider.sqlSelectSessionGeneration = provider.db.Rebind(provider.sqlSelectSessionGeneration)
+	provider.sqlIncrementSessionGeneration = provider.db.Rebind(provider.sqlIncrementSessionGeneration)
+	provider.sqlSelectSessionData = provider.db.Rebind(provider.sqlSelectSessionData)
+	provider.sqlUpdateSessionDataID = provider.db.Rebind(provider.sqlUpdateSessionDataID)
+	provider.sqlDeleteSessionData = provider.db.Rebind(provider.sqlDeleteSessionData)
+	provider.sqlDeleteExpiredSessionData = provider.db.Rebind(provider.sqlDeleteExpiredSessionData)
+	provider.sqlSelectSessionDataCount = provider.db.Rebind(provider.sqlSelectSessionDataCount)
+	provider.sqlSelectSessionInventoryRecords = provider.db.Rebind(provider.sqlSelectSessionInventoryRecords)
+	provider.sqlDeleteSessionInventoryRecord = provider.db.Rebind(provider.sqlDeleteSessionInventoryRecord)
 	provider.sqlInsertAuthenticationAttempt = provider.db.Rebind(provider.sqlInsertAuthenticationAttempt)
 	provider.sqlSelectAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsername)
+	provider.sqlSelectAuthenticationAttemptsHistory = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsHistory)
+	provider.sqlSelectAuthenticationAttemptsByUsernameAndType = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsernameAndType)
+	provider.sqlSelectFailedAuthenticationAttemptsByRemoteIP = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsByRemoteIP)
+	provider.sqlSelectFailedAuthenticationAttemptsBySubnet = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsBySubnet)
+	provider.sqlSelectFailedAuthenticationAttempts = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttempts)
+	provider.sqlSelectSuccessfulAuthenticationAttemptCountByRemoteIP = provider.db.Rebind(provider.sqlSelectSuccessfulAuthenticationAttemptCountByRemoteIP)
+	provider.sqlInsertRegulationBan = provider.db.Rebind(provider.sqlInsertRegulationBan)
+	provider.sqlSelectRegulationBans = provider.db.Rebind(provider.sqlSelectRegulationBans)
+	provider.sqlSelectActiveRegulationBans = provider.db.Rebind(provider.sqlSelectActiveRegulationBans)
+	provider.sqlRevokeRegulationBans = provider.db.Rebind(provider.sqlRevokeRegulationBans)
 	provider.sqlInsertMigration = provider.db.Rebind(provider.sqlInsertMigration)
 	provider.sqlSelectMigrations = provider.db.Rebind(provider.sqlSelectMigrations)
 	provider.sqlSelectLatestMigration = provider.db.Rebind(provider.sqlSelectLatestMigration)
@@ -59,9 +83,28 @@ func NewPostgreSQLProvider(config *schema.Configuration) (provider *PostgreSQLPr
 
 	provider.schema = config.Storage.PostgreSQL.Schema
 
+	provider.configureSQL(config.Storage.PostgreSQL.SQLStorageConfiguration, dataSourceNamesPostgreSQLReplicas(*config.Storage.PostgreSQL))
+
 	return provider
 }
 
+// dataSourceNamesPostgreSQLReplicas returns the data source names of the replicas, which use the port of the database
+// when they don't have one.
+func dataSourceNamesPostgreSQLReplicas(config schema.PostgreSQLStorageConfiguration) (dataSourceNames []string) {
+	for _, replica := range config.Replicas {
+		c := config
+		c.Host = replica.Host
+
+		if replica.Port > 0 {
+			c.Port = replica.Port
+		}
+
+		dataSourceNames = append(dataSourceNames, dataSourceNamePostgreSQL(c))
+	}
+
+	return dataSourceNames
+}
+
 func dataSourceNamePostgreSQL(config schema.PostgreSQLStorageConfiguration) (dataSourceName string) {
 	args := []string{
 		fmt.Sprintf("host=%s", config.Host),
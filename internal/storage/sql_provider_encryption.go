@@ -2,14 +2,12 @@ package storage
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/authelia/authelia/v4/internal/models"
-	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // SchemaEncryptionChangeKey uses the currently configured key to decrypt values in the database and the key provided
@@ -20,7 +18,7 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, encryptionK
 		return fmt.Errorf("error beginning transaction to change encryption key: %w", err)
 	}
 
-	key := sha256.Sum256([]byte(encryptionKey))
+	key := newEncryptionKey(encryptionKey)
 
 	if err = p.schemaEncryptionChangeKeyTOTP(ctx, tx, key); err != nil {
 		return err
@@ -30,7 +28,16 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, encryptionK
 		return err
 	}
 
-	if err = p.setNewEncryptionCheckValue(ctx, &key, tx); err != nil {
+	// Sessions are short lived so they're deleted instead of being encrypted again, which logs out every user.
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteAllSessionData); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+		}
+
+		return fmt.Errorf("rollback due to error: %w", err)
+	}
+
+	if err = p.setNewEncryptionCheckValue(ctx, key, tx); err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
 			return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
 		}
@@ -41,7 +48,7 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, encryptionK
 	return tx.Commit()
 }
 
-func (p *SQLProvider) schemaEncryptionChangeKeyTOTP(ctx context.Context, tx *sqlx.Tx, key [32]byte) (err error) {
+func (p *SQLProvider) schemaEncryptionChangeKeyTOTP(ctx context.Context, tx *sqlx.Tx, key encryptionKey) (err error) {
 	var configs []models.TOTPConfiguration
 
 	for page := 0; true; page++ {
@@ -54,7 +61,7 @@ func (p *SQLProvider) schemaEncryptionChangeKeyTOTP(ctx context.Context, tx *sql
 		}
 
 		for _, config := range configs {
-			if config.Secret, err = utils.Encrypt(config.Secret, &key); err != nil {
+			if config.Secret, err = key.encrypt(config.Secret); err != nil {
 				if rollbackErr := tx.Rollback(); rollbackErr != nil {
 					return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
 				}
@@ -79,7 +86,7 @@ func (p *SQLProvider) schemaEncryptionChangeKeyTOTP(ctx context.Context, tx *sql
 	return nil
 }
 
-func (p *SQLProvider) schemaEncryptionChangeKeyU2F(ctx context.Context, tx *sqlx.Tx, key [32]byte) (err error) {
+func (p *SQLProvider) schemaEncryptionChangeKeyU2F(ctx context.Context, tx *sqlx.Tx, key encryptionKey) (err error) {
 	var devices []models.U2FDevice
 
 	for page := 0; true; page++ {
@@ -92,7 +99,7 @@ func (p *SQLProvider) schemaEncryptionChangeKeyU2F(ctx context.Context, tx *sqlx
 		}
 
 		for _, device := range devices {
-			if device.PublicKey, err = utils.Encrypt(device.PublicKey, &key); err != nil {
+			if device.PublicKey, err = key.encrypt(device.PublicKey); err != nil {
 				if rollbackErr := tx.Rollback(); rollbackErr != nil {
 					return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
 				}
@@ -260,11 +267,116 @@ func (p *SQLProvider) schemaEncryptionCheckU2F(ctx context.Context) (err error)
 }
 
 func (p SQLProvider) encrypt(clearText []byte) (cipherText []byte, err error) {
-	return utils.Encrypt(clearText, &p.key)
+	return p.keys.primary.encrypt(clearText)
 }
 
 func (p SQLProvider) decrypt(cipherText []byte) (clearText []byte, err error) {
-	return utils.Decrypt(cipherText, &p.key)
+	clearText, _, err = p.keys.decrypt(cipherText)
+
+	return clearText, err
+}
+
+// decryptReencrypt decrypts a value of a row, and if the value wasn't encrypted with the primary key it encrypts it
+// again with the primary key. A failure to encrypt the value again is only logged as the value was still decrypted.
+func (p *SQLProvider) decryptReencrypt(ctx context.Context, column encryptedColumn, id int, cipherText []byte) (clearText []byte, err error) {
+	var primary bool
+
+	if clearText, primary, err = p.keys.decrypt(cipherText); err != nil || primary {
+		return clearText, err
+	}
+
+	if _, err = p.reencrypt(ctx, column, id, cipherText, clearText); err != nil {
+		p.log.Errorf("Error occurred encrypting the %s of row %d of table '%s' with the primary encryption key: %+v", column.column, id, column.table, err)
+	}
+
+	return clearText, nil
+}
+
+// reencrypt encrypts the clear text of a value with the primary key and updates the row if its value is still the
+// cipher text it was decrypted from, so a value which was changed in the meantime isn't overwritten.
+func (p *SQLProvider) reencrypt(ctx context.Context, column encryptedColumn, id int, cipherText, clearText []byte) (updated bool, err error) {
+	value, err := p.encrypt(clearText)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := p.db.ExecContext(ctx, p.db.Rebind(fmt.Sprintf(queryFmtUpdateEncryptedValue, column.table, column.column)), value, id, cipherText)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+
+	return affected != 0, err
+}
+
+// SchemaEncryptionReencrypt encrypts every value which isn't encrypted with the primary encryption key again with the
+// primary encryption key and returns the number of values encrypted again. Each value is updated on its own so this can
+// be done while the database is in use, and once it's done the decryption keys are no longer needed.
+func (p *SQLProvider) SchemaEncryptionReencrypt(ctx context.Context) (count int, err error) {
+	invalid := 0
+
+	for _, column := range encryptedColumns {
+		var n, i int
+
+		if n, i, err = p.schemaEncryptionReencryptColumn(ctx, column); err != nil {
+			return count, fmt.Errorf("error encrypting the %s values of table '%s' again: %w", column.column, column.table, err)
+		}
+
+		count += n
+		invalid += i
+	}
+
+	if invalid != 0 {
+		return count, fmt.Errorf(errFmtSchemaEncryptionReencryptInvalid, invalid)
+	}
+
+	return count, nil
+}
+
+func (p *SQLProvider) schemaEncryptionReencryptColumn(ctx context.Context, column encryptedColumn) (count, invalid int, err error) {
+	query := p.db.Rebind(fmt.Sprintf(queryFmtSelectEncryptedValues, column.column, column.table))
+
+	var (
+		values    []encryptedValue
+		clearText []byte
+		lastID    int
+		updated   bool
+	)
+
+	for {
+		values = nil
+
+		if err = p.db.SelectContext(ctx, &values, query, lastID, encryptionPageSize); err != nil {
+			return count, invalid, err
+		}
+
+		for _, value := range values {
+			lastID = value.ID
+
+			if p.keys.isPrimary(value.Value) {
+				continue
+			}
+
+			if clearText, _, err = p.keys.decrypt(value.Value); err != nil {
+				invalid++
+
+				continue
+			}
+
+			if updated, err = p.reencrypt(ctx, column, value.ID, value.Value, clearText); err != nil {
+				return count, invalid, err
+			}
+
+			if updated {
+				count++
+			}
+		}
+
+		if len(values) < encryptionPageSize {
+			return count, invalid, nil
+		}
+	}
 }
 
 func (p *SQLProvider) getEncryptionValue(ctx context.Context, name string) (value []byte, err error) {
@@ -278,13 +390,13 @@ func (p *SQLProvider) getEncryptionValue(ctx context.Context, name string) (valu
 	return p.decrypt(encryptedValue)
 }
 
-func (p *SQLProvider) setNewEncryptionCheckValue(ctx context.Context, key *[32]byte, e sqlx.ExecerContext) (err error) {
+func (p *SQLProvider) setNewEncryptionCheckValue(ctx context.Context, key encryptionKey, e sqlx.ExecerContext) (err error) {
 	valueClearText, err := uuid.NewUUID()
 	if err != nil {
 		return err
 	}
 
-	value, err := utils.Encrypt([]byte(valueClearText.String()), key)
+	value, err := key.encrypt([]byte(valueClearText.String()))
 	if err != nil {
 		return err
 	}
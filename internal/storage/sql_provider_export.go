@@ -0,0 +1,396 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// ExportData writes the header and then every row of the exported tables to the writer as NDJSON. The values of the
+// encrypted columns are decrypted so the export can be imported into a database using another encryption key. The rows
+// are read in a single read-only transaction so the export is consistent while Authelia keeps running.
+func (p *SQLProvider) ExportData(ctx context.Context, w io.Writer) (count int, err error) {
+	version, err := p.SchemaVersion(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	encoder := json.NewEncoder(w)
+
+	if err = encoder.Encode(ExportHeader{
+		Format:             exportFormat,
+		FormatVersion:      exportFormatVersion,
+		SchemaVersion:      version,
+		ApplicationVersion: utils.Version(),
+		Provider:           p.name,
+		Time:               time.Now(),
+	}); err != nil {
+		return 0, fmt.Errorf("error writing the export header: %w", err)
+	}
+
+	tx, err := p.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, fmt.Errorf("error beginning transaction to export data: %w", err)
+	}
+
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, table := range exportTables {
+		var n int
+
+		if n, err = p.exportTable(ctx, tx, encoder, table); err != nil {
+			return count, fmt.Errorf("error exporting table '%s': %w", table.name, err)
+		}
+
+		count += n
+	}
+
+	return count, nil
+}
+
+// exportTable exports the rows of the table in pages of rows with an id greater than the last exported id.
+func (p *SQLProvider) exportTable(ctx context.Context, tx *sqlx.Tx, encoder *json.Encoder, table exportTable) (count int, err error) {
+	names := table.columnNames()
+
+	query := tx.Rebind(fmt.Sprintf(queryFmtSelectExportRows, strings.Join(names, ", "), table.name))
+
+	var (
+		rows   *sqlx.Rows
+		lastID int
+	)
+
+	for {
+		if rows, err = tx.QueryxContext(ctx, query, lastID, exportPageSize); err != nil {
+			return count, err
+		}
+
+		row := 0
+
+		for rows.Next() {
+			row++
+
+			values := make([]interface{}, len(table.columns)+1)
+			values[0] = &lastID
+
+			for i, column := range table.columns {
+				values[i+1] = column.kind.scanner()
+			}
+
+			if err = rows.Scan(values...); err != nil {
+				_ = rows.Close()
+
+				return count, err
+			}
+
+			record := ExportRecord{Table: table.name, Data: make(map[string]interface{}, len(table.columns))}
+
+			for i, column := range table.columns {
+				if record.Data[column.name], err = p.exportValue(column.kind, values[i+1]); err != nil {
+					_ = rows.Close()
+
+					return count, fmt.Errorf("error exporting column '%s': %w", column.name, err)
+				}
+			}
+
+			if err = encoder.Encode(record); err != nil {
+				_ = rows.Close()
+
+				return count, err
+			}
+
+			count++
+		}
+
+		err = rows.Err()
+
+		_ = rows.Close()
+
+		if err != nil {
+			return count, err
+		}
+
+		if row < exportPageSize {
+			break
+		}
+	}
+
+	return count, nil
+}
+
+func (p *SQLProvider) exportValue(kind exportColumnKind, scanned interface{}) (value interface{}, err error) {
+	switch v := scanned.(type) {
+	case *sql.NullString:
+		if v.Valid {
+			return v.String, nil
+		}
+	case *sql.NullInt64:
+		if v.Valid {
+			return v.Int64, nil
+		}
+	case *sql.NullBool:
+		if v.Valid {
+			return v.Bool, nil
+		}
+	case *sql.NullTime:
+		if v.Valid {
+			return v.Time.UTC(), nil
+		}
+	case *[]byte:
+		if *v == nil {
+			return nil, nil
+		}
+
+		if kind == exportColumnEncrypted {
+			return p.decrypt(*v)
+		}
+
+		return *v, nil
+	}
+
+	return nil, nil
+}
+
+// ImportData imports an export written by ExportData in a single transaction. The schema version of the database
+// must be the same as the schema version of the export and the imported tables must be empty. The values of the
+// encrypted columns are encrypted with the encryption key of this provider.
+func (p *SQLProvider) ImportData(ctx context.Context, r io.Reader) (count int, err error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), exportMaxLineSize)
+
+	if !scanner.Scan() {
+		if err = scanner.Err(); err != nil {
+			return 0, fmt.Errorf("error reading the import header: %w", err)
+		}
+
+		return 0, ErrImportEmpty
+	}
+
+	if err = p.importCheckHeader(ctx, scanner.Bytes()); err != nil {
+		return 0, err
+	}
+
+	tables := make(map[string]exportTable, len(exportTables))
+
+	for _, table := range exportTables {
+		var n int
+
+		if err = p.db.GetContext(ctx, &n, fmt.Sprintf(queryFmtSelectRowCount, table.name)); err != nil {
+			return 0, fmt.Errorf("error counting the rows of table '%s': %w", table.name, err)
+		}
+
+		if n != 0 {
+			return 0, fmt.Errorf(errFmtImportTableNotEmpty, table.name, n)
+		}
+
+		tables[table.name] = table
+	}
+
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error beginning transaction to import data: %w", err)
+	}
+
+	queries := make(map[string]string, len(exportTables))
+
+	for line := 2; scanner.Scan(); line++ {
+		if err = p.importRecord(ctx, tx, tables, queries, scanner.Bytes()); err != nil {
+			return 0, p.importRollback(tx, fmt.Errorf("error importing line %d: %w", line, err))
+		}
+
+		count++
+	}
+
+	if err = scanner.Err(); err != nil {
+		return 0, p.importRollback(tx, fmt.Errorf("error reading the import: %w", err))
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("error committing the import: %w", err)
+	}
+
+	return count, nil
+}
+
+func (p *SQLProvider) importCheckHeader(ctx context.Context, data []byte) (err error) {
+	var header ExportHeader
+
+	if err = json.Unmarshal(data, &header); err != nil {
+		return fmt.Errorf("error decoding the import header: %w", err)
+	}
+
+	if header.Format != exportFormat {
+		return ErrImportInvalidFormat
+	}
+
+	if header.FormatVersion != exportFormatVersion {
+		return fmt.Errorf(errFmtImportFormatVersionUnsupported, header.FormatVersion, exportFormatVersion)
+	}
+
+	version, err := p.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+
+	if header.SchemaVersion != version {
+		return fmt.Errorf(errFmtImportSchemaVersionMismatch, header.SchemaVersion, version)
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) importRecord(ctx context.Context, tx *sqlx.Tx, tables map[string]exportTable, queries map[string]string, data []byte) (err error) {
+	var record struct {
+		Table string                     `json:"table"`
+		Data  map[string]json.RawMessage `json:"data"`
+	}
+
+	if err = json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	table, ok := tables[record.Table]
+	if !ok {
+		return fmt.Errorf("table '%s' can't be imported", record.Table)
+	}
+
+	if len(record.Data) != len(table.columns) {
+		return fmt.Errorf("table '%s' has %d columns but the row has %d", table.name, len(table.columns), len(record.Data))
+	}
+
+	values := make([]interface{}, len(table.columns))
+
+	for i, column := range table.columns {
+		raw, ok := record.Data[column.name]
+		if !ok {
+			return fmt.Errorf("table '%s' row is missing the column '%s'", table.name, column.name)
+		}
+
+		if values[i], err = p.importValue(column.kind, raw); err != nil {
+			return fmt.Errorf("table '%s' column '%s' has an invalid value: %w", table.name, column.name, err)
+		}
+	}
+
+	query, ok := queries[table.name]
+	if !ok {
+		names := table.columnNames()
+
+		query = tx.Rebind(fmt.Sprintf(queryFmtInsertImportRow, table.name, strings.Join(names, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", ")))
+		queries[table.name] = query
+	}
+
+	_, err = tx.ExecContext(ctx, query, values...)
+
+	return err
+}
+
+func (p *SQLProvider) importValue(kind exportColumnKind, raw json.RawMessage) (value interface{}, err error) {
+	if string(raw) == "null" {
+		return nil, nil
+	}
+
+	switch kind {
+	case exportColumnString:
+		var v string
+		err = json.Unmarshal(raw, &v)
+
+		return v, err
+	case exportColumnInteger:
+		var v int64
+		err = json.Unmarshal(raw, &v)
+
+		return v, err
+	case exportColumnBoolean:
+		var v bool
+		err = json.Unmarshal(raw, &v)
+
+		return v, err
+	case exportColumnTime:
+		var v time.Time
+		err = json.Unmarshal(raw, &v)
+
+		return v, err
+	default:
+		var v []byte
+		if err = json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+
+		if kind == exportColumnEncrypted {
+			return p.encrypt(v)
+		}
+
+		return v, nil
+	}
+}
+
+func (p *SQLProvider) importRollback(tx *sqlx.Tx, err error) error {
+	if rollbackErr := tx.Rollback(); rollbackErr != nil {
+		return fmt.Errorf("rollback error %v: rollback due to error: %w", rollbackErr, err)
+	}
+
+	return fmt.Errorf("rollback due to error: %w", err)
+}
+
+func (t exportTable) columnNames() (names []string) {
+	names = make([]string, len(t.columns))
+
+	for i, column := range t.columns {
+		names[i] = column.name
+	}
+
+	return names
+}
+
+func (k exportColumnKind) scanner() interface{} {
+	switch k {
+	case exportColumnString:
+		return &sql.NullString{}
+	case exportColumnInteger:
+		return &sql.NullInt64{}
+	case exportColumnBoolean:
+		return &sql.NullBool{}
+	case exportColumnTime:
+		return &sql.NullTime{}
+	default:
+		return &[]byte{}
+	}
+}
+
+// ExportHeader is the first line of an export which describes its format and the database it was exported from.
+type ExportHeader struct {
+	Format             string    `json:"format"`
+	FormatVersion      int       `json:"format_version"`
+	SchemaVersion      int       `json:"schema_version"`
+	ApplicationVersion string    `json:"application_version"`
+	Provider           string    `json:"provider"`
+	Time               time.Time `json:"time"`
+}
+
+// ExportRecord is a line of an export after the header which holds a single row of a table.
+type ExportRecord struct {
+	Table string                 `json:"table"`
+	Data  map[string]interface{} `json:"data"`
+}
+
+type exportTable struct {
+	name    string
+	columns []exportColumn
+}
+
+type exportColumn struct {
+	name string
+	kind exportColumnKind
+}
+
+type exportColumnKind int
@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/models"
+)
+
+func newTestSQLiteProvider(t *testing.T, encryptionKey string) *SQLiteProvider {
+	provider := NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.StorageConfiguration{
+			EncryptionKey: encryptionKey,
+			Local:         &schema.LocalStorageConfiguration{Path: filepath.Join(t.TempDir(), "db.sqlite3")},
+		},
+	})
+
+	require.NoError(t, provider.SchemaMigrate(context.Background(), true, SchemaLatest))
+
+	t.Cleanup(func() {
+		_ = provider.Close()
+	})
+
+	return provider
+}
+
+func TestShouldExportAndImportData(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	source := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	require.NoError(t, source.SavePreferred2FAMethod(ctx, "john", "totp"))
+	require.NoError(t, source.SaveTOTPConfiguration(ctx, models.TOTPConfiguration{
+		Username: "john", Issuer: "Authelia", Algorithm: "SHA1", Digits: 6, Period: 30, Secret: []byte("ABCDEFGHIJKLMNOP"),
+	}))
+	require.NoError(t, source.SaveU2FDevice(ctx, models.U2FDevice{
+		Username: "john", Description: "Primary", KeyHandle: []byte("handle"), PublicKey: []byte("public key"),
+	}))
+	require.NoError(t, source.SavePreferredDuoDevice(ctx, models.DuoDevice{Username: "john", Device: "ABC123", Method: "push"}))
+	require.NoError(t, source.SaveIdentityVerification(ctx, models.IdentityVerification{
+		JTI: uuid.New(), IssuedAt: now, IssuedIP: models.NewIP(net.ParseIP("192.168.1.10")), ExpiresAt: now.Add(5 * time.Minute),
+		Action: "ResetPassword", Username: "john",
+	}))
+	require.NoError(t, source.IncrementSessionGeneration(ctx, "john"))
+	require.NoError(t, source.SaveRegulationBan(ctx, models.RegulationBan{Time: now, Expires: now.Add(time.Hour), Type: "user", Value: "john"}))
+
+	for i := 0; i < exportPageSize+5; i++ {
+		require.NoError(t, source.AppendAuthenticationLog(ctx, models.AuthenticationAttempt{
+			Time: now.Add(time.Duration(i) * time.Second), Successful: i%2 == 0, Username: "john", Type: "1FA",
+			RemoteIP: models.NewNullIP(net.ParseIP("192.168.1.10")), Country: "NZ", ASN: 64500,
+		}))
+	}
+
+	buf := new(bytes.Buffer)
+
+	count, err := source.ExportData(ctx, buf)
+	require.NoError(t, err)
+	assert.Equal(t, exportPageSize+12, count)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, count+1)
+
+	var header ExportHeader
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &header))
+	assert.Equal(t, exportFormat, header.Format)
+	assert.Equal(t, testLatestVersion, header.SchemaVersion)
+	assert.Equal(t, providerSQLite, header.Provider)
+
+	// The encrypted values are exported in clear text.
+	assert.Contains(t, buf.String(), `"secret":"QUJDREVGR0hJSktMTU5PUA=="`)
+
+	target := newTestSQLiteProvider(t, "another-encryption-key-which-is-long-enough")
+
+	count, err = target.ImportData(ctx, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, exportPageSize+12, count)
+
+	config, err := target.LoadTOTPConfiguration(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("ABCDEFGHIJKLMNOP"), config.Secret)
+
+	device, err := target.LoadU2FDevice(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("public key"), device.PublicKey)
+	assert.Equal(t, []byte("handle"), device.KeyHandle)
+
+	method, err := target.LoadPreferred2FAMethod(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, "totp", method)
+
+	generation, err := target.LoadSessionGeneration(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, 1, generation)
+
+	bans, err := target.LoadRegulationBans(ctx, "user", "john", now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Len(t, bans, 1)
+	assert.True(t, now.Add(time.Hour).Equal(bans[0].Expires))
+
+	attempts, err := target.LoadAuthenticationLogs(ctx, "john", now.Add(-time.Minute), 200, 0)
+	require.NoError(t, err)
+	assert.Len(t, attempts, exportPageSize+5)
+
+	require.NoError(t, target.SchemaEncryptionCheckKey(ctx, true))
+
+	// The tables must be empty to import data.
+	_, err = target.ImportData(ctx, bytes.NewReader(buf.Bytes()))
+	assert.EqualError(t, err, "the table 'user_preferences' must be empty to import data but it has 1 rows")
+}
+
+func TestShouldRejectInvalidImports(t *testing.T) {
+	ctx := context.Background()
+
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	header := func(format string, formatVersion, schemaVersion int) string {
+		return fmt.Sprintf(`{"format":"%s","format_version":%d,"schema_version":%d}`, format, formatVersion, schemaVersion)
+	}
+
+	testCases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{"ShouldRejectEmpty", "", "the import is empty"},
+		{"ShouldRejectOtherFormat", header("other", 1, testLatestVersion), "the import is not an Authelia storage export"},
+		{"ShouldRejectFormatVersion", header(exportFormat, 2, testLatestVersion), "the import format version 2 is not supported, the supported format version is 1"},
+		{"ShouldRejectSchemaVersion", header(exportFormat, 1, 3), fmt.Sprintf("the import is from schema version 3 but the schema is version %d, both must be the same version", testLatestVersion)},
+		{"ShouldRejectUnknownTable", header(exportFormat, 1, testLatestVersion) + "\n" + `{"table":"encryption","data":{}}`,
+			"rollback due to error: error importing line 2: table 'encryption' can't be imported"},
+		{"ShouldRejectMissingColumn", header(exportFormat, 1, testLatestVersion) + "\n" + `{"table":"duo_devices","data":{"username":"john","device":"ABC","other":"push"}}`,
+			"rollback due to error: error importing line 2: table 'duo_devices' row is missing the column 'method'"},
+		{"ShouldRejectInvalidValue", header(exportFormat, 1, testLatestVersion) + "\n" + `{"table":"session_generations","data":{"username":"john","generation":"1"}}`,
+			"rollback due to error: error importing line 2: table 'session_generations' column 'generation' has an invalid value: json: cannot unmarshal string into Go value of type int64"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.ImportData(ctx, strings.NewReader(tc.data))
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+
+	// Nothing is imported when any of the rows fail.
+	_, err := provider.ImportData(ctx, strings.NewReader(header(exportFormat, 1, testLatestVersion)+"\n"+
+		`{"table":"user_preferences","data":{"username":"john","second_factor_method":"totp"}}`+"\n"+`{"table":"unknown","data":{}}`))
+	assert.Error(t, err)
+
+	method, err := provider.LoadPreferred2FAMethod(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, "", method)
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/semconv"
+
+	"github.com/authelia/authelia/v4/internal/metrics"
+	"github.com/authelia/authelia/v4/internal/tracing"
+)
+
+// operation starts a storage operation by applying the query timeout to the context and starting a span. The returned
+// function is deferred with a pointer to the error of the operation, it names the operation in the error when the query
+// timeout was exceeded, ends the span, records the metrics and cancels the context once the operation has returned.
+func (p *SQLProvider) operation(ctx context.Context, name string) (context.Context, func(err *error)) {
+	started := time.Now()
+	parent := ctx
+
+	ctx, cancel := p.queryContext(ctx)
+	ctx, span := tracing.Start(ctx, "storage."+name, p.dbSystem())
+
+	return ctx, func(err *error) {
+		if *err != nil && parent.Err() == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			*err = fmt.Errorf("error running storage operation %s: query timeout of %s exceeded: %w", name, p.queryTimeout, *err)
+		}
+
+		failure := p.failure(*err)
+
+		tracing.End(span, failure)
+		metrics.ObserveBackend(metrics.BackendStorage, name, time.Since(started), failure)
+
+		cancel()
+	}
+}
+
+// failure returns the error unless it only indicates that nothing matched which isn't a failure of the storage.
+func (p *SQLProvider) failure(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows),
+		errors.Is(err, ErrNoAuthenticationLogs),
+		errors.Is(err, ErrNoTOTPConfiguration),
+		errors.Is(err, ErrNoU2FDeviceHandle),
+		errors.Is(err, ErrNoDuoDevice):
+		return nil
+	}
+
+	return err
+}
+
+// dbSystem returns the semantic convention of the database system of the provider.
+func (p *SQLProvider) dbSystem() attribute.KeyValue {
+	switch p.name {
+	case providerMySQL:
+		return semconv.DBSystemMySQL
+	case providerPostgres:
+		return semconv.DBSystemPostgres
+	default:
+		return semconv.DBSystemSqlite
+	}
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldNameStorageOperationWhenQueryTimeoutIsExceeded(t *testing.T) {
+	provider := &SQLProvider{queryTimeout: time.Millisecond}
+
+	ctx, done := provider.operation(context.Background(), "LoadUserInfo")
+
+	<-ctx.Done()
+
+	err := ctx.Err()
+	done(&err)
+
+	assert.EqualError(t, err, "error running storage operation LoadUserInfo: query timeout of 1ms exceeded: context deadline exceeded")
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestShouldNotNameStorageOperationWhenParentContextIsCancelled(t *testing.T) {
+	provider := &SQLProvider{queryTimeout: time.Minute}
+
+	parent, cancel := context.WithCancel(context.Background())
+
+	ctx, done := provider.operation(parent, "LoadUserInfo")
+
+	cancel()
+
+	err := ctx.Err()
+	done(&err)
+
+	assert.Equal(t, context.Canceled, err)
+}
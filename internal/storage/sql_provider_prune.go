@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/authelia/authelia/v4/internal/models"
+)
+
+// PruneData deletes the rows of the authentication_logs, identity_verification, and regulation_bans tables which are
+// older than the times of the options. The rows are selected and deleted by their id in batches as none of the
+// supported databases share a syntax for limiting a delete, which also keeps each transaction and its locks small. If
+// the options are for a dry run the rows are counted instead.
+func (p *SQLProvider) PruneData(ctx context.Context, opts models.PruneOptions) (result models.PruneResult, err error) {
+	if opts.BatchSize <= 0 {
+		return result, fmt.Errorf(errFmtPruneBatchSize, opts.BatchSize)
+	}
+
+	if result.AuthenticationLogs, err = p.pruneTable(ctx, tableAuthenticationLogs, "time", opts.AuthenticationLogs, opts); err != nil {
+		return result, err
+	}
+
+	if result.IdentityVerification, err = p.pruneTable(ctx, tableIdentityVerification, "exp", opts.IdentityVerification, opts); err != nil {
+		return result, err
+	}
+
+	if result.RegulationBans, err = p.pruneTable(ctx, tableRegulationBans, "expires", opts.RegulationBans, opts); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (p *SQLProvider) pruneTable(ctx context.Context, table, column string, before time.Time, opts models.PruneOptions) (count int64, err error) {
+	if before.IsZero() {
+		return 0, nil
+	}
+
+	if opts.DryRun {
+		if err = p.db.GetContext(ctx, &count, p.db.Rebind(fmt.Sprintf(queryFmtSelectPruneCount, table, column)), before); err != nil {
+			return 0, fmt.Errorf("error counting the rows to prune from table '%s': %w", table, err)
+		}
+
+		return count, nil
+	}
+
+	querySelect := p.db.Rebind(fmt.Sprintf(queryFmtSelectPruneIDs, table, column))
+	queryDelete := fmt.Sprintf(queryFmtDeletePruneIDs, table)
+
+	for {
+		var (
+			ids   []int
+			query string
+			args  []interface{}
+		)
+
+		if err = ctx.Err(); err != nil {
+			return count, err
+		}
+
+		if err = p.db.SelectContext(ctx, &ids, querySelect, before, opts.BatchSize); err != nil {
+			return count, fmt.Errorf("error selecting the rows to prune from table '%s': %w", table, err)
+		}
+
+		if len(ids) == 0 {
+			return count, nil
+		}
+
+		if query, args, err = sqlx.In(queryDelete, ids); err != nil {
+			return count, fmt.Errorf("error building the query to prune rows from table '%s': %w", table, err)
+		}
+
+		if _, err = p.db.ExecContext(ctx, p.db.Rebind(query), args...); err != nil {
+			return count, fmt.Errorf("error pruning rows from table '%s': %w", table, err)
+		}
+
+		count += int64(len(ids))
+
+		if len(ids) < opts.BatchSize {
+			return count, nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/models"
+)
+
+func TestShouldPruneDataInBatches(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	for i := 0; i < 25; i++ {
+		require.NoError(t, provider.AppendAuthenticationLog(ctx, models.AuthenticationAttempt{
+			Time: now.Add(-time.Duration(i) * time.Hour), Successful: true, Username: "john", Type: "1FA",
+			RemoteIP: models.NewNullIP(net.ParseIP("192.168.1.10")),
+		}))
+	}
+
+	for i := 0; i < 3; i++ {
+		iat := now.Add(-time.Duration(i*2) * time.Hour)
+
+		require.NoError(t, provider.SaveIdentityVerification(ctx, models.IdentityVerification{
+			JTI: uuid.New(), IssuedAt: iat, IssuedIP: models.NewIP(net.ParseIP("192.168.1.10")), ExpiresAt: iat.Add(5 * time.Minute),
+			Action: "ResetPassword", Username: "john",
+		}))
+	}
+
+	require.NoError(t, provider.SaveRegulationBan(ctx, models.RegulationBan{Time: now.Add(-3 * time.Hour), Expires: now.Add(-2 * time.Hour), Type: "user", Value: "john"}))
+	require.NoError(t, provider.SaveRegulationBan(ctx, models.RegulationBan{Time: now.Add(-3 * time.Hour), Expires: now.Add(time.Hour), Type: "user", Value: "harry"}))
+
+	opts := models.PruneOptions{
+		AuthenticationLogs:   now.Add(-9*time.Hour - time.Minute),
+		IdentityVerification: now.Add(-time.Hour),
+		RegulationBans:       now.Add(-time.Hour),
+		BatchSize:            4,
+		DryRun:               true,
+	}
+
+	result, err := provider.PruneData(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, models.PruneResult{AuthenticationLogs: 15, IdentityVerification: 2, RegulationBans: 1}, result)
+
+	attempts, err := provider.LoadAuthenticationLogs(ctx, "john", now.Add(-48*time.Hour), 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 25)
+
+	opts.DryRun = false
+
+	result, err = provider.PruneData(ctx, opts)
+	require.NoError(t, err)
+	assert.Equal(t, models.PruneResult{AuthenticationLogs: 15, IdentityVerification: 2, RegulationBans: 1}, result)
+	assert.Equal(t, int64(18), result.Total())
+
+	attempts, err = provider.LoadAuthenticationLogs(ctx, "john", now.Add(-48*time.Hour), 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 10)
+
+	times, err := provider.LoadIdentityVerificationTimesByUsername(ctx, "john", now.Add(-48*time.Hour), 10)
+	require.NoError(t, err)
+	assert.Len(t, times, 1)
+
+	// The active ban is kept.
+	bans, err := provider.LoadRegulationBans(ctx, "user", "harry", now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, bans, 1)
+
+	bans, err = provider.LoadRegulationBans(ctx, "user", "john", now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, bans, 0)
+
+	// A table with a zero time is kept.
+	result, err = provider.PruneData(ctx, models.PruneOptions{BatchSize: 4})
+	require.NoError(t, err)
+	assert.Equal(t, models.PruneResult{}, result)
+
+	_, err = provider.PruneData(ctx, models.PruneOptions{})
+	assert.EqualError(t, err, "the prune batch size must be greater than 0 but it is 0")
+}
+
+func TestShouldPruneDataWithRetentionJanitor(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	for _, age := range []time.Duration{time.Hour, 48 * time.Hour} {
+		require.NoError(t, provider.AppendAuthenticationLog(ctx, models.AuthenticationAttempt{
+			Time: now.Add(-age), Successful: true, Username: "john", Type: "1FA",
+		}))
+	}
+
+	janitor := NewRetentionJanitor(&schema.StorageRetentionConfiguration{AuthenticationLogs: "1d", RegulationBans: "0"}, provider)
+
+	assert.Equal(t, time.Hour, janitor.interval)
+	assert.Equal(t, 1000, janitor.batchSize)
+
+	opts := janitor.Options(true)
+	assert.WithinDuration(t, time.Now().Add(-24*time.Hour), opts.AuthenticationLogs, time.Minute)
+	assert.True(t, opts.IdentityVerification.IsZero())
+	assert.True(t, opts.RegulationBans.IsZero())
+	assert.True(t, opts.DryRun)
+
+	result, err := janitor.Prune(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.AuthenticationLogs)
+
+	attempts, err := provider.LoadAuthenticationLogs(ctx, "john", now.Add(-72*time.Hour), 100, 0)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 1)
+}
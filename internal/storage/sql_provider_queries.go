@@ -69,6 +69,20 @@ const (
 		UPDATE %s
 		SET consumed = CURRENT_TIMESTAMP, consumed_ip = ?
 		WHERE jti = ?;`
+
+	queryFmtSelectIdentityVerificationTimesByUsername = `
+		SELECT iat
+		FROM %s
+		WHERE iat > ? AND username = ?
+		ORDER BY iat DESC
+		LIMIT ?;`
+
+	queryFmtSelectIdentityVerificationTimesByIssuedIP = `
+		SELECT iat
+		FROM %s
+		WHERE iat > ? AND issued_ip = ?
+		ORDER BY iat DESC
+		LIMIT ?;`
 )
 
 const (
@@ -112,7 +126,7 @@ const (
 
 const (
 	queryFmtSelectU2FDevice = `
-		SELECT id, username, key_handle, public_key
+		SELECT id, username, description, key_handle, public_key
 		FROM %s
 		WHERE username = ?;`
 
@@ -122,6 +136,10 @@ const (
 		LIMIT ?
 		OFFSET ?;`
 
+	queryFmtDeleteU2FDevice = `
+		DELETE FROM %s
+		WHERE username = ?;`
+
 	queryFmtUpdateU2FDevicePublicKey = `
 		UPDATE %s
 		SET public_key = ?
@@ -166,10 +184,92 @@ const (
 		ORDER BY id;`
 )
 
+const (
+	queryFmtSelectSessionGeneration = `
+		SELECT generation
+		FROM %s
+		WHERE username = ?;`
+
+	queryFmtIncrementSessionGeneration = `
+		INSERT INTO %[1]s (username, generation)
+		VALUES (?, 1)
+			ON CONFLICT (username)
+			DO UPDATE SET generation = %[1]s.generation + 1;`
+
+	queryFmtMySQLIncrementSessionGeneration = `
+		INSERT INTO %s (username, generation)
+		VALUES (?, 1)
+			ON DUPLICATE KEY UPDATE generation = generation + 1;`
+)
+
+const (
+	queryFmtUpsertSessionData = `
+		REPLACE INTO %s (session_id, data, expires_at)
+		VALUES (?, ?, ?);`
+
+	queryFmtPostgresUpsertSessionData = `
+		INSERT INTO %s (session_id, data, expires_at)
+		VALUES ($1, $2, $3)
+			ON CONFLICT (session_id)
+			DO UPDATE SET data = $2, expires_at = $3;`
+
+	queryFmtSelectSessionData = `
+		SELECT data
+		FROM %s
+		WHERE session_id = ? AND (expires_at IS NULL OR expires_at > ?);`
+
+	queryFmtUpdateSessionDataID = `
+		UPDATE %s
+		SET session_id = ?, expires_at = ?
+		WHERE session_id = ?;`
+
+	queryFmtDeleteSessionData = `
+		DELETE FROM %s
+		WHERE session_id = ?;`
+
+	queryFmtDeleteExpiredSessionData = `
+		DELETE FROM %s
+		WHERE expires_at IS NOT NULL AND expires_at <= ?;`
+
+	queryFmtDeleteAllSessionData = `
+		DELETE FROM %s;`
+
+	queryFmtSelectSessionDataCount = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE expires_at IS NULL OR expires_at > ?;`
+)
+
+const (
+	queryFmtUpsertSessionInventoryRecord = `
+		REPLACE INTO %s (record_id, session_id, username, remote_ip, user_agent, created_at)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtPostgresUpsertSessionInventoryRecord = `
+		INSERT INTO %s (record_id, session_id, username, remote_ip, user_agent, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (session_id)
+			DO UPDATE SET record_id = $1, username = $3, remote_ip = $4, user_agent = $5, created_at = $6;`
+
+	queryFmtSelectSessionInventoryRecords = `
+		SELECT id, record_id, session_id, username, remote_ip, user_agent, created_at
+		FROM %s
+		WHERE username = ?
+		ORDER BY created_at, id;`
+
+	queryFmtDeleteSessionInventoryRecord = `
+		DELETE FROM %s
+		WHERE username = ? AND session_id = ?;`
+
+	queryFmtDeleteOrphanedSessionInventoryRecords = `
+		DELETE FROM %s
+		WHERE session_id NOT IN (SELECT session_id FROM %s);`
+)
+
 const (
 	queryFmtInsertAuthenticationLogEntry = `
-		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, remote_ip_bytes, country, asn, request_uri, request_method)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
 		SELECT time, successful, username
@@ -178,6 +278,76 @@ const (
 		ORDER BY time DESC
 		LIMIT ?
 		OFFSET ?;`
+
+	queryFmtSelectAuthenticationLogEntriesByUsername = `
+		SELECT id, time, successful, banned, username, auth_type, remote_ip, country, asn, request_uri, request_method
+		FROM %s
+		WHERE time > ? AND username = ?
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectAuthenticationLogEntryByUsernameAndType = `
+		SELECT time, successful, username
+		FROM %s
+		WHERE time > ? AND username = ? AND auth_type = ? AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelect1FAFailedAuthenticationLogEntryByRemoteIP = `
+		SELECT time, successful, username, remote_ip
+		FROM %s
+		WHERE time > ? AND remote_ip = ? AND auth_type = '1FA' AND banned = FALSE AND successful = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelect1FAFailedAuthenticationLogEntryBySubnet = `
+		SELECT time, successful, username, remote_ip
+		FROM %s
+		WHERE time > ? AND remote_ip_bytes BETWEEN ? AND ? AND auth_type = '1FA' AND banned = FALSE AND successful = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelect1FAFailedAuthenticationLogEntry = `
+		SELECT time, successful, username, remote_ip
+		FROM %s
+		WHERE time > ? AND auth_type = '1FA' AND banned = FALSE AND successful = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectSuccessfulAuthenticationLogEntryCountByRemoteIP = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE time > ? AND username = ? AND auth_type = ? AND remote_ip = ? AND successful = TRUE;`
+)
+
+const (
+	queryFmtInsertRegulationBan = `
+		INSERT INTO %s (time, expires, ban_type, ban_value)
+		VALUES (?, ?, ?, ?);`
+
+	queryFmtSelectRegulationBans = `
+		SELECT id, time, expires, revoked, revoked_at, ban_type, ban_value
+		FROM %s
+		WHERE ban_type = ? AND ban_value = ? AND (time > ? OR expires > ?)
+		ORDER BY time DESC;`
+
+	queryFmtSelectActiveRegulationBans = `
+		SELECT id, time, expires, revoked, revoked_at, ban_type, ban_value
+		FROM %s
+		WHERE revoked = FALSE AND expires > ?
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtRevokeRegulationBans = `
+		UPDATE %s
+		SET revoked = TRUE, revoked_at = ?
+		WHERE ban_type = ? AND ban_value = ? AND revoked = FALSE AND expires > ?;`
 )
 
 const (
@@ -12,6 +12,57 @@ const (
 		RENAME %s;`
 )
 
+// Export and import constants.
+const (
+	queryFmtSelectExportRows = `
+		SELECT id, %s
+		FROM %s
+		WHERE id > ?
+		ORDER BY id ASC
+		LIMIT ?;`
+
+	queryFmtSelectRowCount = `
+		SELECT COUNT(id)
+		FROM %s;`
+
+	queryFmtInsertImportRow = `
+		INSERT INTO %s (%s)
+		VALUES (%s);`
+)
+
+// Encryption constants.
+const (
+	queryFmtSelectEncryptedValues = `
+		SELECT id, %s AS value
+		FROM %s
+		WHERE id > ?
+		ORDER BY id ASC
+		LIMIT ?;`
+
+	queryFmtUpdateEncryptedValue = `
+		UPDATE %[1]s
+		SET %[2]s = ?
+		WHERE id = ? AND %[2]s = ?;`
+)
+
+// Prune constants.
+const (
+	queryFmtSelectPruneIDs = `
+		SELECT id
+		FROM %s
+		WHERE %s < ?
+		LIMIT ?;`
+
+	queryFmtSelectPruneCount = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE %s < ?;`
+
+	queryFmtDeletePruneIDs = `
+		DELETE FROM %s
+		WHERE id IN (?);`
+)
+
 // Pre1 migration constants.
 const (
 	queryFmtPre1To1SelectAuthenticationLogs = `
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// configureSQL applies the pool and query timeout options to the database, and opens the read only replicas with the
+// given data source names using the same options.
+func (p *SQLProvider) configureSQL(config schema.SQLStorageConfiguration, replicaDataSourceNames []string) {
+	p.queryTimeout = config.QueryTimeout
+
+	configureSQLPool(p.db, config.Pool)
+
+	for _, dataSourceName := range replicaDataSourceNames {
+		db, err := sqlx.Open(p.driverName, dataSourceName)
+		if err != nil {
+			p.errOpen = fmt.Errorf("error opening replica: %w", err)
+
+			return
+		}
+
+		configureSQLPool(db, config.Pool)
+
+		p.replicas = append(p.replicas, db)
+	}
+}
+
+func configureSQLPool(db *sqlx.DB, config schema.SQLStoragePoolConfiguration) {
+	if db == nil {
+		return
+	}
+
+	if config.MaxOpen > 0 {
+		db.SetMaxOpenConns(config.MaxOpen)
+	}
+
+	// A max idle of 0 disables the idle connections instead of using the default.
+	if config.MaxIdle > 0 {
+		db.SetMaxIdleConns(config.MaxIdle)
+	}
+
+	if config.MaxLifetime > 0 {
+		db.SetConnMaxLifetime(config.MaxLifetime)
+	}
+
+	if config.MaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(config.MaxIdleTime)
+	}
+}
+
+// queryContext returns a context which is cancelled after the query timeout, or the given context when there is no
+// query timeout.
+func (p *SQLProvider) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if p.queryTimeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, p.queryTimeout)
+}
+
+// read runs a read only query against the next replica using round robin. The query is run against the database when
+// there are no replicas, when the replica fails, or when the replica has no rows as it may lag behind the database.
+func (p *SQLProvider) read(ctx context.Context, query func(db *sqlx.DB) error) (err error) {
+	if len(p.replicas) == 0 {
+		return query(p.db)
+	}
+
+	n := atomic.AddUint32(&p.replicaNext, 1)
+
+	if err = query(p.replicas[int(n)%len(p.replicas)]); err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		if ctx.Err() != nil {
+			return err
+		}
+
+		p.log.Warnf("Error occurred running a query against a storage replica, the query is being run against the primary instead: %+v", err)
+	}
+
+	return query(p.db)
+}
+
+func (p *SQLProvider) pingReplicas(ctx context.Context) (err error) {
+	for i, db := range p.replicas {
+		if err = db.PingContext(ctx); err != nil {
+			return fmt.Errorf("error pinging replica %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) closeReplicas() (err error) {
+	for _, db := range p.replicas {
+		if e := db.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/models"
+)
+
+func TestShouldReadFromReplicasAndFallbackToPrimary(t *testing.T) {
+	ctx := context.Background()
+
+	primary := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+	replica := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	require.NoError(t, primary.SaveTOTPConfiguration(ctx, models.TOTPConfiguration{Username: "john", Period: 30, Digits: 6, Algorithm: "SHA1", Secret: []byte("primary")}))
+	require.NoError(t, primary.SaveTOTPConfiguration(ctx, models.TOTPConfiguration{Username: "harry", Period: 30, Digits: 6, Algorithm: "SHA1", Secret: []byte("primary")}))
+	require.NoError(t, replica.SaveTOTPConfiguration(ctx, models.TOTPConfiguration{Username: "john", Period: 30, Digits: 6, Algorithm: "SHA1", Secret: []byte("replica")}))
+
+	primary.configureSQL(schema.SQLStorageConfiguration{
+		QueryTimeout: time.Minute,
+		Pool:         schema.SQLStoragePoolConfiguration{MaxOpen: 5, MaxIdle: 2, MaxLifetime: time.Hour},
+	}, []string{replica.config.Storage.Local.Path, filepath.Join(t.TempDir(), "empty.sqlite3")})
+
+	require.NoError(t, primary.errOpen)
+	require.Len(t, primary.replicas, 2)
+	assert.Equal(t, 5, primary.db.Stats().MaxOpenConnections)
+	assert.Equal(t, 5, primary.replicas[0].Stats().MaxOpenConnections)
+
+	// The replicas are used in turn starting with the first.
+	config, err := primary.LoadTOTPConfiguration(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("primary"), config.Secret)
+
+	config, err = primary.LoadTOTPConfiguration(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("replica"), config.Secret)
+
+	// The query is run against the primary when the replica has no rows.
+	config, err = primary.LoadTOTPConfiguration(ctx, "harry")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("primary"), config.Secret)
+
+	// The query is run against the primary when the replica fails as it doesn't have the schema.
+	config, err = primary.LoadTOTPConfiguration(ctx, "harry")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("primary"), config.Secret)
+
+	_, err = primary.LoadTOTPConfiguration(ctx, "bob")
+	assert.Equal(t, ErrNoTOTPConfiguration, err)
+
+	// The authentication logs of the regulation are only read from the primary.
+	require.NoError(t, replica.AppendAuthenticationLog(ctx, models.AuthenticationAttempt{Username: "john", Type: "1FA", Time: time.Now()}))
+
+	for i := 0; i < 2; i++ {
+		attempts, err := primary.LoadAuthenticationLogs(ctx, "john", time.Now().Add(-time.Hour), 10, 0)
+		require.NoError(t, err)
+		assert.Len(t, attempts, 0)
+	}
+
+	queryCtx, cancel := primary.queryContext(ctx)
+	defer cancel()
+
+	deadline, ok := queryCtx.Deadline()
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+}
+
+func TestShouldBuildReplicaDataSourceNames(t *testing.T) {
+	postgres := schema.PostgreSQLStorageConfiguration{
+		SQLStorageConfiguration: schema.SQLStorageConfiguration{
+			Host: "primary", Port: 5432, Database: "authelia", Username: "authelia", Password: "secret", Timeout: 5 * time.Second,
+			Replicas: []schema.SQLStorageReplicaConfiguration{{Host: "replica1"}, {Host: "replica2", Port: 5433}},
+		},
+		Schema: "public",
+		SSL:    schema.PostgreSQLSSLStorageConfiguration{Mode: "disable"},
+	}
+
+	assert.Equal(t, []string{
+		"host=replica1 user='authelia' password='secret' dbname=authelia search_path=public sslmode=disable port=5432 connect_timeout=5",
+		"host=replica2 user='authelia' password='secret' dbname=authelia search_path=public sslmode=disable port=5433 connect_timeout=5",
+	}, dataSourceNamesPostgreSQLReplicas(postgres))
+
+	mysql := schema.MySQLStorageConfiguration{
+		SQLStorageConfiguration: postgres.SQLStorageConfiguration,
+	}
+
+	mysql.Port = 3306
+
+	assert.Equal(t, []string{
+		"authelia:secret@tcp(replica1:3306)/authelia?timeout=5s&multiStatements=true&parseTime=true",
+		"authelia:secret@tcp(replica2:5433)/authelia?timeout=5s&multiStatements=true&parseTime=true",
+	}, dataSourceNamesMySQLReplicas(mysql))
+}
@@ -234,7 +234,7 @@ func (p *SQLProvider) schemaMigrateApply(ctx context.Context, migration models.S
 		}
 
 		// Add the schema encryption value if upgrading to v1.
-		if err = p.setNewEncryptionCheckValue(ctx, &p.key, nil); err != nil {
+		if err = p.setNewEncryptionCheckValue(ctx, p.keys.primary, nil); err != nil {
 			return err
 		}
 	}
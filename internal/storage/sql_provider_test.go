@@ -0,0 +1,171 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/models"
+)
+
+func TestShouldDeleteU2FDevice(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	require.NoError(t, provider.SaveU2FDevice(ctx, models.U2FDevice{Username: "john", Description: "Primary", KeyHandle: []byte("handle"), PublicKey: []byte("public key")}))
+	require.NoError(t, provider.SaveU2FDevice(ctx, models.U2FDevice{Username: "harry", Description: "Primary", KeyHandle: []byte("handle"), PublicKey: []byte("public key")}))
+
+	device, err := provider.LoadU2FDevice(ctx, "john")
+	require.NoError(t, err)
+	assert.Equal(t, "Primary", device.Description)
+
+	require.NoError(t, provider.DeleteU2FDevice(ctx, "john"))
+
+	_, err = provider.LoadU2FDevice(ctx, "john")
+	assert.Equal(t, ErrNoU2FDeviceHandle, err)
+
+	_, err = provider.LoadU2FDevice(ctx, "harry")
+	assert.NoError(t, err)
+}
+
+func TestShouldLoadAuthenticationLogsHistory(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, attempt := range []models.AuthenticationAttempt{
+		{Username: "john", Type: "1FA", Successful: false},
+		{Username: "john", Type: "1FA", Successful: false, Banned: true},
+		{Username: "harry", Type: "1FA", Successful: true},
+		{Username: "john", Type: "TOTP", Successful: true, Country: "US", ASN: 64496},
+	} {
+		attempt.Time = now.Add(time.Duration(i) * time.Minute)
+		attempt.RemoteIP = models.NewNullIPFromString("192.168.0.10")
+
+		require.NoError(t, provider.AppendAuthenticationLog(ctx, attempt))
+	}
+
+	attempts, err := provider.LoadAuthenticationLogsHistory(ctx, "john", now.Add(-time.Hour), 2, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+
+	assert.Equal(t, "TOTP", attempts[0].Type)
+	assert.Equal(t, "US", attempts[0].Country)
+	assert.Equal(t, uint(64496), attempts[0].ASN)
+	assert.Equal(t, "192.168.0.10", attempts[0].RemoteIP.IP.String())
+	assert.True(t, attempts[1].Banned)
+
+	attempts, err = provider.LoadAuthenticationLogsHistory(ctx, "john", now.Add(-time.Hour), 2, 1)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.False(t, attempts[0].Banned)
+
+	attempts, err = provider.LoadAuthenticationLogsHistory(ctx, "john", now.Add(time.Minute), 10, 0)
+	require.NoError(t, err)
+	assert.Len(t, attempts, 1)
+}
+
+func TestShouldLoadFailedAuthenticationLogsBySubnet(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, attempt := range []models.AuthenticationAttempt{
+		{Username: "john", RemoteIP: models.NewNullIPFromString("192.0.2.1")},
+		{Username: "harry", RemoteIP: models.NewNullIPFromString("192.0.2.255")},
+		{Username: "bob", RemoteIP: models.NewNullIPFromString("192.0.3.1")},
+		{Username: "james", RemoteIP: models.NewNullIPFromString("2001:db8:0:1::10")},
+		{Username: "fred", RemoteIP: models.NewNullIPFromString("2001:db8:0:2::10")},
+		{Username: "alice", RemoteIP: models.NewNullIPFromString("192.0.2.2"), Successful: true},
+		{Username: "alice"},
+	} {
+		attempt.Type = "1FA"
+		attempt.Time = now.Add(time.Duration(i) * time.Minute)
+
+		require.NoError(t, provider.AppendAuthenticationLog(ctx, attempt))
+	}
+
+	_, subnet, err := net.ParseCIDR("192.0.2.0/24")
+	require.NoError(t, err)
+
+	attempts, err := provider.LoadFailedAuthenticationLogsBySubnet(ctx, subnet, now.Add(-time.Hour), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+	assert.Equal(t, "harry", attempts[0].Username)
+	assert.Equal(t, "john", attempts[1].Username)
+
+	attempts, err = provider.LoadFailedAuthenticationLogsBySubnet(ctx, subnet, now.Add(-time.Hour), 1, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, "harry", attempts[0].Username)
+
+	_, subnet, err = net.ParseCIDR("2001:db8:0:1::/64")
+	require.NoError(t, err)
+
+	attempts, err = provider.LoadFailedAuthenticationLogsBySubnet(ctx, subnet, now.Add(-time.Hour), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, "james", attempts[0].Username)
+}
+
+func TestShouldSaveAndDeleteSessionInventoryRecords(t *testing.T) {
+	ctx := context.Background()
+	provider := newTestSQLiteProvider(t, "an-encryption-key-which-is-long-enough")
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	for i, record := range []models.SessionInventoryRecord{
+		{RecordID: "a", SessionID: "session-a", Username: "john"},
+		{RecordID: "b", SessionID: "session-b", Username: "john"},
+		{RecordID: "c", SessionID: "session-c", Username: "harry"},
+	} {
+		record.RemoteIP = "192.168.0.10"
+		record.CreatedAt = now.Add(time.Duration(i) * time.Minute)
+
+		require.NoError(t, provider.SaveSessionInventoryRecord(ctx, record))
+		require.NoError(t, provider.SaveSessionData(ctx, record.SessionID, []byte("data"), nil))
+	}
+
+	// Saving the record of a session again replaces it.
+	require.NoError(t, provider.SaveSessionInventoryRecord(ctx, models.SessionInventoryRecord{
+		RecordID: "a", SessionID: "session-a", Username: "john", RemoteIP: "192.168.0.20", UserAgent: "curl/7.80.0", CreatedAt: now,
+	}))
+
+	records, err := provider.LoadSessionInventoryRecords(ctx, "john")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, "a", records[0].RecordID)
+	assert.Equal(t, "192.168.0.20", records[0].RemoteIP)
+	assert.Equal(t, "curl/7.80.0", records[0].UserAgent)
+	assert.Equal(t, now, records[0].CreatedAt.UTC())
+	assert.Equal(t, "b", records[1].RecordID)
+
+	require.NoError(t, provider.DeleteSessionInventoryRecord(ctx, "harry", "session-a"))
+	require.NoError(t, provider.DeleteSessionInventoryRecord(ctx, "john", "session-b"))
+
+	records, err = provider.LoadSessionInventoryRecords(ctx, "john")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "a", records[0].RecordID)
+
+	// The records of the sessions which no longer exist are deleted along with the expired sessions.
+	require.NoError(t, provider.DeleteSessionData(ctx, "session-a"))
+
+	_, err = provider.DeleteExpiredSessionData(ctx)
+	require.NoError(t, err)
+
+	records, err = provider.LoadSessionInventoryRecords(ctx, "john")
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	records, err = provider.LoadSessionInventoryRecords(ctx, "harry")
+	require.NoError(t, err)
+	assert.Len(t, records, 1)
+}
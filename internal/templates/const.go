@@ -0,0 +1,21 @@
+package templates
+
+const (
+	// EmailIdentityVerification is the name of the email template sent to users to verify their identity.
+	EmailIdentityVerification = "IdentityVerification"
+
+	// EmailSecurityEvent is the name of the email template sent to users when a security relevant change is made to
+	// their account.
+	EmailSecurityEvent = "SecurityEvent"
+)
+
+const (
+	extensionHTML = ".html"
+	extensionText = ".txt"
+
+	// templateNameTitle is the name of the optional template defined in the plain text template which overrides the
+	// title of the email.
+	templateNameTitle = "title"
+)
+
+var emailTemplateNames = []string{EmailIdentityVerification, EmailSecurityEvent}
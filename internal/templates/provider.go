@@ -0,0 +1,210 @@
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	th "html/template"
+	"os"
+	"path/filepath"
+	tt "text/template"
+
+	"golang.org/x/text/language"
+)
+
+//go:embed src/*
+var embeddedFS embed.FS
+
+// NewProvider creates a Provider using the embedded templates which are overridden by the templates in the path if
+// it's not empty, the locale is the locale of these templates. Each sub-directory of the path named after a locale can
+// contain a localized variant of any template, templates missing from a locale directory fall back to the templates in
+// the path and then to the embedded templates.
+func NewProvider(path, locale string) (provider *Provider, err error) {
+	defaultTag, err := language.Parse(locale)
+	if err != nil {
+		return nil, fmt.Errorf("error occurred loading the email templates: '%s' is not a valid locale: %w", locale, err)
+	}
+
+	provider = &Provider{
+		emails:  map[string]map[string]*emailTemplate{},
+		locales: []string{""},
+	}
+
+	if provider.emails[""], err = loadEmailTemplates(path, nil); err != nil {
+		return nil, err
+	}
+
+	tags := []language.Tag{defaultTag}
+
+	if path != "" {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("error occurred loading the email templates from '%s': %w", path, err)
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			var tag language.Tag
+
+			if tag, err = language.Parse(entry.Name()); err != nil {
+				return nil, fmt.Errorf("error occurred loading the email templates: directory '%s' is not a valid locale: %w", filepath.Join(path, entry.Name()), err)
+			}
+
+			if provider.emails[entry.Name()], err = loadEmailTemplates(filepath.Join(path, entry.Name()), provider.emails[""]); err != nil {
+				return nil, err
+			}
+
+			tags = append(tags, tag)
+			provider.locales = append(provider.locales, entry.Name())
+		}
+	}
+
+	provider.matcher = language.NewMatcher(tags)
+
+	return provider, nil
+}
+
+// ExecuteIdentityVerification renders the IdentityVerification email. The preferences are either locales or
+// Accept-Language header values in order of priority, the HTML body is only rendered if html is true.
+func (p *Provider) ExecuteIdentityVerification(values EmailIdentityVerificationValues, html bool, preferences ...string) (email *Email, err error) {
+	return p.executeEmail(EmailIdentityVerification, values.Title, values, html, preferences)
+}
+
+// ExecuteSecurityEvent renders the SecurityEvent email. The preferences are either locales or Accept-Language header
+// values in order of priority, the HTML body is only rendered if html is true.
+func (p *Provider) ExecuteSecurityEvent(values EmailSecurityEventValues, html bool, preferences ...string) (email *Email, err error) {
+	return p.executeEmail(EmailSecurityEvent, values.Title, values, html, preferences)
+}
+
+// Locale returns the locale of the templates which best matches the preferences, or an empty string if the default
+// templates are the best match.
+func (p *Provider) Locale(preferences ...string) string {
+	if len(p.locales) == 1 {
+		return ""
+	}
+
+	var tags []language.Tag
+
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+
+		parsed, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil {
+			continue
+		}
+
+		tags = append(tags, parsed...)
+	}
+
+	if len(tags) == 0 {
+		return ""
+	}
+
+	_, index, confidence := p.matcher.Match(tags...)
+	if confidence == language.No {
+		return ""
+	}
+
+	return p.locales[index]
+}
+
+func (p *Provider) executeEmail(name, title string, values interface{}, html bool, preferences []string) (email *Email, err error) {
+	tmpl, ok := p.emails[p.Locale(preferences...)][name]
+	if !ok {
+		return nil, fmt.Errorf("email template '%s' does not exist", name)
+	}
+
+	email = &Email{Title: title}
+
+	buf := new(bytes.Buffer)
+
+	if t := tmpl.text.Lookup(templateNameTitle); t != nil {
+		if err = t.Execute(buf, values); err != nil {
+			return nil, fmt.Errorf("error occurred rendering the title of the email template '%s': %w", name, err)
+		}
+
+		email.Title = buf.String()
+
+		buf.Reset()
+	}
+
+	if err = tmpl.text.Execute(buf, values); err != nil {
+		return nil, fmt.Errorf("error occurred rendering the email template '%s': %w", name+extensionText, err)
+	}
+
+	email.Text = buf.String()
+
+	if html {
+		buf.Reset()
+
+		if err = tmpl.html.Execute(buf, values); err != nil {
+			return nil, fmt.Errorf("error occurred rendering the email template '%s': %w", name+extensionHTML, err)
+		}
+
+		email.HTML = buf.String()
+	}
+
+	return email, nil
+}
+
+// loadEmailTemplates loads all of the email templates from the path, any template which doesn't exist in the path is
+// taken from the fallback templates or the embedded templates if the fallback is nil.
+func loadEmailTemplates(path string, fallback map[string]*emailTemplate) (emails map[string]*emailTemplate, err error) {
+	emails = make(map[string]*emailTemplate, len(emailTemplateNames))
+
+	for _, name := range emailTemplateNames {
+		tmpl := &emailTemplate{}
+
+		if fallback != nil {
+			tmpl.text, tmpl.html = fallback[name].text, fallback[name].html
+		}
+
+		var data []byte
+
+		if data, err = readTemplate(path, name+extensionText, fallback == nil); err != nil {
+			return nil, err
+		} else if data != nil {
+			if tmpl.text, err = tt.New(name).Parse(string(data)); err != nil {
+				return nil, fmt.Errorf("error occurred parsing the email template '%s': %w", filepath.Join(path, name+extensionText), err)
+			}
+		}
+
+		if data, err = readTemplate(path, name+extensionHTML, fallback == nil); err != nil {
+			return nil, err
+		} else if data != nil {
+			if tmpl.html, err = th.New(name).Parse(string(data)); err != nil {
+				return nil, fmt.Errorf("error occurred parsing the email template '%s': %w", filepath.Join(path, name+extensionHTML), err)
+			}
+		}
+
+		emails[name] = tmpl
+	}
+
+	return emails, nil
+}
+
+// readTemplate reads the template file from the path, returning nil if it doesn't exist. If embedded is true the
+// embedded template is returned when the path is empty or the file doesn't exist.
+func readTemplate(path, fileName string, embedded bool) (data []byte, err error) {
+	if path != "" {
+		data, err = os.ReadFile(filepath.Join(path, fileName))
+
+		switch {
+		case err == nil:
+			return data, nil
+		case !os.IsNotExist(err):
+			return nil, fmt.Errorf("error occurred reading the email template '%s': %w", filepath.Join(path, fileName), err)
+		}
+	}
+
+	if !embedded {
+		return nil, nil
+	}
+
+	return embeddedFS.ReadFile("src/" + fileName)
+}
@@ -0,0 +1,139 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestValues() EmailIdentityVerificationValues {
+	return EmailIdentityVerificationValues{
+		Title:       "Reset your password",
+		DisplayName: "John Doe",
+		Username:    "john",
+		LinkURL:     "https://login.example.com/reset-password/step2?token=abc",
+		LinkText:    "Reset",
+		RemoteIP:    "192.168.1.10",
+	}
+}
+
+func writeTestTemplate(t *testing.T, path, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+}
+
+func TestShouldRenderEmbeddedTemplates(t *testing.T) {
+	provider, err := NewProvider("", "en")
+	require.NoError(t, err)
+
+	email, err := provider.ExecuteIdentityVerification(newTestValues(), true, "de-DE,de;q=0.9")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Reset your password", email.Title)
+	assert.Contains(t, email.Text, "https://login.example.com/reset-password/step2?token=abc")
+	assert.Contains(t, email.HTML, "<h1>Reset your password</h1>")
+	assert.Contains(t, email.HTML, `<a href="https://login.example.com/reset-password/step2?token=abc" class="button">Reset</a>`)
+
+	email, err = provider.ExecuteIdentityVerification(newTestValues(), false)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", email.HTML)
+}
+
+func TestShouldRenderOverriddenAndLocalizedTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestTemplate(t, filepath.Join(dir, "IdentityVerification.txt"), "Hello {{ .DisplayName }}, visit {{ .LinkURL }}")
+	writeTestTemplate(t, filepath.Join(dir, "de", "IdentityVerification.txt"),
+		`{{ define "title" }}Passwort zurücksetzen{{ end }}Hallo {{ .DisplayName }}, besuche {{ .LinkURL }}`)
+	writeTestTemplate(t, filepath.Join(dir, "de", "IdentityVerification.html"), "<p>Hallo {{ .DisplayName }}</p>")
+
+	provider, err := NewProvider(dir, "en")
+	require.NoError(t, err)
+
+	email, err := provider.ExecuteIdentityVerification(newTestValues(), true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Reset your password", email.Title)
+	assert.Equal(t, "Hello John Doe, visit https://login.example.com/reset-password/step2?token=abc", email.Text)
+	assert.Contains(t, email.HTML, "<h1>Reset your password</h1>")
+
+	email, err = provider.ExecuteIdentityVerification(newTestValues(), true, "", "fr-CH,de-AT;q=0.8,en;q=0.5")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Passwort zurücksetzen", email.Title)
+	assert.Equal(t, "Hallo John Doe, besuche https://login.example.com/reset-password/step2?token=abc", email.Text)
+	assert.Equal(t, "<p>Hallo John Doe</p>", email.HTML)
+
+	// The locale of the user takes precedence over the browser language.
+	assert.Equal(t, "", provider.Locale("en", "de"))
+	assert.Equal(t, "de", provider.Locale("de-DE", "en"))
+	assert.Equal(t, "", provider.Locale("fr"))
+	assert.Equal(t, "", provider.Locale("en-US,en;q=0.9,de;q=0.8"))
+}
+
+func TestShouldEscapeHTMLTemplateValues(t *testing.T) {
+	provider, err := NewProvider("", "en")
+	require.NoError(t, err)
+
+	values := newTestValues()
+	values.Title = "<script>alert(1)</script>"
+
+	email, err := provider.ExecuteIdentityVerification(values, true)
+	require.NoError(t, err)
+
+	assert.NotContains(t, email.HTML, "<script>")
+	assert.Contains(t, email.HTML, "&lt;script&gt;alert(1)&lt;/script&gt;")
+}
+
+func TestShouldRenderSecurityEventTemplate(t *testing.T) {
+	provider, err := NewProvider("", "en")
+	require.NoError(t, err)
+
+	values := EmailSecurityEventValues{
+		Title:       "New sign in to your account",
+		Event:       "new_login",
+		Description: "Your account was signed in to from a new IP address or device.",
+		Username:    "john",
+		RemoteIP:    "192.168.1.10",
+		Time:        time.Date(2021, 12, 1, 10, 30, 0, 0, time.UTC),
+	}
+
+	email, err := provider.ExecuteSecurityEvent(values, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, "New sign in to your account", email.Title)
+	assert.Contains(t, email.Text, "Hi john,")
+	assert.Contains(t, email.Text, "Time: 2021-12-01 10:30:00 UTC\nIP Address: 192.168.1.10\n\n")
+	assert.NotContains(t, email.Text, "Browser:")
+	assert.Contains(t, email.HTML, "<h1>New sign in to your account</h1>")
+	assert.Contains(t, email.HTML, "<br />IP Address: 192.168.1.10")
+}
+
+func TestShouldFailToLoadInvalidTemplates(t *testing.T) {
+	dir := t.TempDir()
+
+	writeTestTemplate(t, filepath.Join(dir, "IdentityVerification.html"), "<p>{{ .DisplayName </p>")
+
+	_, err := NewProvider("", "not_a_locale!")
+	assert.EqualError(t, err, "error occurred loading the email templates: 'not_a_locale!' is not a valid locale: language: tag is not well-formed")
+
+	_, err = NewProvider(dir, "en")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error occurred parsing the email template '"+filepath.Join(dir, "IdentityVerification.html")+"': ")
+
+	dir = t.TempDir()
+
+	writeTestTemplate(t, filepath.Join(dir, "not_a_locale!", "IdentityVerification.txt"), "Hello")
+
+	_, err = NewProvider(dir, "en")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "directory '"+filepath.Join(dir, "not_a_locale!")+"' is not a valid locale")
+
+	_, err = NewProvider(filepath.Join(dir, "missing"), "en")
+	assert.EqualError(t, err, "error occurred loading the email templates from '"+filepath.Join(dir, "missing")+"': open "+filepath.Join(dir, "missing")+": no such file or directory")
+}
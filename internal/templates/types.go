@@ -0,0 +1,85 @@
+package templates
+
+import (
+	th "html/template"
+	tt "text/template"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Provider renders the email templates. The embedded templates can be overridden and localized from a directory.
+type Provider struct {
+	// emails holds the templates by locale and then by name, the empty locale holds the default templates.
+	emails map[string]map[string]*emailTemplate
+
+	locales []string
+	matcher language.Matcher
+}
+
+// Email represents a rendered email.
+type Email struct {
+	Title string
+	Text  string
+	HTML  string
+}
+
+// EmailIdentityVerificationValues are the values available to the IdentityVerification email template.
+type EmailIdentityVerificationValues struct {
+	// Title is the default title of the email which describes the action being verified.
+	Title string
+
+	// DisplayName is the display name of the user.
+	DisplayName string
+
+	// Username is the username of the user.
+	Username string
+
+	// LinkURL is the URL the user has to visit to complete the identity verification.
+	LinkURL string
+
+	// LinkText is the text of the button linking to the LinkURL in the HTML email.
+	LinkText string
+
+	// RemoteIP is the IP address of the client which initiated the identity verification.
+	RemoteIP string
+}
+
+// EmailSecurityEventValues are the values available to the SecurityEvent email template.
+type EmailSecurityEventValues struct {
+	// Title is the default title of the email which describes the event.
+	Title string
+
+	// Event is the identifier of the event, one of password_changed, device_registered, device_removed, new_login or
+	// account_banned.
+	Event string
+
+	// Description is the default description of the event.
+	Description string
+
+	// DisplayName is the display name of the user.
+	DisplayName string
+
+	// Username is the username of the user.
+	Username string
+
+	// Device is the type of the second factor device for the device_registered and device_removed events.
+	Device string
+
+	// RemoteIP is the IP address of the client which caused the event if any.
+	RemoteIP string
+
+	// UserAgent is the user agent of the client which caused the event if any.
+	UserAgent string
+
+	// Time is when the event happened.
+	Time time.Time
+
+	// BannedUntil is when the ban ends for the account_banned event.
+	BannedUntil time.Time
+}
+
+type emailTemplate struct {
+	text *tt.Template
+	html *th.Template
+}
@@ -0,0 +1,7 @@
+package tracing
+
+// tracerName is the name of the tracer which starts the spans of Authelia.
+const tracerName = "github.com/authelia/authelia/v4"
+
+// userValueContext is the user value of the requests which holds the context of the span of the request.
+const userValueContext = "authelia_tracing_context"
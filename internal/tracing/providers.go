@@ -0,0 +1,66 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/notification"
+)
+
+// NewUserProvider returns a user provider which traces the calls to the provider with spans which are children of the
+// span of the context. The user providers don't take a context, so the decorator is bound to the context of a request.
+func NewUserProvider(ctx context.Context, provider authentication.UserProvider) authentication.UserProvider {
+	return &tracedUserProvider{ctx: ctx, provider: provider}
+}
+
+type tracedUserProvider struct {
+	ctx      context.Context
+	provider authentication.UserProvider
+}
+
+func (p *tracedUserProvider) StartupCheck() (err error) {
+	return p.provider.StartupCheck()
+}
+
+func (p *tracedUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	_, span := Start(p.ctx, "user.CheckUserPassword")
+	defer func() { End(span, err) }()
+
+	return p.provider.CheckUserPassword(username, password)
+}
+
+func (p *tracedUserProvider) GetDetails(username string) (details *authentication.UserDetails, err error) {
+	_, span := Start(p.ctx, "user.GetDetails")
+	defer func() { End(span, err) }()
+
+	return p.provider.GetDetails(username)
+}
+
+func (p *tracedUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	_, span := Start(p.ctx, "user.UpdatePassword")
+	defer func() { End(span, err) }()
+
+	return p.provider.UpdatePassword(username, newPassword)
+}
+
+// NewNotifier returns a notifier which traces the notifications sent by the notifier with spans which are children of
+// the span of the context.
+func NewNotifier(ctx context.Context, notifier notification.Notifier) notification.Notifier {
+	return &tracedNotifier{ctx: ctx, notifier: notifier}
+}
+
+type tracedNotifier struct {
+	ctx      context.Context
+	notifier notification.Notifier
+}
+
+func (n *tracedNotifier) StartupCheck() (err error) {
+	return n.notifier.StartupCheck()
+}
+
+func (n *tracedNotifier) Send(recipient, subject, body, htmlBody string) (err error) {
+	_, span := Start(n.ctx, "notifier.Send")
+	defer func() { End(span, err) }()
+
+	return n.notifier.Send(recipient, subject, body, htmlBody)
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+)
+
+type testUserProvider struct{}
+
+func (p testUserProvider) StartupCheck() (err error) {
+	return nil
+}
+
+func (p testUserProvider) CheckUserPassword(username string, password string) (valid bool, err error) {
+	return password == "password", nil
+}
+
+func (p testUserProvider) GetDetails(username string) (details *authentication.UserDetails, err error) {
+	return nil, errors.New("user not found")
+}
+
+func (p testUserProvider) UpdatePassword(username string, newPassword string) (err error) {
+	return nil
+}
+
+type testNotifier struct{}
+
+func (n testNotifier) StartupCheck() (err error) {
+	return nil
+}
+
+func (n testNotifier) Send(recipient, subject, body, htmlBody string) (err error) {
+	return nil
+}
+
+func TestShouldTraceUserProviderAndNotifierCallsAsChildrenOfRequest(t *testing.T) {
+	exporter := newTestExporter()
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.SetRequestURI("/api/firstfactor")
+
+	span := StartRequest(ctx, "/api/firstfactor")
+
+	provider := NewUserProvider(RequestContext(ctx), testUserProvider{})
+
+	valid, err := provider.CheckUserPassword("john", "password")
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = provider.GetDetails("john")
+	assert.EqualError(t, err, "user not found")
+
+	require.NoError(t, NewNotifier(RequestContext(ctx), testNotifier{}).Send("john@example.com", "Subject", "Body", ""))
+
+	EndRequest(ctx, span)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 4)
+
+	assert.Equal(t, "user.CheckUserPassword", spans[0].Name)
+	assert.Equal(t, codes.Unset, spans[0].StatusCode)
+	assert.Equal(t, "user.GetDetails", spans[1].Name)
+	assert.Equal(t, codes.Error, spans[1].StatusCode)
+	assert.Equal(t, "notifier.Send", spans[2].Name)
+
+	for _, child := range spans[:3] {
+		assert.Equal(t, spans[3].SpanContext.SpanID(), child.Parent.SpanID())
+	}
+}
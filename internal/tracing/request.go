@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartRequest starts the span of a request as a child of the span of the W3C trace context sent by the proxy if any.
+// The context of the span is stored in the request so the spans started with RequestContext are its children.
+func StartRequest(ctx *fasthttp.RequestCtx, route string) trace.Span {
+	parent := otel.GetTextMapPropagator().Extract(context.Background(), requestHeaderCarrier{header: &ctx.Request.Header})
+
+	method := string(ctx.Method())
+
+	name := method
+	if route != "" {
+		name = method + " " + route
+	}
+
+	spanCtx, span := otel.Tracer(tracerName).Start(parent, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(method),
+			semconv.HTTPTargetKey.String(string(ctx.RequestURI())),
+			semconv.HTTPRouteKey.String(route),
+		))
+
+	ctx.SetUserValue(userValueContext, spanCtx)
+
+	return span
+}
+
+// EndRequest ends the span of a request with the status code of the response.
+func EndRequest(ctx *fasthttp.RequestCtx, span trace.Span) {
+	statusCode := ctx.Response.StatusCode()
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(statusCode))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(statusCode))
+	span.End()
+}
+
+// RequestContext returns the context of the span of the request, or an empty context when the request has no span.
+func RequestContext(ctx *fasthttp.RequestCtx) context.Context {
+	if spanCtx, ok := ctx.UserValue(userValueContext).(context.Context); ok {
+		return spanCtx
+	}
+
+	return context.Background()
+}
+
+// requestHeaderCarrier is a propagation.TextMapCarrier of the headers of a request.
+type requestHeaderCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+// Get returns the value of the header.
+func (c requestHeaderCarrier) Get(key string) string {
+	return string(c.header.Peek(key))
+}
+
+// Set sets the value of the header.
+func (c requestHeaderCarrier) Set(key, value string) {
+	c.header.Set(key, value)
+}
+
+// Keys returns the names of the headers.
+func (c requestHeaderCarrier) Keys() (keys []string) {
+	c.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+
+	return keys
+}
@@ -0,0 +1,108 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlphttp"
+	"go.opentelemetry.io/otel/exporters/stdout"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// InitializeTracing configures the global tracer provider which exports the spans with the configured exporter, and the
+// global propagator which propagates the W3C trace context. The spans are discarded until it's called.
+func InitializeTracing(config schema.TracingConfiguration) (err error) {
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.ServiceNameKey.String(config.ServiceName),
+			semconv.ServiceVersionKey.String(utils.Version()),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRatio))),
+	}
+
+	switch config.Exporter {
+	case schema.TracingExporterFile:
+		var exporter *stdout.Exporter
+
+		if exporter, err = newFileExporter(config.File); err != nil {
+			return err
+		}
+
+		// The spans are written as soon as they end so they can be read while testing.
+		options = append(options, sdktrace.WithSyncer(exporter))
+	default:
+		var exporter *otlp.Exporter
+
+		if exporter, err = otlp.NewExporter(context.Background(), newOTLPDriver(config.OTLP)); err != nil {
+			return fmt.Errorf("unable to create the OTLP exporter: %w", err)
+		}
+
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(options...))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return nil
+}
+
+func newOTLPDriver(config schema.TracingOTLPConfiguration) otlp.ProtocolDriver {
+	if config.Protocol == schema.TracingOTLPProtocolHTTP {
+		options := []otlphttp.Option{otlphttp.WithEndpoint(config.Endpoint)}
+
+		if config.Insecure {
+			options = append(options, otlphttp.WithInsecure())
+		}
+
+		return otlphttp.NewDriver(options...)
+	}
+
+	options := []otlpgrpc.Option{otlpgrpc.WithEndpoint(config.Endpoint)}
+
+	if config.Insecure {
+		options = append(options, otlpgrpc.WithInsecure())
+	}
+
+	return otlpgrpc.NewDriver(options...)
+}
+
+func newFileExporter(config schema.TracingFileConfiguration) (exporter *stdout.Exporter, err error) {
+	var writer io.Writer = os.Stdout
+
+	if config.Path != "" {
+		if writer, err = os.OpenFile(config.Path, os.O_APPEND|os.O_WRONLY|os.O_CREATE, 0600); err != nil {
+			return nil, fmt.Errorf("unable to open the tracing file: %w", err)
+		}
+	}
+
+	return stdout.NewExporter(stdout.WithWriter(writer), stdout.WithoutMetricExport())
+}
+
+// Start starts a span which is a child of the span of the context if it has one.
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, and records the error and sets the status of the span to error when the error isn't nil.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+const (
+	testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	testTraceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+)
+
+func newTestExporter() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	return exporter
+}
+
+func TestShouldStartRequestFromTraceParent(t *testing.T) {
+	exporter := newTestExporter()
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fasthttp.MethodPost)
+	ctx.Request.SetRequestURI("/api/firstfactor")
+	ctx.Request.Header.Set("traceparent", testTraceParent)
+
+	span := StartRequest(ctx, "/api/firstfactor")
+
+	_, child := Start(RequestContext(ctx), "storage.LoadUserInfo")
+	End(child, errors.New("failed"))
+
+	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
+	EndRequest(ctx, span)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "storage.LoadUserInfo", spans[0].Name)
+	assert.Equal(t, codes.Error, spans[0].StatusCode)
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+
+	assert.Equal(t, "POST /api/firstfactor", spans[1].Name)
+	assert.Equal(t, trace.SpanKindServer, spans[1].SpanKind)
+	assert.Equal(t, testTraceID, spans[1].SpanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[1].Parent.SpanID().String())
+	assert.True(t, spans[1].Parent.IsRemote())
+}
+
+func TestShouldStartRequestWithoutRoute(t *testing.T) {
+	exporter := newTestExporter()
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.SetMethod(fasthttp.MethodGet)
+	ctx.Request.SetRequestURI("/unknown")
+
+	EndRequest(ctx, StartRequest(ctx, ""))
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, fasthttp.MethodGet, spans[0].Name)
+	assert.False(t, spans[0].Parent.IsValid())
+}
+
+func TestShouldReturnBackgroundContextWithoutRequestSpan(t *testing.T) {
+	assert.Equal(t, context.Background(), RequestContext(&fasthttp.RequestCtx{}))
+}
+
+func TestShouldExportToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+
+	config := schema.DefaultTracingConfiguration
+	config.Exporter = schema.TracingExporterFile
+	config.File.Path = path
+
+	require.NoError(t, InitializeTracing(config))
+
+	ctx := &fasthttp.RequestCtx{}
+	ctx.Request.Header.Set("traceparent", testTraceParent)
+
+	EndRequest(ctx, StartRequest(ctx, "/api/state"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(data), testTraceID)
+	assert.Contains(t, string(data), "GET /api/state")
+}
+
+func TestShouldFailToOpenTracingFile(t *testing.T) {
+	config := schema.DefaultTracingConfiguration
+	config.Exporter = schema.TracingExporterFile
+	config.File.Path = filepath.Join(t.TempDir(), "missing", "traces.json")
+
+	err := InitializeTracing(config)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to open the tracing file: ")
+}
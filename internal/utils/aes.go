@@ -15,6 +15,12 @@ import (
 // the data and provides a check that it hasn't been altered. Output takes the
 // form nonce|ciphertext|tag where '|' indicates concatenation.
 func Encrypt(plaintext []byte, key *[32]byte) (ciphertext []byte, err error) {
+	return EncryptWithAdditionalData(plaintext, key, nil)
+}
+
+// EncryptWithAdditionalData encrypts data like Encrypt and authenticates the additional data along with it, so the
+// data can only be decrypted with the same additional data. The additional data isn't part of the output.
+func EncryptWithAdditionalData(plaintext []byte, key *[32]byte, additionalData []byte) (ciphertext []byte, err error) {
 	block, err := aes.NewCipher(key[:])
 	if err != nil {
 		return nil, err
@@ -32,13 +38,18 @@ func Encrypt(plaintext []byte, key *[32]byte) (ciphertext []byte, err error) {
 		return nil, err
 	}
 
-	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
 }
 
 // Decrypt decrypts data using 256-bit AES-GCM.  This both hides the content of
 // the data and provides a check that it hasn't been altered. Expects input
 // form nonce|ciphertext|tag where '|' indicates concatenation.
 func Decrypt(ciphertext []byte, key *[32]byte) (plaintext []byte, err error) {
+	return DecryptWithAdditionalData(ciphertext, key, nil)
+}
+
+// DecryptWithAdditionalData decrypts data encrypted by EncryptWithAdditionalData with the same additional data.
+func DecryptWithAdditionalData(ciphertext []byte, key *[32]byte, additionalData []byte) (plaintext []byte, err error) {
 	block, err := aes.NewCipher(key[:])
 	if err != nil {
 		return nil, err
@@ -56,6 +67,6 @@ func Decrypt(ciphertext []byte, key *[32]byte) (plaintext []byte, err error) {
 	return gcm.Open(nil,
 		ciphertext[:gcm.NonceSize()],
 		ciphertext[gcm.NonceSize():],
-		nil,
+		additionalData,
 	)
 }
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"net"
+	"strings"
+)
+
+// ParseHostCIDR parses a string as a CIDR. A string which is a single IP address is parsed as a network only containing
+// that address.
+func ParseHostCIDR(input string) (cidr *net.IPNet, err error) {
+	if !strings.Contains(input, "/") {
+		ip := net.ParseIP(input)
+		if ip.To4() != nil {
+			_, cidr, err = net.ParseCIDR(input + "/32")
+		} else {
+			_, cidr, err = net.ParseCIDR(input + "/128")
+		}
+	} else {
+		_, cidr, err = net.ParseCIDR(input)
+	}
+
+	return cidr, err
+}
+
+// IsIPInNetworks checks if an IP is contained in any of the networks.
+func IsIPInNetworks(ip net.IP, networks []*net.IPNet) bool {
+	if ip == nil {
+		return false
+	}
+
+	for _, network := range networks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,6 +1,10 @@
+import { useCallback } from "react";
+
 import { useRemoteCall } from "@hooks/RemoteCall";
 import { getState } from "@services/State";
 
-export function useAutheliaState() {
-    return useRemoteCall(getState, []);
+export function useAutheliaState(redirectionURL?: string, requestMethod?: string) {
+    const fn = useCallback(() => getState(redirectionURL, requestMethod), [redirectionURL, requestMethod]);
+
+    return useRemoteCall(fn, []);
 }
@@ -1,3 +1,5 @@
+import queryString from "query-string";
+
 import { StatePath } from "@services/Api";
 import { Get } from "@services/Client";
 
@@ -12,6 +14,10 @@ export interface AutheliaState {
     authentication_level: AuthenticationLevel;
 }
 
-export async function getState(): Promise<AutheliaState> {
-    return Get<AutheliaState>(StatePath);
+// The authentication level is reported for the given redirection URL, which may require the second factor to be
+// performed again when it was completed too long ago.
+export async function getState(redirectionURL?: string, requestMethod?: string): Promise<AutheliaState> {
+    const query = queryString.stringify({ rd: redirectionURL, rm: requestMethod });
+
+    return Get<AutheliaState>(query ? `${StatePath}?${query}` : StatePath);
 }
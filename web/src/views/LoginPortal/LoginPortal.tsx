@@ -43,7 +43,7 @@ const LoginPortal = function (props: Props) {
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const redirector = useRedirector();
 
-    const [state, fetchState, , fetchStateError] = useAutheliaState();
+    const [state, fetchState, , fetchStateError] = useAutheliaState(redirectionURL, requestMethod);
     const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfo();
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
 